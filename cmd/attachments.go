@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,7 +12,6 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
-	"jet/internal/config"
 	"jet/internal/jira"
 )
 
@@ -38,21 +38,17 @@ Examples:
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ticketKey := args[0]
 
-		// Load configuration
-		cfg, err := config.Load()
+		client, err := newJiraClient()
 		if err != nil {
-			return fmt.Errorf("configuration error: %w", err)
+			return err
 		}
 
-		// Create JIRA client
-		client := jira.NewClient(cfg.URL, cfg.Email, cfg.Username, cfg.Token)
-
 		if len(attachmentUpload) > 0 {
-			return uploadAttachments(client, ticketKey, attachmentUpload)
+			return uploadAttachments(cmd.Context(), client, ticketKey, attachmentUpload)
 		}
 
 		// Fetch the ticket
-		issue, err := client.GetIssue(ticketKey)
+		issue, err := client.GetIssueContext(cmd.Context(), ticketKey)
 		if err != nil {
 			return err
 		}
@@ -70,7 +66,7 @@ Examples:
 		}
 
 		// Download attachments
-		return downloadAttachments(ticketKey, attachments, cfg)
+		return downloadAttachments(cmd.Context(), ticketKey, attachments, client)
 	},
 }
 
@@ -113,7 +109,7 @@ func listAttachments(ticketKey string, attachments []jira.Attachment) error {
 	return nil
 }
 
-func downloadAttachments(ticketKey string, attachments []jira.Attachment, cfg *config.Config) error {
+func downloadAttachments(ctx context.Context, ticketKey string, attachments []jira.Attachment, client *jira.Client) error {
 	// Determine which attachments to download
 	var indicesToDownload []int
 	if attachmentIndex != "" {
@@ -160,7 +156,7 @@ func downloadAttachments(ticketKey string, attachments []jira.Attachment, cfg *c
 		
 		fmt.Printf("%s %s...", yellow.Sprintf("⬇️  Downloading"), attachment.Filename)
 		
-		if err := downloadSingleAttachment(attachment, outputDir, cfg); err != nil {
+		if err := downloadSingleAttachment(ctx, attachment, outputDir, client); err != nil {
 			fmt.Printf(" ❌\n")
 			fmt.Printf("   Error: %v\n", err)
 			continue
@@ -173,29 +169,23 @@ func downloadAttachments(ticketKey string, attachments []jira.Attachment, cfg *c
 	return nil
 }
 
-func downloadSingleAttachment(attachment jira.Attachment, outputDir string, cfg *config.Config) error {
+func downloadSingleAttachment(ctx context.Context, attachment jira.Attachment, outputDir string, jiraClient *jira.Client) error {
 	if attachment.Content == "" {
 		return fmt.Errorf("no download URL available")
 	}
 
-	// Create HTTP client with authentication
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", attachment.Content, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", attachment.Content, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Add authentication headers
-	if cfg.Email != "" && cfg.Token != "" {
-		// Atlassian Cloud authentication
-		req.SetBasicAuth(cfg.Email, cfg.Token)
-	} else if cfg.Username != "" && cfg.Token != "" {
-		// Atlassian Server authentication
-		req.SetBasicAuth(cfg.Username, cfg.Token)
+	// Add authentication headers for the profile's auth strategy
+	if err := jiraClient.Authorize(req); err != nil {
+		return err
 	}
 
 	// Make the request
-	resp, err := client.Do(req)
+	resp, err := jiraClient.HTTPClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to download: %w", err)
 	}
@@ -222,7 +212,7 @@ func downloadSingleAttachment(attachment jira.Attachment, outputDir string, cfg
 	return nil
 }
 
-func uploadAttachments(client *jira.Client, ticketKey string, paths []string) error {
+func uploadAttachments(ctx context.Context, client *jira.Client, ticketKey string, paths []string) error {
 	for _, p := range paths {
 		info, err := os.Stat(p)
 		if err != nil {
@@ -243,7 +233,7 @@ func uploadAttachments(client *jira.Client, ticketKey string, paths []string) er
 		fmt.Printf("   %s %s\n", yellow.Sprint("•"), p)
 	}
 
-	uploaded, err := client.UploadAttachments(ticketKey, paths)
+	uploaded, err := client.UploadAttachmentsContext(ctx, ticketKey, paths)
 	if err != nil {
 		return err
 	}
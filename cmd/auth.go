@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"jet/internal/auth"
+	"jet/internal/config"
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage authentication for the active profile",
+	Long: `Manage authentication for the active profile.
+
+The strategy is chosen per profile with the auth key in ~/.jira_config:
+
+  auth = basic    # default: email/username + API token
+  auth = bearer   # Data Center personal access token in 'token'
+  auth = oauth2   # OAuth 2.0 (3LO); requires oauth_client_id and
+                  # oauth_client_secret, then 'jet auth login'
+
+OAuth tokens are stored in the secret store (token_store, default "file") and
+refreshed automatically; rotated refresh tokens are persisted on every refresh.`,
+}
+
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the authentication strategy of the active profile",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("configuration error: %w", err)
+		}
+		fmt.Printf("URL:  %s\n", cfg.URL)
+		fmt.Printf("Auth: %s\n", cfg.AuthMode())
+		if cfg.AuthMode() != "oauth2" {
+			return nil
+		}
+
+		o, err := auth.OAuth2FromConfig(cfg)
+		if err != nil {
+			return err
+		}
+		token, err := o.Store.Load()
+		if err != nil {
+			return err
+		}
+		if token == nil {
+			fmt.Println(color.YellowString("Not logged in. Run: jet auth login"))
+			return nil
+		}
+		if token.Valid() {
+			fmt.Printf("Access token valid until %s\n", token.Expiry.Local().Format(time.RFC1123))
+		} else {
+			fmt.Println("Access token expired; it will be refreshed on the next request.")
+		}
+		if token.RefreshToken == "" {
+			fmt.Println(color.YellowString("No refresh token stored (include offline_access in oauth_scopes)."))
+		}
+		return nil
+	},
+}
+
+var authLoginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Authorize jet with OAuth 2.0 (3LO) in the browser",
+	Long: `Authorize jet with OAuth 2.0 (3LO).
+
+Opens the Atlassian consent page, receives the authorization code on
+oauth_redirect_url (default ` + auth.DefaultRedirectURL + `), and stores the
+resulting tokens. The redirect URL must be registered as the callback URL of
+your OAuth app.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("configuration error: %w", err)
+		}
+		if cfg.AuthMode() != "oauth2" {
+			return fmt.Errorf("the active profile uses %s auth; set auth = oauth2 to use jet auth login", cfg.AuthMode())
+		}
+		o, err := auth.OAuth2FromConfig(cfg)
+		if err != nil {
+			return err
+		}
+		return runOAuthLogin(cmd.Context(), o)
+	},
+}
+
+func runOAuthLogin(ctx context.Context, o *auth.OAuth2) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	redirect, err := url.Parse(o.Config.RedirectURL)
+	if err != nil || redirect.Host == "" {
+		return fmt.Errorf("invalid oauth_redirect_url %q", o.Config.RedirectURL)
+	}
+
+	stateBytes := make([]byte, 16)
+	if _, err := rand.Read(stateBytes); err != nil {
+		return err
+	}
+	state := hex.EncodeToString(stateBytes)
+
+	listener, err := net.Listen("tcp", redirect.Host)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", redirect.Host, err)
+	}
+
+	type result struct {
+		code string
+		err  error
+	}
+	// Only the first callback counts; later ones must not block on the
+	// channel once nobody reads it.
+	results := make(chan result, 1)
+	send := func(res result) {
+		select {
+		case results <- res:
+		default:
+		}
+	}
+	path := redirect.Path
+	if path == "" {
+		path = "/"
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch {
+		case q.Get("state") != state:
+			// Not our authorization (a stray request, or a stale tab):
+			// keep waiting for the real callback.
+			http.Error(w, "state mismatch", http.StatusBadRequest)
+		case q.Get("error") != "":
+			http.Error(w, q.Get("error_description"), http.StatusBadRequest)
+			send(result{err: fmt.Errorf("authorization denied: %s", q.Get("error"))})
+		default:
+			fmt.Fprintln(w, "jet is authorized. You can close this window.")
+			send(result{code: q.Get("code")})
+		}
+	})
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go server.Serve(listener)
+	defer server.Close()
+
+	authURL := o.AuthCodeURL(state)
+	fmt.Println("Open this URL to authorize jet:")
+	fmt.Println()
+	fmt.Println("  " + authURL)
+	fmt.Println()
+	openBrowser(authURL)
+
+	var res result
+	select {
+	case res = <-results:
+	case <-time.After(5 * time.Minute):
+		return fmt.Errorf("timed out waiting for authorization")
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if res.err != nil {
+		return res.err
+	}
+
+	if _, err := o.Exchange(ctx, res.code); err != nil {
+		return err
+	}
+	fmt.Println(color.GreenString("Logged in."))
+
+	// 3LO API calls go through api.atlassian.com; show the URLs to configure.
+	resources, err := o.AccessibleResources(ctx)
+	if err == nil && len(resources) > 0 {
+		fmt.Println()
+		fmt.Println("Accessible sites (use the API URL as 'url' in your profile):")
+		for _, r := range resources {
+			fmt.Printf("  %-30s %s\n", r.Name, r.APIBaseURL("jira"))
+		}
+	}
+	return nil
+}
+
+// openBrowser tries to open url in the default browser, ignoring failures.
+func openBrowser(url string) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	_ = cmd.Start()
+}
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(authStatusCmd)
+	authCmd.AddCommand(authLoginCmd)
+}
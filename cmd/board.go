@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"jet/internal/jira"
+	"jet/internal/output"
+)
+
+var (
+	boardProject string
+	boardType    string
+	boardFormat  outputFlags
+)
+
+var boardCmd = &cobra.Command{
+	Use:   "board [BOARD]",
+	Short: "List boards, or show a board's current sprint",
+	Long: `Without an argument, list the boards you can see. With a board ID or name,
+show its active sprint grouped by status, its future sprints and the size of
+its backlog.
+
+Set 'board = ID' in your profile (or the [jira] section) of ~/.jira_config
+to make a board the default for 'jet sprint'.
+
+Examples:
+  jet board                        # All boards
+  jet board --project PROJ         # Boards of one project
+  jet board 42                     # Board 42's current sprint
+  jet board "Team Rocket" --format json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+
+		if len(args) == 0 {
+			boards, err := client.GetBoardsContext(ctx, jira.BoardFilter{ProjectKey: boardProject, Type: boardType})
+			if err != nil {
+				return fmt.Errorf("failed to fetch boards: %w", err)
+			}
+			if !boardFormat.readable() {
+				return output.Write(os.Stdout, boards, boardColumns, boardFormat.options())
+			}
+			fmt.Print(formatBoards(boards))
+			return nil
+		}
+
+		// A single board is a summary, not a list.
+		if opts := boardFormat.options(); opts.Format != output.Readable && (opts.Format != output.JSON || len(opts.Columns) > 0) {
+			return fmt.Errorf("a board's summary is only available as readable or json")
+		}
+
+		board, err := resolveBoard(ctx, client, args[0])
+		if err != nil {
+			return err
+		}
+		summary := boardSummary{Board: *board, Active: []sprintReport{}, Future: []jira.Sprint{}}
+
+		// Kanban boards have no sprints; the Agile API rejects the request.
+		if board.Type == "scrum" {
+			sprints, err := client.GetSprintsContext(ctx, board.ID, jira.SprintActive, jira.SprintFuture)
+			if err != nil {
+				return fmt.Errorf("failed to fetch sprints: %w", err)
+			}
+			for _, s := range sprints {
+				if s.State != jira.SprintActive {
+					summary.Future = append(summary.Future, s)
+					continue
+				}
+				issues, err := client.GetSprintIssuesContext(ctx, s.ID)
+				if err != nil {
+					return fmt.Errorf("failed to fetch issues of sprint %s: %w", s.Name, err)
+				}
+				summary.Active = append(summary.Active, buildSprintReport(s, issues))
+			}
+		}
+		backlog, err := client.GetBoardBacklogContext(ctx, board.ID)
+		if err != nil && board.Type == "scrum" {
+			return fmt.Errorf("failed to fetch backlog: %w", err)
+		}
+		// Kanban boards only have a backlog when it is enabled.
+		summary.Backlog = len(backlog)
+
+		if boardFormat.options().Format == output.JSON {
+			jsonData, err := json.MarshalIndent(summary, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format JSON: %w", err)
+			}
+			fmt.Println(string(jsonData))
+			return nil
+		}
+		fmt.Print(formatBoardSummary(summary, time.Now()))
+		return nil
+	},
+}
+
+// boardSummary is what `jet board BOARD` shows, also its JSON shape.
+type boardSummary struct {
+	Board   jira.Board     `json:"board"`
+	Active  []sprintReport `json:"activeSprints"`
+	Future  []jira.Sprint  `json:"futureSprints"`
+	Backlog int            `json:"backlogIssues"`
+}
+
+// boardColumns are the columns of `jet board` without an argument.
+var boardColumns = []output.Column[jira.Board]{
+	{Name: "id", Value: func(b jira.Board) string { return strconv.Itoa(b.ID) }},
+	{Name: "type", Value: func(b jira.Board) string { return b.Type }},
+	{Name: "project", Value: func(b jira.Board) string { return b.Location.ProjectKey }},
+	{Name: "name", Value: func(b jira.Board) string { return b.Name }},
+}
+
+func formatBoards(boards []jira.Board) string {
+	var sb strings.Builder
+	if len(boards) == 0 {
+		sb.WriteString("No boards found\n")
+		return sb.String()
+	}
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	colYellow.Fprintln(w, "ID\tTYPE\tPROJECT\tNAME")
+	for _, b := range boards {
+		project := b.Location.ProjectKey
+		if project == "" {
+			project = colGray.Sprint("-")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", colBlue.Sprint(b.ID), b.Type, project, b.Name)
+	}
+	w.Flush()
+	return sb.String()
+}
+
+func formatBoardSummary(s boardSummary, now time.Time) string {
+	var sb strings.Builder
+	colCyan.Fprintf(&sb, "📋 %s", s.Board.Name)
+	sb.WriteString(colGray.Sprintf(" (%s board %d", s.Board.Type, s.Board.ID))
+	if s.Board.Location.ProjectKey != "" {
+		sb.WriteString(colGray.Sprintf(", %s", s.Board.Location.ProjectKey))
+	}
+	sb.WriteString(colGray.Sprint(")\n"))
+	sb.WriteString(colGray.Sprint("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n"))
+
+	if s.Board.Type == "scrum" && len(s.Active) == 0 {
+		sb.WriteString("No active sprint\n")
+	}
+	for _, r := range s.Active {
+		sb.WriteString(formatSprintReport(r, now))
+		sb.WriteString("\n")
+	}
+
+	if len(s.Future) > 0 {
+		colYellow.Fprintln(&sb, "Upcoming sprints")
+		w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+		for _, f := range s.Future {
+			dates := colGray.Sprint("not planned")
+			if start, end := f.Start(), f.End(); !start.IsZero() && !end.IsZero() {
+				dates = fmt.Sprintf("%s → %s", start.Local().Format("Jan 2"), end.Local().Format("Jan 2"))
+			}
+			fmt.Fprintf(w, "  %d\t%s\t%s\n", f.ID, f.Name, dates)
+		}
+		w.Flush()
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(&sb, "Backlog: %d issue(s)\n", s.Backlog)
+	return sb.String()
+}
+
+func init() {
+	rootCmd.AddCommand(boardCmd)
+
+	boardCmd.Flags().StringVar(&boardProject, "project", "", "Only list boards of this project")
+	boardCmd.Flags().StringVar(&boardType, "type", "", "Only list boards of this type (scrum or kanban)")
+	addOutputFlags(boardCmd, &boardFormat, boardColumns)
+}
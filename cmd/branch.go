@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"jet/internal/config"
+	"jet/internal/git"
+	"jet/internal/jira"
+)
+
+var (
+	branchBase  string
+	branchPrint bool
+)
+
+var branchCmd = &cobra.Command{
+	Use:   "branch TICKET-KEY",
+	Short: "Create or switch to the git branch of a ticket",
+	Long: `Switch to the local branch of a ticket, creating it when there is none.
+
+A branch whose name contains the ticket key (in any case) is reused, so
+renaming a ticket or changing the template does not make a second branch.
+New branches are named with the branch_template setting of the [jet]
+section of ~/.jira_config, a Go template with the functions key, summary,
+type and project (the ticket's values), slug, lower and upper:
+
+  [jet]
+  branch_template = feature/{{lower key}}-{{slug summary}}
+
+The default is {{key}}-{{slug summary}}, e.g. PROJ-123-fix-login-timeout.
+Commands that take a ticket key (view, comment, start, close, shift, grab)
+default to the key of the current branch.
+
+Examples:
+  jet branch PROJ-123                  # Create or switch to the branch
+  jet branch PROJ-123 --base origin/main
+  jet branch PROJ-123 --print          # Only print the name`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		key := strings.ToUpper(args[0])
+		if !jira.IsIssueKey(key) {
+			return fmt.Errorf("%q is not a ticket key", args[0])
+		}
+
+		tmpl, err := config.BranchTemplate()
+		if err != nil {
+			return fmt.Errorf("failed to read config: %w", err)
+		}
+		if tmpl == "" {
+			tmpl = git.DefaultBranchTemplate
+		}
+
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+		issue, err := client.GetIssueContext(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to fetch %s: %w", key, err)
+		}
+		name, err := git.BranchName(tmpl, git.BranchInfo{
+			Key:     issue.Key,
+			Summary: issue.Fields.Summary,
+			Type:    issue.Fields.IssueType.Name,
+			Project: issue.Fields.Project.Key,
+		})
+		if err != nil {
+			return err
+		}
+		if branchPrint {
+			fmt.Println(name)
+			return nil
+		}
+
+		repo := git.Repo{}
+		if !repo.ValidBranchName(ctx, name) {
+			return fmt.Errorf("%q is not a valid branch name; check branch_template", name)
+		}
+		branches, err := repo.Branches(ctx)
+		if err != nil {
+			return err
+		}
+		if existing := ticketBranch(branches, issue.Key, name); existing != "" {
+			current, err := repo.CurrentBranch(ctx)
+			if err != nil {
+				return err
+			}
+			if current == existing {
+				fmt.Printf("Already on %s\n", colCyan.Sprint(existing))
+				return nil
+			}
+			if err := repo.Switch(ctx, existing); err != nil {
+				return err
+			}
+			fmt.Printf("%s Switched to %s\n", colGreen.Sprint("✓"), colCyan.Sprint(existing))
+			return nil
+		}
+
+		if err := repo.CreateBranch(ctx, name, branchBase); err != nil {
+			return err
+		}
+		fmt.Printf("%s Created and switched to %s\n", colGreen.Sprint("✓"), colCyan.Sprint(name))
+		return nil
+	},
+}
+
+// ticketBranch picks the local branch of the ticket key: the one named
+// name, or else the first that contains the key.
+func ticketBranch(branches []string, key, name string) string {
+	found := ""
+	for _, b := range branches {
+		if b == name {
+			return b
+		}
+		if found == "" {
+			for _, k := range jira.FindIssueKeys(strings.ToUpper(b)) {
+				if k == key {
+					found = b
+					break
+				}
+			}
+		}
+	}
+	return found
+}
+
+func init() {
+	rootCmd.AddCommand(branchCmd)
+
+	branchCmd.Flags().StringVar(&branchBase, "base", "", "Start a new branch from this commit or branch (default: HEAD)")
+	branchCmd.Flags().BoolVar(&branchPrint, "print", false, "Print the branch name without touching the repository")
+}
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"jet/internal/bulk"
+	"jet/internal/jira"
+)
+
+var (
+	bulkJQL         string
+	bulkDryRun      bool
+	bulkYes         bool
+	bulkConcurrency int
+	bulkLimit       int
+	bulkReport      string
+)
+
+var bulkCmd = &cobra.Command{
+	Use:   "bulk",
+	Short: "Change every ticket a JQL query matches",
+	Long: `Shift, assign, label or edit every ticket a JQL query matches.
+
+Each bulk command lists the matching tickets with what would change for each
+and asks for confirmation before changing anything. Tickets that already are
+as the change would leave them are skipped. Changes run a few tickets at a
+time; one ticket failing does not stop the others.
+
+The outcome for every ticket, failures included, is written as JSON to
+--report, or to ~/.jet/bulk/ by default.
+
+Examples:
+  jet bulk shift --jql "sprint in openSprints() AND status = 'In Review'" Done
+  jet bulk assign --jql "project = PROJ AND assignee is EMPTY" me
+  jet bulk assign --jql "assignee = jdoe AND resolution is EMPTY" none
+  jet bulk label add --jql "fixVersion = 2.3" release-2.3
+  jet bulk set-field --jql "parent = PROJ-100" "Story Points=3" --dry-run`,
+}
+
+var bulkShiftCmd = &cobra.Command{
+	Use:   "shift --jql QUERY STATUS",
+	Short: "Transition matching tickets to a status",
+	Long: `Transition every matching ticket to STATUS. As with jet shift, the status
+name is case-insensitive and may be part of a name.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+		return runBulk(cmd, client, bulk.Shift(args[0]))
+	},
+}
+
+var bulkAssignCmd = &cobra.Command{
+	Use:   "assign --jql QUERY USER",
+	Short: "Assign matching tickets to a user",
+	Long: `Assign every matching ticket to USER: "me", an account ID, username or
+email. Use "none" to unassign them.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+
+		var user *jira.UserRef
+		name := args[0]
+		if !strings.EqualFold(name, "none") {
+			u, err := client.ResolveUserContext(cmd.Context(), name)
+			if err != nil {
+				return err
+			}
+			user, name = u.Ref(), userName(u)
+		}
+		return runBulk(cmd, client, bulk.Assign(user, name))
+	},
+}
+
+var bulkLabelCmd = &cobra.Command{
+	Use:   "label",
+	Short: "Add or remove labels on matching tickets",
+}
+
+var bulkLabelAddCmd = &cobra.Command{
+	Use:   "add --jql QUERY LABEL...",
+	Short: "Add labels to matching tickets",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+		return runBulk(cmd, client, bulk.AddLabels(args...))
+	},
+}
+
+var bulkLabelRemoveCmd = &cobra.Command{
+	Use:   "remove --jql QUERY LABEL...",
+	Short: "Remove labels from matching tickets",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+		return runBulk(cmd, client, bulk.RemoveLabels(args...))
+	},
+}
+
+var bulkSetFieldCmd = &cobra.Command{
+	Use:   "set-field --jql QUERY NAME=VALUE...",
+	Short: "Set fields on matching tickets",
+	Long: `Set fields on every matching ticket. Fields are given by name or ID, as
+with jet edit --field; array fields such as labels take comma-separated
+values and replace the whole list.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+		reg, err := client.FieldRegistry(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("failed to load field list: %w", err)
+		}
+		fields, err := reg.ParseFieldValuesContext(cmd.Context(), args)
+		if err != nil {
+			return err
+		}
+		return runBulk(cmd, client, bulk.SetFields(fields, strings.Join(args, ", ")))
+	},
+}
+
+// runBulk finds the tickets for --jql, shows what change would do to them
+// and, once confirmed, applies it and writes the report.
+func runBulk(cmd *cobra.Command, client *jira.Client, change bulk.Change) error {
+	argsAccepted(cmd)
+
+	ctx := cmd.Context()
+	issues, more, err := bulk.Search(ctx, client, bulkJQL, bulkLimit)
+	if err != nil {
+		return err
+	}
+	if more {
+		return fmt.Errorf("the query matches more than %d tickets; narrow it or raise --limit", bulkLimit)
+	}
+	if len(issues) == 0 {
+		fmt.Println("No tickets match the query.")
+		return nil
+	}
+
+	plan := bulk.NewPlan(issues, change)
+	pending := bulk.Pending(plan)
+	printBulkPlan(plan, change)
+	if bulkDryRun {
+		fmt.Printf("\nDry run: %d of %d tickets would change.\n", pending, len(plan))
+		return nil
+	}
+	if pending == 0 {
+		fmt.Println("\nNothing to change.")
+		return nil
+	}
+
+	if !bulkYes {
+		if !stdinIsTerminal() {
+			return fmt.Errorf("refusing to change %d tickets without confirmation; pass --yes", pending)
+		}
+		fmt.Printf("\nChange %d tickets? [y/N]: ", pending)
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if answer != "y" && answer != "yes" {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	fmt.Println()
+	report := bulk.Run(ctx, client, change, plan, bulk.Options{
+		Concurrency: bulkConcurrency,
+		Progress: func(r bulk.Result) {
+			switch r.Outcome {
+			case bulk.Applied:
+				fmt.Printf("%s %s %s\n", colGreen.Sprint("✓"), colCyan.Sprint(r.Key), r.Change)
+			case bulk.Failed:
+				fmt.Printf("%s %s %s\n", colRed.Sprint("✗"), colCyan.Sprint(r.Key), colRed.Sprint(r.Error))
+			}
+		},
+	})
+	report.JQL = bulkJQL
+
+	path := bulkReport
+	if path == "" {
+		path = bulk.DefaultReportPath(report.Started)
+	}
+	saveErr := report.Save(path)
+
+	fmt.Printf("\n%s applied, %s skipped, %s failed\n",
+		colGreen.Sprint(report.Applied), colGray.Sprint(report.Skipped), colRed.Sprint(report.Failed))
+	if saveErr != nil {
+		return saveErr
+	}
+	fmt.Println(colGray.Sprint("Report written to " + path))
+	if report.Failed > 0 {
+		return fmt.Errorf("%d of %d changes failed", report.Failed, pending)
+	}
+	return nil
+}
+
+// printBulkPlan lists each ticket with what the change would do to it.
+func printBulkPlan(plan []bulk.Item, change bulk.Change) {
+	fmt.Printf("%s %s\n", colCyan.Sprintf("%d ticket(s) match;", len(plan)), change)
+	fmt.Println(colGray.Sprint(strings.Repeat("━", 74)))
+
+	keyWidth := 0
+	for _, item := range plan {
+		keyWidth = max(keyWidth, len(item.Issue.Key))
+	}
+	for _, item := range plan {
+		key := fmt.Sprintf("%-*s", keyWidth, item.Issue.Key)
+		summary := fmt.Sprintf("%-40s", truncateString(item.Issue.Fields.Summary, 40))
+		what := item.Change
+		if item.Skip {
+			what = colGray.Sprint("skip: " + what)
+		} else {
+			what = colYellow.Sprint(what)
+		}
+		fmt.Printf("%s  %s  %s\n", colCyan.Sprint(key), summary, what)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(bulkCmd)
+	bulkCmd.AddCommand(bulkShiftCmd, bulkAssignCmd, bulkLabelCmd, bulkSetFieldCmd)
+	bulkLabelCmd.AddCommand(bulkLabelAddCmd, bulkLabelRemoveCmd)
+
+	flags := bulkCmd.PersistentFlags()
+	flags.StringVar(&bulkJQL, "jql", "", "JQL query selecting the tickets to change (required)")
+	flags.BoolVar(&bulkDryRun, "dry-run", false, "Show what would change without changing anything")
+	flags.BoolVarP(&bulkYes, "yes", "y", false, "Change the tickets without asking")
+	flags.IntVar(&bulkConcurrency, "concurrency", bulk.DefaultConcurrency, "Tickets changed at once")
+	flags.IntVar(&bulkLimit, "limit", 500, "Refuse queries matching more tickets than this")
+	flags.StringVar(&bulkReport, "report", "", "Write the JSON report here (default: ~/.jet/bulk/<time>.json)")
+	bulkCmd.MarkPersistentFlagRequired("jql")
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/fatih/color"
+	"jet/internal/auth"
+	"jet/internal/config"
+	"jet/internal/confluence"
+	"jet/internal/httpclient"
+	"jet/internal/jira"
+)
+
+// newJiraClient builds a Jira client for the active profile using its
+// configured authentication strategy.
+func newJiraClient() (*jira.Client, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("configuration error: %w", err)
+	}
+	a, err := auth.FromConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("configuration error: %w", err)
+	}
+	client := jira.NewClientWithAuth(cfg.URL, a)
+	client.CacheDir = config.CacheDir()
+	return client, nil
+}
+
+// newConfluenceClient builds a Confluence client for the active profile.
+func newConfluenceClient() (*confluence.Client, error) {
+	cfg, err := config.LoadConfluence()
+	if err != nil {
+		return nil, fmt.Errorf("configuration error: %w", err)
+	}
+	a, err := auth.FromConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("configuration error: %w", err)
+	}
+	return confluence.NewClientWithAuth(cfg.URL, a), nil
+}
+
+// configureHTTP applies the retry settings from config to the shared HTTP
+// layer and reports retries on stderr.
+func configureHTTP() error {
+	rs, err := config.LoadRetrySettings()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	if rs.MaxRetries >= 0 {
+		httpclient.DefaultRetryPolicy.MaxRetries = rs.MaxRetries
+	}
+	if rs.MaxWait > 0 {
+		httpclient.DefaultRetryPolicy.MaxWait = rs.MaxWait
+	}
+	httpclient.DefaultRetryPolicy.Notify = printRetry
+	return nil
+}
+
+func printRetry(ev httpclient.RetryEvent) {
+	reason := fmt.Sprintf("HTTP %d", ev.Status)
+	if ev.Err != nil {
+		reason = ev.Err.Error()
+	} else if ev.Status == 429 {
+		reason = "rate limited"
+	}
+	if rl := ev.RateLimit.String(); rl != "" {
+		reason += " (" + rl + ")"
+	}
+	fmt.Fprintln(os.Stderr, color.YellowString("! %s, retrying in %s (%d/%d)", reason, ev.Wait.Round(100*time.Millisecond), ev.Attempt, ev.Max))
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"jet/internal/issueset"
+	"jet/internal/jira"
+)
+
+var (
+	cloneProject  string
+	cloneSummary  string
+	cloneSubtasks bool
+	cloneLinks    bool
+)
+
+var cloneCmd = &cobra.Command{
+	Use:   "clone TICKET-KEY",
+	Short: "Copy a ticket into a new one",
+	Long: `Create a copy of a ticket with its summary, description, type, labels,
+components and parent, linked to the original as "clones".
+
+With --subtasks the original's sub-tasks are copied under the new ticket,
+and with --links its links to other tickets are added to the new ticket
+too. If any part of the copy cannot be created, the tickets already created
+are deleted again.
+
+Examples:
+  jet clone PROJ-123
+  jet clone PROJ-123 --subtasks --links
+  jet clone PROJ-123 --project OTHER --summary "Port login fix to OTHER"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		argsAccepted(cmd)
+
+		key := strings.ToUpper(args[0])
+		ctx := cmd.Context()
+
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+		orig, err := client.GetIssueContext(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		set := issueset.Set{Parent: cloneFields(orig)}
+		if cloneProject != "" && !strings.EqualFold(cloneProject, orig.Fields.Project.Key) {
+			// A parent in another project would not take the clone.
+			set.Parent.Project = jira.ProjectRef{Key: strings.ToUpper(cloneProject)}
+			set.Parent.Parent = nil
+		}
+		if cloneSummary != "" {
+			set.Parent.Summary = cloneSummary
+		}
+
+		var subtasks []jira.Issue
+		if cloneSubtasks {
+			children, err := client.GetIssueChildrenContext(ctx, key)
+			if err != nil {
+				return err
+			}
+			for _, child := range children {
+				if child.Fields.IssueType.Subtask {
+					subtasks = append(subtasks, child)
+					fields := cloneFields(&child)
+					fields.Project = set.Parent.Project
+					set.Children = append(set.Children, fields)
+				}
+			}
+		}
+
+		created, err := issueset.Create(ctx, client, set)
+		if err != nil {
+			return err
+		}
+
+		// Each new ticket clones its original; with --links it also gets
+		// the original's links.
+		err = linkClone(ctx, client, created.Parent.Key, orig, cloneLinks)
+		for i, child := range created.Children {
+			if err == nil {
+				err = linkClone(ctx, client, child.Key, &subtasks[i], false)
+			}
+		}
+		if err != nil {
+			return issueset.Rollback(ctx, client, created, err)
+		}
+
+		fmt.Printf("Cloned %s as %s\n", colCyan.Sprint(key), colCyan.Sprint(created.Parent.Key))
+		fmt.Printf("Summary: %s\n", set.Parent.Summary)
+		for i, child := range created.Children {
+			fmt.Printf("  %s %s %s\n", colCyan.Sprint(child.Key), set.Children[i].Summary, colGray.Sprint("(from "+subtasks[i].Key+")"))
+		}
+		return nil
+	},
+}
+
+// cloneFields returns the create fields that copy issue.
+func cloneFields(issue *jira.Issue) jira.CreateIssueFields {
+	f := issue.Fields
+	fields := jira.CreateIssueFields{
+		Project:   jira.ProjectRef{Key: f.Project.Key},
+		Summary:   f.Summary,
+		IssueType: jira.IssueTypeRef{Name: f.IssueType.Name},
+		Labels:    f.Labels,
+	}
+	if jira.IsADF(f.Description) {
+		var doc jira.ADFNode
+		if err := json.Unmarshal(f.Description, &doc); err == nil {
+			fields.Description = &doc
+		}
+	} else if len(f.Description) > 0 {
+		var text string
+		if err := json.Unmarshal(f.Description, &text); err == nil && text != "" {
+			fields.Description = text
+		}
+	}
+	for _, c := range f.Components {
+		fields.Components = append(fields.Components, jira.NameRef{Name: c.Name})
+	}
+	if f.Parent != nil {
+		fields.Parent = &jira.IssueRef{Key: f.Parent.Key}
+	}
+	return fields
+}
+
+// linkClone links newKey to orig as its clone and, with links, to each
+// issue orig is linked to in the same direction.
+func linkClone(ctx context.Context, client *jira.Client, newKey string, orig *jira.Issue, links bool) error {
+	if err := client.LinkIssuesContext(ctx, newKey, orig.Key, "Cloners", false); err != nil {
+		return fmt.Errorf("failed to link %s to %s: %w", newKey, orig.Key, err)
+	}
+	if !links {
+		return nil
+	}
+	for _, link := range orig.Fields.IssueLinks {
+		var err error
+		switch {
+		case link.OutwardIssue != nil:
+			err = client.LinkIssuesContext(ctx, newKey, link.OutwardIssue.Key, link.Type.Name, false)
+		case link.InwardIssue != nil:
+			err = client.LinkIssuesContext(ctx, link.InwardIssue.Key, newKey, link.Type.Name, false)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to copy the %s link of %s: %w", link.Type.Name, orig.Key, err)
+		}
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(cloneCmd)
+	cloneCmd.Flags().StringVarP(&cloneProject, "project", "p", "", "Create the copy in this project")
+	cloneCmd.Flags().StringVarP(&cloneSummary, "summary", "s", "", "Summary of the copy (default: the original's)")
+	cloneCmd.Flags().BoolVar(&cloneSubtasks, "subtasks", false, "Copy the ticket's sub-tasks too")
+	cloneCmd.Flags().BoolVar(&cloneLinks, "links", false, "Copy the ticket's links to other tickets too")
+}
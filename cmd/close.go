@@ -2,38 +2,38 @@ package cmd
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
-	"jet/internal/config"
 	"jet/internal/jira"
 )
 
 var closeCmd = &cobra.Command{
-	Use:   "close TICKET-KEY",
+	Use:   "close [TICKET-KEY]",
 	Short: "Close a ticket (transition to Done/Closed)",
 	Long: `Close a JIRA ticket by transitioning it to "Done" or "Closed" status.
 
 This is a shortcut for: jet shift TICKET-KEY "Done"
 
+Without TICKET-KEY, the key is taken from the current git branch or recent
+commits.
+
 Example:
   jet close LX-123`,
-	Args: cobra.ExactArgs(1),
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ticketKey := args[0]
-
-		// Load configuration
-		cfg, err := config.Load()
+		ticketKey, err := ticketArg(cmd.Context(), args)
 		if err != nil {
-			return fmt.Errorf("configuration error: %w", err)
+			return err
 		}
 
-		// Create JIRA client
-		client := jira.NewClient(cfg.URL, cfg.Email, cfg.Username, cfg.Token)
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
 
 		// Get available transitions
-		transitions, err := client.GetTransitions(ticketKey)
+		transitions, err := client.GetTransitionsContext(cmd.Context(), ticketKey)
 		if err != nil {
 			return fmt.Errorf("failed to get transitions: %w", err)
 		}
@@ -43,21 +43,7 @@ Example:
 		}
 
 		// Find "Done", "Closed", or similar transition
-		var matchedTransition *jira.Transition
-		closeStatuses := []string{"done", "closed", "resolved", "complete"}
-
-		for i := range transitions {
-			transitionStatusLower := strings.ToLower(transitions[i].To.Name)
-			for _, status := range closeStatuses {
-				if transitionStatusLower == status || strings.Contains(transitionStatusLower, status) {
-					matchedTransition = &transitions[i]
-					break
-				}
-			}
-			if matchedTransition != nil {
-				break
-			}
-		}
+		matchedTransition := jira.MatchAnyTransition(transitions, jira.CloseStatuses)
 
 		if matchedTransition == nil {
 			// Show available transitions
@@ -73,7 +59,7 @@ Example:
 		}
 
 		// Perform the transition
-		if err := client.TransitionIssue(ticketKey, matchedTransition.ID); err != nil {
+		if err := client.TransitionIssueContext(cmd.Context(), ticketKey, matchedTransition.ID); err != nil {
 			return fmt.Errorf("failed to transition issue: %w", err)
 		}
 
@@ -7,23 +7,37 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
-	"jet/internal/config"
 	"jet/internal/jira"
 )
 
 var (
-	commentFile string
+	commentFile     string
+	commentMarkdown bool
 )
 
 var commentCmd = &cobra.Command{
-	Use:   "comment TICKET-KEY COMMENT",
+	Use:   "comment [TICKET-KEY] [COMMENT]",
 	Short: "Add a comment to a JIRA ticket",
 	Long: `Add a comment to a JIRA ticket.
 	
-You can provide the comment text directly as an argument or read from a file using --file.`,
-	Args: cobra.RangeArgs(1, 2),
+You can provide the comment text directly as an argument or read from a file using --file.
+Use --markdown to convert the comment from Markdown to rich text.
+Without TICKET-KEY, the key is taken from the current git branch or recent
+commits: jet comment "Fixed in the login service".`,
+	Args: cobra.RangeArgs(0, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ticketKey := args[0]
+		// A lone argument is the ticket when the text comes from --file,
+		// or when it looks like a key; otherwise it is the comment.
+		keyArgs := args
+		if len(args) == 2 || len(args) == 1 && (commentFile != "" || jira.IsIssueKey(args[0])) {
+			keyArgs, args = args[:1], args[1:]
+		} else {
+			keyArgs = nil
+		}
+		ticketKey, err := ticketArg(cmd.Context(), keyArgs)
+		if err != nil {
+			return err
+		}
 		var commentText string
 
 		// Get comment text from file or argument
@@ -43,8 +57,8 @@ You can provide the comment text directly as an argument or read from a file usi
 				}
 				commentText = strings.TrimSpace(string(content))
 			}
-		} else if len(args) == 2 {
-			commentText = args[1]
+		} else if len(args) == 1 {
+			commentText = args[0]
 		} else {
 			return fmt.Errorf("comment text required: provide as argument or use --file")
 		}
@@ -53,17 +67,18 @@ You can provide the comment text directly as an argument or read from a file usi
 			return fmt.Errorf("comment text cannot be empty")
 		}
 
-		// Load configuration
-		cfg, err := config.Load()
+		client, err := newJiraClient()
 		if err != nil {
-			return fmt.Errorf("configuration error: %w", err)
+			return err
 		}
 
-		// Create JIRA client
-		client := jira.NewClient(cfg.URL, cfg.Email, cfg.Username, cfg.Token)
-
 		// Add the comment
-		if err := client.AddComment(ticketKey, commentText); err != nil {
+		if commentMarkdown {
+			err = client.AddCommentADFContext(cmd.Context(), ticketKey, jira.MarkdownToADF(commentText))
+		} else {
+			err = client.AddCommentContext(cmd.Context(), ticketKey, commentText)
+		}
+		if err != nil {
 			return err
 		}
 
@@ -76,4 +91,5 @@ func init() {
 	rootCmd.AddCommand(commentCmd)
 	
 	commentCmd.Flags().StringVarP(&commentFile, "file", "f", "", "Read comment from file (use '-' for stdin)")
+	commentCmd.Flags().BoolVar(&commentMarkdown, "markdown", false, "Convert the comment from Markdown to rich text (uses the v3 API)")
 }
\ No newline at end of file
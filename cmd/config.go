@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"jet/internal/config"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect and manage jet configuration",
+}
+
+var configProfilesCmd = &cobra.Command{
+	Use:   "profiles",
+	Short: "Manage named configuration profiles",
+	Long: `Manage named profiles stored as [profile NAME] sections in ~/.jira_config.
+
+Each profile holds url, email/username and token for Jira, plus optional
+confluence_url, confluence_email, confluence_username and confluence_token
+keys (defaulting to the Jira values):
+
+  [profile work]
+  url = https://yourcompany.atlassian.net
+  email = you@company.com
+  token = ...
+
+  [profile onprem]
+  url = https://jira.internal.example.com
+  username = you
+  token = ...
+
+The active profile is chosen by --profile, then JET_PROFILE, then the
+default_profile set with 'jet config profiles use NAME'. Without any of these
+the [jira] and [confluence] sections are used. Run with no subcommand to list
+profiles.`,
+	RunE: func(cmd *cobra.Command, args []string) error { return runProfilesList() },
+}
+
+var configProfilesListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List configured profiles",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runProfilesList()
+	},
+}
+
+var configProfilesUseCmd = &cobra.Command{
+	Use:   "use NAME",
+	Short: "Set the default profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.SetDefaultProfile(args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("Default profile set to %s\n", args[0])
+		return nil
+	},
+}
+
+var configProfilesShowCmd = &cobra.Command{
+	Use:   "show [NAME]",
+	Short: "Show a profile's settings (defaults to the active profile)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 1 {
+			config.SelectProfile(args[0])
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("configuration error: %w", err)
+		}
+
+		name := cfg.Profile
+		if name == "" {
+			name = "(none: [jira] section / environment)"
+		}
+		bold := color.New(color.Bold)
+		bold.Printf("Profile: %s\n", name)
+		printConfig("Jira", cfg)
+
+		if ccfg, err := config.LoadConfluence(); err == nil {
+			printConfig("Confluence", ccfg)
+		}
+		return nil
+	},
+}
+
+func printConfig(title string, cfg *config.Config) {
+	fmt.Printf("\n%s\n", color.New(color.Bold).Sprint(title))
+	fmt.Printf("  URL:      %s\n", cfg.URL)
+	fmt.Printf("  Auth:     %s\n", cfg.AuthMode())
+	if cfg.Email != "" {
+		fmt.Printf("  Email:    %s\n", cfg.Email)
+	}
+	if cfg.Username != "" {
+		fmt.Printf("  Username: %s\n", cfg.Username)
+	}
+	switch {
+	case cfg.AuthMode() == "oauth2":
+		fmt.Printf("  Client:   %s (tokens via 'jet auth login')\n", cfg.OAuth.ClientID)
+	case cfg.TokenCommand != "":
+		fmt.Printf("  Token:    %s (from token_command: %s)\n", maskToken(cfg.Token), cfg.TokenCommand)
+	case cfg.TokenStore != "":
+		fmt.Printf("  Token:    %s (from %s secret store)\n", maskToken(cfg.Token), cfg.TokenStore)
+	default:
+		fmt.Printf("  Token:    %s\n", maskToken(cfg.Token))
+	}
+}
+
+func runProfilesList() error {
+	names, err := config.ListProfiles()
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	if len(names) == 0 {
+		fmt.Println("No profiles configured. Add a [profile NAME] section to ~/.jira_config.")
+		return nil
+	}
+
+	active, _ := config.ActiveProfile()
+	source := config.ActiveProfileSource()
+	color.New(color.Bold).Printf("Profiles (%d)\n", len(names))
+	for _, name := range names {
+		if strings.EqualFold(name, active) {
+			fmt.Printf("%s %s %s\n", color.GreenString("*"), name, color.New(color.Faint).Sprintf("(active via %s)", source))
+		} else {
+			fmt.Printf("  %s\n", name)
+		}
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configProfilesCmd)
+	configProfilesCmd.AddCommand(configProfilesListCmd)
+	configProfilesCmd.AddCommand(configProfilesUseCmd)
+	configProfilesCmd.AddCommand(configProfilesShowCmd)
+}
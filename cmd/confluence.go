@@ -10,7 +10,6 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
-	"jet/internal/config"
 	"jet/internal/confluence"
 )
 
@@ -56,17 +55,13 @@ You can find the page ID in the URL when viewing a page in Confluence:
 			}
 		}
 
-		// Load configuration
-		cfg, err := config.LoadConfluence()
+		client, err := newConfluenceClient()
 		if err != nil {
-			return fmt.Errorf("configuration error: %w", err)
+			return err
 		}
 
-		// Create Confluence client
-		client := confluence.NewClient(cfg.URL, cfg.Email, cfg.Username, cfg.Token)
-
 		// Fetch the page
-		page, err := client.GetPage(pageID)
+		page, err := client.GetPageContext(cmd.Context(), pageID)
 		if err != nil {
 			return err
 		}
@@ -121,23 +116,19 @@ Use --space to limit search to a specific space.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		query := args[0]
 
-		// Load configuration
-		cfg, err := config.LoadConfluence()
+		client, err := newConfluenceClient()
 		if err != nil {
-			return fmt.Errorf("configuration error: %w", err)
+			return err
 		}
 
-		// Create Confluence client
-		client := confluence.NewClient(cfg.URL, cfg.Email, cfg.Username, cfg.Token)
-
 		// Perform search
 		var results *confluence.SearchResponse
 		if conSearchSpace != "" {
 			// Build CQL with space filter and text search
 			cql := fmt.Sprintf("type=page AND space=\"%s\" AND text~\"%s\"", confluence.EscapeString(conSearchSpace), confluence.EscapeString(query))
-			results, err = client.SearchPages(cql, conSearchLimit)
+			results, err = client.SearchPagesContext(cmd.Context(), cql, conSearchLimit)
 		} else {
-			results, err = client.SearchByText(query, conSearchLimit)
+			results, err = client.SearchByTextContext(cmd.Context(), query, conSearchLimit)
 		}
 
 		if err != nil {
@@ -349,10 +340,10 @@ Content should be in Confluence storage format (HTML-like format).`,
 			return fmt.Errorf("space ID or key is required (use --space flag)")
 		}
 
-		// Load configuration
-		cfg, err := config.LoadConfluence()
+		// Create Confluence client
+		client, err := newConfluenceClient()
 		if err != nil {
-			return fmt.Errorf("configuration error: %w", err)
+			return err
 		}
 
 		// Read content from file or stdin
@@ -376,15 +367,12 @@ Content should be in Confluence storage format (HTML-like format).`,
 			return fmt.Errorf("content cannot be empty")
 		}
 
-		// Create Confluence client
-		client := confluence.NewClient(cfg.URL, cfg.Email, cfg.Username, cfg.Token)
-
 		// If space is a key (not numeric), convert to ID
 		spaceID := conCreateSpace
 		// Check if it's not all digits - if so, it's a space key
 		if !regexp.MustCompile(`^\d+$`).MatchString(conCreateSpace) {
 			fmt.Printf("Looking up space ID for key: %s\n", conCreateSpace)
-			space, err := client.GetSpace(conCreateSpace)
+			space, err := client.GetSpaceContext(cmd.Context(), conCreateSpace)
 			if err != nil {
 				return fmt.Errorf("failed to get space: %w", err)
 			}
@@ -393,7 +381,7 @@ Content should be in Confluence storage format (HTML-like format).`,
 		}
 
 		// Create the page
-		page, err := client.CreatePage(spaceID, title, content, conCreateParent)
+		page, err := client.CreatePageContext(cmd.Context(), spaceID, title, content, conCreateParent)
 		if err != nil {
 			return err
 		}
@@ -461,17 +449,13 @@ Use "jet con convert" to convert Markdown to storage format.`,
 			return fmt.Errorf("no update fields specified. Use --title, --content-file, or --parent")
 		}
 
-		// Load configuration
-		cfg, err := config.LoadConfluence()
+		client, err := newConfluenceClient()
 		if err != nil {
-			return fmt.Errorf("configuration error: %w", err)
+			return err
 		}
 
-		// Create Confluence client
-		client := confluence.NewClient(cfg.URL, cfg.Email, cfg.Username, cfg.Token)
-
 		// Get current page to retrieve version number and current values
-		currentPage, err := client.GetPage(pageID)
+		currentPage, err := client.GetPageContext(cmd.Context(), pageID)
 		if err != nil {
 			return err
 		}
@@ -519,7 +503,7 @@ Use "jet con convert" to convert Markdown to storage format.`,
 		}
 
 		// Update the page
-		updatedPage, err := client.UpdatePage(pageID, title, content, currentPage.SpaceID, version, parentID, conUpdateVersionMessage)
+		updatedPage, err := client.UpdatePageContext(cmd.Context(), pageID, title, content, currentPage.SpaceID, version, parentID, conUpdateVersionMessage)
 		if err != nil {
 			return err
 		}
@@ -577,17 +561,13 @@ Examples:
 			}
 		}
 
-		// Load configuration
-		cfg, err := config.LoadConfluence()
+		client, err := newConfluenceClient()
 		if err != nil {
-			return fmt.Errorf("configuration error: %w", err)
+			return err
 		}
 
-		// Create Confluence client
-		client := confluence.NewClient(cfg.URL, cfg.Email, cfg.Username, cfg.Token)
-
 		// Get child pages
-		childrenResp, err := client.GetChildPages(pageID, conChildrenLimit)
+		childrenResp, err := client.GetChildPagesContext(cmd.Context(), pageID, conChildrenLimit)
 		if err != nil {
 			return err
 		}
@@ -1,14 +1,20 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"jet/internal/config"
+	"jet/internal/issueset"
 	"jet/internal/jira"
+	"jet/internal/templates"
 )
 
 var (
@@ -18,6 +24,16 @@ var (
 	createDescFile    string
 	createIssueType   string
 	createEpic        string
+	createMarkdown    bool
+	createFields      []string
+	createPriority    string
+	createLabels      []string
+	createComponents  []string
+	createAssignee    string
+	createFixVersions []string
+	createNoInput     bool
+	createTemplate    string
+	createVars        []string
 )
 
 var createCmd = &cobra.Command{
@@ -33,8 +49,35 @@ Optional fields:
   --description: Ticket description
   --description-file: Read description from file
   --type: Issue type (default: Story)
-  --epic: Epic key to link this ticket to`,
+  --epic: Epic key to link this ticket to
+  --markdown: Treat the description as Markdown and send it as rich text
+  --priority: Priority name (e.g., High)
+  --labels: Comma-separated labels
+  --components: Comma-separated component names
+  --fix-version: Fix version name (repeatable or comma-separated)
+  --assignee: "me", an account ID, username or email
+  --field: Set any field by name or ID, e.g. --field "Story Points=5" (repeatable)
+  --template: Start from a template in ~/.jet/templates/ (see jet templates)
+  --var: Set a template variable as NAME=VALUE (repeatable)
+
+The project's create screen is checked before submitting: priorities,
+components and versions must be allowed values (unique prefixes are accepted),
+and required fields without a default must be set. When run in a terminal,
+jet prompts for missing required fields; use --no-input to fail instead.
+
+A template fills in the ticket's fields, and may add children to create
+under it in the same go; flags given as well override the template's
+fields. If any ticket of a template cannot be created, those already
+created are deleted again.
+
+Examples:
+  jet create -p PROJ -s "Fix login" --type Bug --labels auth
+  jet create --template bug --var component=api --var title="Login fails"`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if createTemplate != "" {
+			return runCreateTemplate(cmd)
+		}
+
 		// Validate required fields
 		if createProject == "" {
 			return fmt.Errorf("project key is required (use --project)")
@@ -43,25 +86,9 @@ Optional fields:
 			return fmt.Errorf("summary is required (use --summary)")
 		}
 
-		// Handle description from file
-		description := createDescription
-		if createDescFile != "" {
-			var content []byte
-			var err error
-			if createDescFile == "-" {
-				// Read from stdin
-				content, err = io.ReadAll(os.Stdin)
-				if err != nil {
-					return fmt.Errorf("failed to read from stdin: %w", err)
-				}
-			} else {
-				// Read from file
-				content, err = os.ReadFile(createDescFile)
-				if err != nil {
-					return fmt.Errorf("failed to read description file: %w", err)
-				}
-			}
-			description = strings.TrimSpace(string(content))
+		description, err := createDescriptionFlag()
+		if err != nil {
+			return err
 		}
 
 		// Set default issue type if not specified
@@ -70,17 +97,37 @@ Optional fields:
 			issueType = "Story"
 		}
 
-		// Load configuration
-		cfg, err := config.Load()
+		client, err := newJiraClient()
 		if err != nil {
-			return fmt.Errorf("configuration error: %w", err)
+			return err
+		}
+
+		spec := templates.Issue{
+			Project:     createProject,
+			Type:        issueType,
+			Parent:      createEpic,
+			Summary:     createSummary,
+			Description: description,
+			Markdown:    createMarkdown,
+			Priority:    createPriority,
+			Labels:      createLabels,
+			Components:  createComponents,
+			FixVersions: createFixVersions,
+			Assignee:    createAssignee,
+		}
+		fields, err := buildCreateFields(cmd.Context(), client, spec, createFields)
+		if err != nil {
+			return err
 		}
 
-		// Create JIRA client
-		client := jira.NewClient(cfg.URL, cfg.Email, cfg.Username, cfg.Token)
+		// Check the fields against the project's create screen
+		interactive := !createNoInput && createDescFile != "-" && stdinIsTerminal()
+		if err := checkCreateMeta(cmd.Context(), client, &fields, interactive); err != nil {
+			return err
+		}
 
 		// Create the ticket
-		issue, err := client.CreateIssue(createProject, createSummary, description, issueType, createEpic)
+		issue, err := client.CreateIssueWithFieldsContext(cmd.Context(), fields)
 		if err != nil {
 			return err
 		}
@@ -95,17 +142,311 @@ Optional fields:
 	},
 }
 
+// createDescriptionFlag returns the description from --description or
+// --description-file, where "-" reads stdin.
+func createDescriptionFlag() (string, error) {
+	if createDescFile == "" {
+		return createDescription, nil
+	}
+	var content []byte
+	var err error
+	if createDescFile == "-" {
+		content, err = io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf("failed to read from stdin: %w", err)
+		}
+	} else {
+		content, err = os.ReadFile(createDescFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read description file: %w", err)
+		}
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+// buildCreateFields turns spec into create fields, resolving its assignee
+// and looking up fieldArgs (NAME=VALUE, as for --field) by name.
+func buildCreateFields(ctx context.Context, client *jira.Client, spec templates.Issue, fieldArgs []string) (jira.CreateIssueFields, error) {
+	fields := jira.CreateIssueFields{
+		Project:     jira.ProjectRef{Key: spec.Project},
+		Summary:     spec.Summary,
+		IssueType:   jira.IssueTypeRef{Name: spec.Type},
+		Labels:      spec.Labels,
+	}
+	if spec.Description != "" {
+		fields.Description = spec.Description
+	}
+	if spec.Markdown {
+		var doc *jira.ADFNode
+		if spec.Description != "" {
+			doc = jira.MarkdownToADF(spec.Description)
+		}
+		fields.Description = doc
+	}
+	if spec.Parent != "" {
+		fields.Parent = &jira.IssueRef{Key: spec.Parent}
+	}
+	if spec.Priority != "" {
+		fields.Priority = &jira.NameRef{Name: spec.Priority}
+	}
+	for _, name := range spec.Components {
+		fields.Components = append(fields.Components, jira.NameRef{Name: name})
+	}
+	for _, name := range spec.FixVersions {
+		fields.FixVersions = append(fields.FixVersions, jira.NameRef{Name: name})
+	}
+	if spec.Assignee != "" {
+		var err error
+		if fields.Assignee, err = resolveAssignee(ctx, client, spec.Assignee); err != nil {
+			return fields, err
+		}
+	}
+	if len(fieldArgs) > 0 {
+		reg, err := client.FieldRegistry(ctx)
+		if err != nil {
+			return fields, fmt.Errorf("failed to load field list: %w", err)
+		}
+		if fields.Custom, err = reg.ParseFieldValuesContext(ctx, fieldArgs); err != nil {
+			return fields, err
+		}
+	}
+	return fields, nil
+}
+
+// runCreateTemplate creates the ticket of --template, and its children,
+// with the flags given on top.
+func runCreateTemplate(cmd *cobra.Command) error {
+	argsAccepted(cmd)
+
+	tmpl, err := templates.Load(createTemplate)
+	if err != nil {
+		return err
+	}
+	vars, err := templates.ParseVars(createVars)
+	if err != nil {
+		return err
+	}
+	if tmpl, err = tmpl.Expand(vars); err != nil {
+		return err
+	}
+
+	spec := tmpl.Issue
+	flags := cmd.Flags()
+	for name, apply := range map[string]func(){
+		"project":     func() { spec.Project = createProject },
+		"summary":     func() { spec.Summary = createSummary },
+		"description": func() { spec.Description = createDescription },
+		"type":        func() { spec.Type = createIssueType },
+		"epic":        func() { spec.Parent = createEpic },
+		"markdown":    func() { spec.Markdown = createMarkdown },
+		"priority":    func() { spec.Priority = createPriority },
+		"labels":      func() { spec.Labels = createLabels },
+		"components":  func() { spec.Components = createComponents },
+		"fix-version": func() { spec.FixVersions = createFixVersions },
+		"assignee":    func() { spec.Assignee = createAssignee },
+	} {
+		if flags.Changed(name) {
+			apply()
+		}
+	}
+	if createDescFile != "" {
+		if spec.Description, err = createDescriptionFlag(); err != nil {
+			return err
+		}
+	}
+	if spec.Type == "" {
+		spec.Type = "Story"
+	}
+	if spec.Project == "" {
+		return fmt.Errorf("template %s sets no project (use --project)", tmpl.Name)
+	}
+	if spec.Summary == "" {
+		return fmt.Errorf("template %s sets no summary (use --summary)", tmpl.Name)
+	}
+
+	client, err := newJiraClient()
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+	interactive := !createNoInput && createDescFile != "-" && stdinIsTerminal()
+
+	set := issueset.Set{}
+	if set.Parent, err = buildCreateFields(ctx, client, spec, append(spec.FieldArgs(), createFields...)); err != nil {
+		return err
+	}
+	if err := checkCreateMeta(ctx, client, &set.Parent, interactive); err != nil {
+		return err
+	}
+	for _, child := range tmpl.Children {
+		if child.Project == "" {
+			child.Project = spec.Project
+		}
+		if child.Type == "" {
+			child.Type = "Sub-task"
+		}
+		fields, err := buildCreateFields(ctx, client, child, child.FieldArgs())
+		if err != nil {
+			return err
+		}
+		// The set links each child to the parent once that exists.
+		if err := checkCreateMeta(ctx, client, &fields, interactive, "parent"); err != nil {
+			return fmt.Errorf("%q: %w", child.Summary, err)
+		}
+		set.Children = append(set.Children, fields)
+	}
+
+	created, err := issueset.Create(ctx, client, set)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Ticket created successfully: %s\n", created.Parent.Key)
+	fmt.Printf("Summary: %s\n", set.Parent.Summary)
+	if spec.Parent != "" {
+		fmt.Printf("Linked to epic: %s\n", spec.Parent)
+	}
+	for i, child := range created.Children {
+		fmt.Printf("  %s %s\n", colCyan.Sprint(child.Key), set.Children[i].Summary)
+	}
+	return nil
+}
+
+// checkCreateMeta validates fields against the create screen of the project
+// and issue type, normalizing allowed values and filling in missing required
+// fields by prompting when interactive. When the metadata cannot be read
+// (e.g. on restricted servers) validation is skipped with a warning. Fields
+// in filled are set by the caller later and never count as missing.
+func checkCreateMeta(ctx context.Context, client *jira.Client, fields *jira.CreateIssueFields, interactive bool, filled ...string) error {
+	meta, err := client.GetCreateMetaContext(ctx, fields.Project.Key, fields.IssueType.Name)
+	var apiErr *jira.APIError
+	if errors.As(err, &apiErr) {
+		fmt.Fprintf(os.Stderr, "Warning: could not read create metadata, skipping validation: %v\n", err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := meta.Normalize(fields); err != nil {
+		return err
+	}
+	missing, err := meta.Missing(*fields)
+	if err != nil {
+		return err
+	}
+	missing = slices.DeleteFunc(missing, func(f jira.CreateMetaField) bool {
+		return slices.Contains(filled, f.FieldID)
+	})
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if !interactive {
+		var hints []string
+		for _, f := range missing {
+			hints = append(hints, fmt.Sprintf("%s (%s)", f.Name, createFieldFlag(f)))
+		}
+		return fmt.Errorf("missing required fields for %s %s: %s", meta.Project, meta.IssueType.Name, strings.Join(hints, ", "))
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	for _, f := range missing {
+		value, err := promptCreateField(ctx, client, reader, f)
+		if err != nil {
+			return err
+		}
+		if fields.Custom == nil {
+			fields.Custom = make(map[string]interface{})
+		}
+		fields.Custom[f.FieldID] = value
+	}
+	fmt.Println()
+	return nil
+}
+
+// resolveAssignee turns "me", an account ID, username or email into a user
+// reference. Searches must match exactly one user.
+func resolveAssignee(ctx context.Context, client *jira.Client, who string) (*jira.UserRef, error) {
+	user, err := client.ResolveUserContext(ctx, who)
+	if err != nil {
+		return nil, fmt.Errorf("invalid assignee: %w", err)
+	}
+	return user.Ref(), nil
+}
+
+// completeCreateField completes allowed values of a create-screen field from
+// the metadata of the --project and --type being typed. Comma-separated
+// flags complete their last item.
+func completeCreateField(fieldID string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if createProject == "" {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		config.SelectProfile(profileFlag)
+		client, err := newJiraClient()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		done, last := "", toComplete
+		if i := strings.LastIndex(toComplete, ","); i >= 0 {
+			done, last = toComplete[:i+1], toComplete[i+1:]
+		}
+
+		var candidates []string
+		if fieldID == "issuetype" {
+			types, err := client.GetCreateMetaIssueTypesContext(ctx, createProject)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			for _, t := range types {
+				if strings.HasPrefix(strings.ToLower(t.Name), strings.ToLower(last)) {
+					candidates = append(candidates, t.Name)
+				}
+			}
+		} else {
+			meta, err := client.GetCreateMetaContext(ctx, createProject, createIssueType)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			if f := meta.Field(fieldID); f != nil {
+				candidates = f.Complete(last)
+			}
+		}
+		for i := range candidates {
+			candidates[i] = done + candidates[i]
+		}
+		return candidates, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(createCmd)
 	
-	createCmd.Flags().StringVarP(&createProject, "project", "p", "", "Project key (required)")
-	createCmd.Flags().StringVarP(&createSummary, "summary", "s", "", "Ticket summary/title (required)")
+	createCmd.Flags().StringVarP(&createProject, "project", "p", "", "Project key (required unless the template sets it)")
+	createCmd.Flags().StringVarP(&createSummary, "summary", "s", "", "Ticket summary/title (required unless the template sets it)")
 	createCmd.Flags().StringVarP(&createDescription, "description", "d", "", "Ticket description")
 	createCmd.Flags().StringVar(&createDescFile, "description-file", "", "Read description from file (use '-' for stdin)")
 	createCmd.Flags().StringVarP(&createIssueType, "type", "t", "Story", "Issue type")
 	createCmd.Flags().StringVarP(&createEpic, "epic", "e", "", "Epic key to link this ticket to")
-	
-	// Mark required flags
-	createCmd.MarkFlagRequired("project")
-	createCmd.MarkFlagRequired("summary")
+	createCmd.Flags().BoolVar(&createMarkdown, "markdown", false, "Convert the description from Markdown to rich text (uses the v3 API)")
+	createCmd.Flags().StringArrayVar(&createFields, "field", nil, "Set a field by name or ID as NAME=VALUE (repeatable)")
+	createCmd.Flags().StringVar(&createPriority, "priority", "", "Priority name")
+	createCmd.Flags().StringSliceVar(&createLabels, "labels", nil, "Comma-separated labels")
+	createCmd.Flags().StringSliceVar(&createComponents, "components", nil, "Comma-separated component names")
+	createCmd.Flags().StringVar(&createAssignee, "assignee", "", "Assignee: me, account ID, username or email")
+	createCmd.Flags().StringSliceVar(&createFixVersions, "fix-version", nil, "Fix version name (repeatable)")
+	createCmd.Flags().BoolVar(&createNoInput, "no-input", false, "Never prompt; fail when required fields are missing")
+	createCmd.Flags().StringVar(&createTemplate, "template", "", "Create from a template in ~/.jet/templates/")
+	createCmd.Flags().StringArrayVar(&createVars, "var", nil, "Set a template variable as NAME=VALUE (repeatable)")
+
+	createCmd.RegisterFlagCompletionFunc("type", completeCreateField("issuetype"))
+	createCmd.RegisterFlagCompletionFunc("priority", completeCreateField("priority"))
+	createCmd.RegisterFlagCompletionFunc("components", completeCreateField("components"))
+	createCmd.RegisterFlagCompletionFunc("fix-version", completeCreateField("fixVersions"))
+	createCmd.RegisterFlagCompletionFunc("template", completeTemplates)
 }
\ No newline at end of file
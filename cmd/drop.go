@@ -4,8 +4,6 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
-	"jet/internal/config"
-	"jet/internal/jira"
 )
 
 var dropCmd = &cobra.Command{
@@ -18,22 +16,18 @@ This is a convenient shortcut for unassigning tickets that are currently assigne
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ticketKey := args[0]
 
-		// Load configuration
-		cfg, err := config.Load()
+		client, err := newJiraClient()
 		if err != nil {
-			return fmt.Errorf("configuration error: %w", err)
+			return err
 		}
 
-		// Create JIRA client
-		client := jira.NewClient(cfg.URL, cfg.Email, cfg.Username, cfg.Token)
-
 		// Prepare fields to update - set assignee to null to unassign
 		fields := map[string]interface{}{
 			"assignee": nil,
 		}
 
 		// Update the ticket
-		if err := client.UpdateIssue(ticketKey, fields); err != nil {
+		if err := client.UpdateIssueContext(cmd.Context(), ticketKey, fields); err != nil {
 			return err
 		}
 
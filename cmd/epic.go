@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -9,12 +8,12 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
-	"jet/internal/config"
 	"jet/internal/jira"
+	"jet/internal/output"
 )
 
 var (
-	epicFormat string
+	epicFormat outputFlags
 	epicOutput string
 	showAllTickets bool
 )
@@ -42,14 +41,12 @@ Examples:
 			}
 		}
 
-		cfg, err := config.Load()
+		client, err := newJiraClient()
 		if err != nil {
-			return fmt.Errorf("configuration error: %w", err)
+			return err
 		}
 
-		client := jira.NewClient(cfg.URL, cfg.Email, cfg.Username, cfg.Token)
-
-		children, err := client.GetEpicChildren(epicKey)
+		children, err := client.GetEpicChildrenContext(cmd.Context(), epicKey)
 		if err != nil {
 			return fmt.Errorf("failed to fetch epic children: %w", err)
 		}
@@ -66,7 +63,7 @@ Examples:
 			children = filteredChildren
 		}
 
-		if len(children) == 0 {
+		if len(children) == 0 && epicFormat.readable() {
 			if showAllTickets {
 				fmt.Printf("No child tickets found for epic %s\n", epicKey)
 			} else {
@@ -75,25 +72,21 @@ Examples:
 			return nil
 		}
 
-		var output string
-		if epicFormat == "json" {
-			jsonData, err := json.MarshalIndent(children, "", "  ")
-			if err != nil {
-				return fmt.Errorf("failed to format JSON: %w", err)
-			}
-			output = string(jsonData)
-		} else {
-			output = formatEpicChildren(epicKey, children)
+		var sb strings.Builder
+		if epicFormat.readable() {
+			sb.WriteString(formatEpicChildren(epicKey, children))
+		} else if err := output.Write(&sb, children, issueColumns, epicFormat.options()); err != nil {
+			return err
 		}
 
 		if epicOutput != "" {
-			err := os.WriteFile(epicOutput, []byte(output), 0644)
+			err := os.WriteFile(epicOutput, []byte(sb.String()), 0644)
 			if err != nil {
 				return fmt.Errorf("failed to write to file: %w", err)
 			}
 			fmt.Printf("Epic children written to %s\n", epicOutput)
 		} else {
-			fmt.Print(output)
+			fmt.Print(sb.String())
 		}
 
 		return nil
@@ -192,7 +185,7 @@ func truncateString(s string, maxLen int) string {
 func init() {
 	rootCmd.AddCommand(epicCmd)
 	
-	epicCmd.Flags().StringVar(&epicFormat, "format", "readable", "Output format (readable or json)")
+	addOutputFlags(epicCmd, &epicFormat, issueColumns)
 	epicCmd.Flags().StringVarP(&epicOutput, "output", "o", "", "Output file (default: stdout)")
 	epicCmd.Flags().BoolVar(&showAllTickets, "all", false, "Show all tickets including closed ones")
 }
\ No newline at end of file
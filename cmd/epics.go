@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -9,12 +8,12 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
-	"jet/internal/config"
 	"jet/internal/jira"
+	"jet/internal/output"
 )
 
 var (
-	epicsFormat     string
+	epicsFormat     outputFlags
 	epicsOutput     string
 	epicsShowAll    bool
 	epicsMaxResults int
@@ -37,13 +36,11 @@ Examples:
 	RunE: func(cmd *cobra.Command, args []string) error {
 		projectKey := args[0]
 
-		cfg, err := config.Load()
+		client, err := newJiraClient()
 		if err != nil {
-			return fmt.Errorf("configuration error: %w", err)
+			return err
 		}
 
-		client := jira.NewClient(cfg.URL, cfg.Email, cfg.Username, cfg.Token)
-
 		// Build JQL
 		jql := fmt.Sprintf("project = \"%s\" AND issuetype = Epic", jira.EscapeString(projectKey))
 		if !epicsShowAll {
@@ -51,12 +48,12 @@ Examples:
 		}
 		jql += " ORDER BY updated DESC"
 
-		searchResp, err := client.SearchIssues(jql, epicsMaxResults)
+		searchResp, err := client.SearchIssuesContext(cmd.Context(), jql, epicsMaxResults)
 		if err != nil {
 			return fmt.Errorf("search failed: %w", err)
 		}
 
-		if len(searchResp.Issues) == 0 {
+		if len(searchResp.Issues) == 0 && epicsFormat.readable() {
 			if epicsShowAll {
 				fmt.Printf("No epics found in project %s\n", projectKey)
 			} else {
@@ -65,25 +62,21 @@ Examples:
 			return nil
 		}
 
-		var output string
-		if epicsFormat == "json" {
-			jsonData, err := json.MarshalIndent(searchResp.Issues, "", "  ")
-			if err != nil {
-				return fmt.Errorf("error formatting JSON: %w", err)
-			}
-			output = string(jsonData)
-		} else {
-			output = formatEpics(projectKey, searchResp.Issues, searchResp.Total)
+		var sb strings.Builder
+		if epicsFormat.readable() {
+			sb.WriteString(formatEpics(projectKey, searchResp.Issues, searchResp.Total))
+		} else if err := output.Write(&sb, searchResp.Issues, issueColumns, epicsFormat.options()); err != nil {
+			return err
 		}
 
 		if epicsOutput != "" {
-			err := os.WriteFile(epicsOutput, []byte(output), 0644)
+			err := os.WriteFile(epicsOutput, []byte(sb.String()), 0644)
 			if err != nil {
 				return fmt.Errorf("error writing to file: %w", err)
 			}
 			fmt.Printf("Epics written to %s\n", epicsOutput)
 		} else {
-			fmt.Print(output)
+			fmt.Print(sb.String())
 		}
 
 		return nil
@@ -160,7 +153,7 @@ func formatEpics(projectKey string, epics []jira.Issue, total int) string {
 func init() {
 	rootCmd.AddCommand(epicsCmd)
 
-	epicsCmd.Flags().StringVar(&epicsFormat, "format", "readable", "Output format (readable or json)")
+	addOutputFlags(epicsCmd, &epicsFormat, issueColumns)
 	epicsCmd.Flags().StringVarP(&epicsOutput, "output", "o", "", "Output file (default: stdout)")
 	epicsCmd.Flags().BoolVar(&epicsShowAll, "all", false, "Show all epics including closed ones")
 	epicsCmd.Flags().IntVar(&epicsMaxResults, "max", 50, "Maximum number of results")
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"jet/internal/jira"
+	"jet/internal/output"
+)
+
+var (
+	fieldsCustomOnly bool
+	fieldsRefresh    bool
+	fieldsFormat     outputFlags
+)
+
+var fieldsCmd = &cobra.Command{
+	Use:   "fields [FILTER]",
+	Short: "List the site's fields and their IDs",
+	Long: `List the system and custom fields of your JIRA site. The names shown
+here can be used with --field on create, edit and view.
+
+The field list is cached per site in ~/.jet/cache for a day; use --refresh
+after adding fields in JIRA.
+
+Examples:
+  jet fields                     # All fields
+  jet fields --custom            # Custom fields only
+  jet fields points              # Fields whose name or ID contains "points"
+  jet fields --refresh           # Re-fetch the list from JIRA`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+
+		var reg *jira.FieldRegistry
+		if fieldsRefresh {
+			reg, err = client.RefreshFieldRegistry(cmd.Context())
+		} else {
+			reg, err = client.FieldRegistry(cmd.Context())
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load field list: %w", err)
+		}
+
+		filter := ""
+		if len(args) > 0 {
+			filter = strings.ToLower(args[0])
+		}
+		var fields []jira.Field
+		for _, f := range reg.Fields {
+			if fieldsCustomOnly && !f.Custom {
+				continue
+			}
+			if filter != "" && !strings.Contains(strings.ToLower(f.Name), filter) && !strings.Contains(strings.ToLower(f.ID), filter) {
+				continue
+			}
+			fields = append(fields, f)
+		}
+
+		if !fieldsFormat.readable() {
+			return output.Write(os.Stdout, fields, fieldColumns, fieldsFormat.options())
+		}
+
+		if len(fields) == 0 {
+			fmt.Println("No matching fields")
+			return nil
+		}
+
+		yellow := color.New(color.FgYellow, color.Bold)
+		gray := color.New(color.FgHiBlack)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		yellow.Fprintln(w, "ID\tNAME\tTYPE")
+		for _, f := range fields {
+			typ := fieldType(f)
+			if typ == "" {
+				typ = gray.Sprint("-")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", f.ID, f.Name, typ)
+		}
+		return w.Flush()
+	},
+}
+
+// fieldType describes a field's schema, e.g. "array of string".
+func fieldType(f jira.Field) string {
+	typ := f.Schema.Type
+	if typ == "array" && f.Schema.Items != "" {
+		typ += " of " + f.Schema.Items
+	}
+	return typ
+}
+
+// fieldColumns are the columns of `jet fields`.
+var fieldColumns = []output.Column[jira.Field]{
+	{Name: "id", Value: func(f jira.Field) string { return f.ID }},
+	{Name: "name", Value: func(f jira.Field) string { return f.Name }},
+	{Name: "type", Value: fieldType},
+	{Name: "custom", Value: func(f jira.Field) string { return strconv.FormatBool(f.Custom) }, Extra: true},
+	{Name: "key", Value: func(f jira.Field) string { return f.Key }, Extra: true},
+}
+
+func init() {
+	rootCmd.AddCommand(fieldsCmd)
+
+	fieldsCmd.Flags().BoolVar(&fieldsCustomOnly, "custom", false, "Only list custom fields")
+	fieldsCmd.Flags().BoolVar(&fieldsRefresh, "refresh", false, "Ignore the cached field list and fetch it again")
+	addOutputFlags(fieldsCmd, &fieldsFormat, fieldColumns)
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"jet/internal/jira"
+	"jet/internal/output"
+)
+
+// outputFlags are the --format, --template and --columns flags of a listing
+// command. The readable format is the command's own coloured output; the
+// others are written by the output package.
+type outputFlags struct {
+	format   string
+	template string
+	columns  []string
+}
+
+// addOutputFlags registers the output flags of cmd, whose rows have cols,
+// and checks them before the command runs, ahead of any PreRun or PreRunE
+// cmd already has.
+func addOutputFlags[T any](cmd *cobra.Command, f *outputFlags, cols []output.Column[T]) {
+	names := make([]string, len(cols))
+	for i, c := range cols {
+		names[i] = c.Name
+	}
+	cmd.Flags().StringVar(&f.format, "format", output.Readable, "Output format ("+strings.Join(output.Formats, ", ")+")")
+	cmd.Flags().StringVar(&f.template, "template", "", "Go template run for each row (implies --format template)")
+	cmd.Flags().StringSliceVar(&f.columns, "columns", nil, "Columns to output (implies --format table): "+strings.Join(names, ","))
+
+	// Cobra skips PreRun when PreRunE is set, so both are called from here.
+	preRun, preRunE := cmd.PreRun, cmd.PreRunE
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if !f.readable() {
+			if err := output.Check(cols, f.options()); err != nil {
+				return err
+			}
+		}
+		if preRunE != nil {
+			return preRunE(cmd, args)
+		}
+		if preRun != nil {
+			preRun(cmd, args)
+		}
+		return nil
+	}
+}
+
+func (f *outputFlags) options() output.Options {
+	format := f.format
+	if format == output.Readable {
+		if f.template != "" {
+			format = output.Template
+		} else if len(f.columns) > 0 {
+			format = output.Table
+		}
+	}
+	return output.Options{Format: format, Template: f.template, Columns: f.columns}
+}
+
+// readable reports whether the command's coloured output was asked for.
+func (f *outputFlags) readable() bool {
+	return f.options().Format == output.Readable
+}
+
+// issueColumns are the columns of listed issues.
+var issueColumns = []output.Column[jira.Issue]{
+	{Name: "key", Value: func(i jira.Issue) string { return i.Key }},
+	{Name: "type", Value: func(i jira.Issue) string { return i.Fields.IssueType.Name }},
+	{Name: "status", Value: func(i jira.Issue) string { return i.Fields.Status.Name }},
+	{Name: "priority", Value: func(i jira.Issue) string { return i.Fields.Priority.Name }},
+	{Name: "assignee", Value: func(i jira.Issue) string { return userName(i.Fields.Assignee) }},
+	{Name: "summary", Value: func(i jira.Issue) string { return i.Fields.Summary }},
+	{Name: "reporter", Value: func(i jira.Issue) string { return userName(i.Fields.Reporter) }, Extra: true},
+	{Name: "project", Value: func(i jira.Issue) string { return i.Fields.Project.Key }, Extra: true},
+	{Name: "created", Value: func(i jira.Issue) string { return i.Fields.Created }, Extra: true},
+	{Name: "updated", Value: func(i jira.Issue) string { return i.Fields.Updated }, Extra: true},
+	{Name: "resolved", Value: func(i jira.Issue) string { return i.Fields.ResolutionDate }, Extra: true},
+	{Name: "labels", Value: func(i jira.Issue) string { return strings.Join(i.Fields.Labels, ",") }, Extra: true},
+	{Name: "components", Value: func(i jira.Issue) string {
+		names := make([]string, len(i.Fields.Components))
+		for n, c := range i.Fields.Components {
+			names[n] = c.Name
+		}
+		return strings.Join(names, ",")
+	}, Extra: true},
+	{Name: "fixversions", Value: func(i jira.Issue) string {
+		names := make([]string, len(i.Fields.FixVersions))
+		for n, v := range i.Fields.FixVersions {
+			names[n] = v.Name
+		}
+		return strings.Join(names, ",")
+	}, Extra: true},
+	{Name: "parent", Value: func(i jira.Issue) string {
+		if i.Fields.Parent == nil {
+			return ""
+		}
+		return i.Fields.Parent.Key
+	}, Extra: true},
+	{Name: "epic", Value: func(i jira.Issue) string {
+		if i.Fields.EpicLink == nil {
+			return ""
+		}
+		return i.Fields.EpicLink.Key
+	}, Extra: true},
+	{Name: "points", Value: func(i jira.Issue) string {
+		if i.Fields.StoryPoints == nil {
+			return ""
+		}
+		return formatPoints(*i.Fields.StoryPoints)
+	}, Extra: true},
+}
+
+// userName is a user's display name, falling back to the login name; empty
+// for nobody.
+func userName(u *jira.User) string {
+	if u == nil {
+		return ""
+	}
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	return u.Name
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"jet/internal/git"
+	"jet/internal/jira"
+)
+
+// recentCommitsScanned is how many commit subjects are searched for a
+// ticket key when the branch name has none.
+const recentCommitsScanned = 10
+
+// ticketArg returns the ticket key given as the first of args, or when args
+// is empty the one inferred from git.
+func ticketArg(ctx context.Context, args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	return inferIssueKey(ctx)
+}
+
+// inferIssueKey finds the ticket being worked on: the key the current
+// branch's name or one of its parts starts with (in any case, e.g.
+// feature/proj-123-fix-login), or else the newest of the last few commits
+// whose subject mentions one.
+func inferIssueKey(ctx context.Context) (string, error) {
+	repo := git.Repo{}
+	branch, err := repo.CurrentBranch(ctx)
+	if errors.Is(err, git.ErrNotRepository) {
+		return "", fmt.Errorf("no ticket key given, and not in a git repository to infer one from")
+	}
+	if err != nil {
+		return "", fmt.Errorf("no ticket key given, and git failed: %w", err)
+	}
+	if key := jira.BranchIssueKey(branch); key != "" {
+		fmt.Fprintln(os.Stderr, colGray.Sprintf("Using %s from branch %s", key, branch))
+		return key, nil
+	}
+
+	subjects, err := repo.RecentSubjects(ctx, recentCommitsScanned)
+	if err != nil {
+		return "", fmt.Errorf("no ticket key given, and git failed: %w", err)
+	}
+	for _, s := range subjects {
+		if keys := jira.FindIssueKeys(s); len(keys) > 0 {
+			fmt.Fprintln(os.Stderr, colGray.Sprintf("Using %s from commit %q", keys[0], truncateString(s, 60)))
+			return keys[0], nil
+		}
+	}
+	if branch == "" {
+		branch = "HEAD"
+	}
+	return "", fmt.Errorf("no ticket key given, and none found in %s or its last %d commits", branch, recentCommitsScanned)
+}
@@ -4,31 +4,29 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
-	"jet/internal/config"
-	"jet/internal/jira"
 )
 
 var grabCmd = &cobra.Command{
-	Use:   "grab TICKET-KEY",
+	Use:   "grab [TICKET-KEY]",
 	Short: "Grab (assign) a JIRA ticket to yourself",
 	Long: `Grab (assign) a JIRA ticket to yourself.
 	
-This is a convenient shortcut for assigning tickets to the currently authenticated user.`,
-	Args:  cobra.ExactArgs(1),
+This is a convenient shortcut for assigning tickets to the currently authenticated user.
+Without TICKET-KEY, the key is taken from the current git branch or recent commits.`,
+	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ticketKey := args[0]
-
-		// Load configuration
-		cfg, err := config.Load()
+		ticketKey, err := ticketArg(cmd.Context(), args)
 		if err != nil {
-			return fmt.Errorf("configuration error: %w", err)
+			return err
 		}
 
-		// Create JIRA client
-		client := jira.NewClient(cfg.URL, cfg.Email, cfg.Username, cfg.Token)
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
 
 		// Get current user
-		currentUser, err := client.GetCurrentUser()
+		currentUser, err := client.GetCurrentUserContext(cmd.Context())
 		if err != nil {
 			return fmt.Errorf("failed to get current user: %w", err)
 		}
@@ -48,7 +46,7 @@ This is a convenient shortcut for assigning tickets to the currently authenticat
 		}
 
 		// Update the ticket
-		if err := client.UpdateIssue(ticketKey, fields); err != nil {
+		if err := client.UpdateIssueContext(cmd.Context(), ticketKey, fields); err != nil {
 			return err
 		}
 
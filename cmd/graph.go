@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"jet/internal/graph"
+)
+
+var (
+	graphDepth       int
+	graphFormat      string
+	graphOutput      string
+	graphConcurrency int
+)
+
+var graphCmd = &cobra.Command{
+	Use:   "graph TICKET-KEY",
+	Short: "Export the dependency graph around a ticket or epic",
+	Long: `Export the links and parent/child relationships around a ticket or epic
+as a Graphviz (DOT) or Mermaid diagram, or as JSON.
+
+The graph follows issue links in both directions, parents and epics, and
+children, up to --depth hops from the ticket. Nodes are coloured by status
+category (to do, in progress, done). The longest chain of unresolved issues
+blocking one another is drawn in red as the critical path, and issues that
+block each other in a circle are reported.
+
+Examples:
+  jet graph PROJ-123                               # DOT on stdout
+  jet graph PROJ-123 | dot -Tsvg > graph.svg
+  jet graph PROJ-100 --depth 1 --format mermaid    # For Confluence or Markdown
+  jet graph PROJ-123 --format json -o graph.json`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if !slices.Contains(graph.Formats, graphFormat) {
+			return fmt.Errorf("invalid --format %q (use %s)", graphFormat, strings.Join(graph.Formats, ", "))
+		}
+		if graphDepth < 0 {
+			return fmt.Errorf("--depth must not be negative")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key := args[0]
+		if strings.Contains(key, "/browse/") {
+			parts := strings.Split(key, "/browse/")
+			if len(parts) == 2 {
+				key = parts[1]
+			}
+		}
+
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+
+		g, err := graph.Crawl(cmd.Context(), client, key, graph.Options{Depth: graphDepth, Concurrency: graphConcurrency})
+		if err != nil {
+			return err
+		}
+
+		var sb strings.Builder
+		if err := graph.Write(&sb, g, graphFormat); err != nil {
+			return err
+		}
+		if graphOutput != "" {
+			if err := os.WriteFile(graphOutput, []byte(sb.String()), 0644); err != nil {
+				return fmt.Errorf("failed to write to file: %w", err)
+			}
+			fmt.Printf("Graph of %d issues written to %s\n", len(g.Nodes), graphOutput)
+			if len(g.CriticalPath) > 0 {
+				fmt.Printf("%s %s\n", colRed.Sprint("Critical path:"), strings.Join(g.CriticalPath, " → "))
+			}
+		} else {
+			fmt.Print(sb.String())
+		}
+
+		for _, cycle := range g.Cycles {
+			fmt.Fprintf(os.Stderr, "%s %s\n", colYellow.Sprint("⚠ Blocking cycle:"), strings.Join(append(cycle, cycle[0]), " → "))
+		}
+		if len(g.Skipped) > 0 {
+			fmt.Fprintln(os.Stderr, colGray.Sprintf("Could not fetch %s; shown as linked", strings.Join(g.Skipped, ", ")))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(graphCmd)
+
+	graphCmd.Flags().IntVar(&graphDepth, "depth", 2, "Hops to follow from the ticket")
+	graphCmd.Flags().StringVar(&graphFormat, "format", graph.DOT, "Output format: "+strings.Join(graph.Formats, ", "))
+	graphCmd.Flags().StringVarP(&graphOutput, "output", "o", "", "Output file (default: stdout)")
+	graphCmd.Flags().IntVar(&graphConcurrency, "concurrency", graph.DefaultConcurrency, "Tickets fetched at once")
+}
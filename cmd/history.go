@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"jet/internal/jira"
+	"jet/internal/output"
+)
+
+var (
+	historyFields  []string
+	historyAuthors []string
+	historySince   string
+	historyFormat  outputFlags
+	historyOutput  string
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history TICKET-KEY",
+	Short: "Show the change history of a ticket",
+	Long: `Show who changed which field of a ticket, from what to what, and when.
+Entries are listed oldest first.
+
+Examples:
+  jet history PROJ-123                       # Full history
+  jet history PROJ-123 --field status        # Status changes only
+  jet history PROJ-123 --author jane         # Changes by authors matching "jane"
+  jet history PROJ-123 --since 7d            # Changes in the last week
+  jet history PROJ-123 --since 2024-03-01 --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		issueKey := args[0]
+
+		// Extract ticket key from URL if provided
+		if strings.Contains(issueKey, "/browse/") {
+			parts := strings.Split(issueKey, "/browse/")
+			if len(parts) == 2 {
+				issueKey = parts[1]
+			}
+		}
+
+		filter := jira.HistoryFilter{Fields: historyFields, Authors: historyAuthors}
+		if historySince != "" {
+			since, err := parseSince(historySince, time.Now())
+			if err != nil {
+				return err
+			}
+			filter.Since = since
+		}
+
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+
+		changelog, err := client.GetChangelogContext(cmd.Context(), issueKey)
+		if err != nil {
+			return fmt.Errorf("failed to fetch history: %w", err)
+		}
+		entries := filter.Filter(changelog.Entries())
+
+		var sb strings.Builder
+		if historyFormat.readable() {
+			sb.WriteString(formatHistory(issueKey, entries))
+		} else if err := output.Write(&sb, entries, historyColumns, historyFormat.options()); err != nil {
+			return err
+		}
+
+		if historyOutput != "" {
+			if err := os.WriteFile(historyOutput, []byte(sb.String()), 0644); err != nil {
+				return fmt.Errorf("failed to write to file: %w", err)
+			}
+			fmt.Printf("History written to %s\n", historyOutput)
+		} else {
+			fmt.Print(sb.String())
+		}
+
+		return nil
+	},
+}
+
+// historyColumns are the columns of `jet history`.
+var historyColumns = []output.Column[jira.HistoryEntry]{
+	{Name: "time", Value: func(e jira.HistoryEntry) string { return e.Time.Format(time.RFC3339) }},
+	{Name: "author", Value: func(e jira.HistoryEntry) string { return e.Author }},
+	{Name: "field", Value: func(e jira.HistoryEntry) string { return e.Field }},
+	{Name: "from", Value: func(e jira.HistoryEntry) string { return e.From }},
+	{Name: "to", Value: func(e jira.HistoryEntry) string { return e.To }},
+	{Name: "fieldid", Value: func(e jira.HistoryEntry) string { return e.FieldID }, Extra: true},
+}
+
+// formatHistory renders entries grouped by edit: one header per author and
+// time, followed by the fields that edit changed.
+func formatHistory(issueKey string, entries []jira.HistoryEntry) string {
+	var sb strings.Builder
+
+	colCyan.Fprintf(&sb, "History of %s\n", issueKey)
+	if len(entries) == 0 {
+		sb.WriteString("No matching changes\n")
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, "%d change(s):\n", len(entries))
+
+	for i, e := range entries {
+		if i == 0 || !e.Time.Equal(entries[i-1].Time) || e.Author != entries[i-1].Author {
+			sb.WriteString("\n")
+			fmt.Fprintf(&sb, "%s  %s\n", colGray.Sprint(e.Time.Local().Format("2006-01-02 15:04")), colYellow.Sprint(e.Author))
+		}
+		from, to := e.From, e.To
+		if from == "" {
+			from = colGray.Sprint("(none)")
+		}
+		if to == "" {
+			to = colGray.Sprint("(none)")
+		}
+		if strings.Contains(e.From, "\n") || strings.Contains(e.To, "\n") || len(e.From)+len(e.To) > 100 {
+			// Long text such as descriptions: just say it changed.
+			fmt.Fprintf(&sb, "  %s %s\n", colBlue.Sprint(e.Field+":"), colGray.Sprint("changed"))
+			continue
+		}
+		fmt.Fprintf(&sb, "  %s %s → %s\n", colBlue.Sprint(e.Field+":"), from, colGreen.Sprint(to))
+	}
+	return sb.String()
+}
+
+// parseSince accepts a date (2024-03-01) or an age such as 36h, 7d or 2w.
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	for unit, days := range map[string]int{"d": 1, "w": 7} {
+		if num, ok := strings.CutSuffix(s, unit); ok {
+			if n, err := strconv.Atoi(num); err == nil {
+				return now.AddDate(0, 0, -n*days), nil
+			}
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q: use a date (2024-03-01) or an age (36h, 7d, 2w)", s)
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+
+	historyCmd.Flags().StringSliceVar(&historyFields, "field", nil, "Only show changes to these fields (repeatable or comma-separated)")
+	historyCmd.Flags().StringSliceVar(&historyAuthors, "author", nil, "Only show changes by authors matching these names")
+	historyCmd.Flags().StringVar(&historySince, "since", "", "Only show changes since a date (2024-03-01) or age (7d)")
+	addOutputFlags(historyCmd, &historyFormat, historyColumns)
+	historyCmd.Flags().StringVarP(&historyOutput, "output", "o", "", "Output file (default: stdout)")
+}
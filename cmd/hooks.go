@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"jet/internal/git"
+	"jet/internal/httpclient"
+	"jet/internal/jira"
+)
+
+var (
+	hooksVerify bool
+	hooksForce  bool
+)
+
+// hookNames are the hooks 'jet hooks install' writes.
+var hookNames = []string{"prepare-commit-msg", "commit-msg"}
+
+// hookVerifyTimeout bounds the Jira lookups of a commit; past it the key
+// cache answers, as when offline.
+const hookVerifyTimeout = 5 * time.Second
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Git hooks that put ticket keys in commit messages",
+	Long: `Install git hooks that keep ticket keys in commit messages.
+
+prepare-commit-msg starts the message with the key of the current branch
+("PROJ-123: "), unless the message already has a key. commit-msg rejects a
+commit whose message has no key; with --verify it also checks with Jira that
+the key the message starts with exists and is not done. Keys Jira confirmed are remembered in
+~/.jet/cache, so commits can still be checked when Jira is unreachable.
+
+The hooks run 'jet hooks run', so they change with jet itself. Bypass them
+for one commit with 'git commit --no-verify'.
+
+Examples:
+  jet hooks install
+  jet hooks install --verify     # Also check keys with Jira
+  jet hooks uninstall`,
+}
+
+var hooksInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install the hooks in the current repository",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := hooksDir(cmd.Context())
+		if err != nil {
+			return err
+		}
+		jet, err := os.Executable()
+		if err != nil {
+			jet = "jet"
+		}
+		for _, name := range hookNames {
+			run := "hooks run " + name
+			if hooksVerify && name == "commit-msg" {
+				run = "hooks run --verify " + name
+			}
+			script := git.HookScript(jet, run)
+			if err := git.InstallHook(dir, name, script, hooksForce); err != nil {
+				if errors.Is(err, git.ErrForeignHook) {
+					return fmt.Errorf("%w; use --force to replace it (it is kept as %s.orig)", err, name)
+				}
+				return err
+			}
+			fmt.Printf("%s Installed %s\n", colGreen.Sprint("✓"), name)
+		}
+		return nil
+	},
+}
+
+var hooksUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove the hooks from the current repository",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := hooksDir(cmd.Context())
+		if err != nil {
+			return err
+		}
+		for _, name := range hookNames {
+			removed, err := git.UninstallHook(dir, name)
+			if err != nil {
+				return err
+			}
+			if removed {
+				fmt.Printf("%s Removed %s\n", colGreen.Sprint("✓"), name)
+			}
+		}
+		return nil
+	},
+}
+
+var hooksRunCmd = &cobra.Command{
+	Use:   "run HOOK FILE [ARGS...]",
+	Short: "Run a hook (called by the installed hooks)",
+	Long: `Run prepare-commit-msg or commit-msg with the arguments git passes them.
+The installed hooks call this; it is not meant to be run by hand.`,
+	Args:          cobra.MinimumNArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		hook, file := args[0], args[1]
+		switch hook {
+		case "prepare-commit-msg":
+			source := ""
+			if len(args) > 2 {
+				source = args[2]
+			}
+			return prepareCommitMsg(cmd.Context(), file, source)
+		case "commit-msg":
+			return checkCommitMsg(cmd.Context(), file, hooksVerify)
+		}
+		return fmt.Errorf("unknown hook %q (use %s)", hook, strings.Join(hookNames, " or "))
+	},
+}
+
+func hooksDir(ctx context.Context) (string, error) {
+	dir, err := git.Repo{}.HooksDir(ctx)
+	if errors.Is(err, git.ErrNotRepository) {
+		return "", fmt.Errorf("not in a git repository")
+	}
+	return dir, err
+}
+
+// prepareCommitMsg prefixes the message with the key of the current branch.
+// Merges, squashes and amended or reused commits keep their message.
+func prepareCommitMsg(ctx context.Context, file, source string) error {
+	switch source {
+	case "merge", "squash", "commit":
+		return nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read commit message: %w", err)
+	}
+	repo := git.Repo{}
+	comment := repo.CommentChar(ctx)
+	msg := string(data)
+	if len(jira.FindIssueKeys(git.CleanMessage(msg, comment))) > 0 {
+		return nil
+	}
+	branch, err := repo.CurrentBranch(ctx)
+	if err != nil {
+		return err
+	}
+	key := jira.BranchIssueKey(branch)
+	if key == "" {
+		return nil
+	}
+	return os.WriteFile(file, []byte(git.PrefixMessage(msg, key+": ", comment)), 0644)
+}
+
+// checkCommitMsg rejects a message without a ticket key and, with verify,
+// one starting with a key that does not exist or is done. Only the leading
+// key, the one prepareCommitMsg adds, is verified: words such as UTF-8
+// further on look like keys too.
+func checkCommitMsg(ctx context.Context, file string, verify bool) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read commit message: %w", err)
+	}
+	msg := git.CleanMessage(string(data), git.Repo{}.CommentChar(ctx))
+	if msg == "" || strings.HasPrefix(msg, "Merge ") {
+		// Git aborts empty messages itself; merges name branches.
+		return nil
+	}
+	keys := jira.FindIssueKeys(msg)
+	if len(keys) == 0 {
+		return fmt.Errorf("commit message has no ticket key; start it with one, e.g. \"PROJ-123: %s\" (skip the check with --no-verify)", truncateString(strings.SplitN(msg, "\n", 2)[0], 40))
+	}
+	if strings.TrimSuffix(msg, ":") == keys[0] {
+		return fmt.Errorf("commit message has only the ticket key")
+	}
+	key := jira.LeadingIssueKey(msg)
+	if !verify || key == "" {
+		return nil
+	}
+
+	// Answer from the cache promptly rather than retrying a dead network.
+	httpclient.DefaultRetryPolicy.MaxRetries = 0
+	httpclient.DefaultRetryPolicy.Notify = nil
+	client, err := newJiraClient()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "jet: ticket key not checked: %v\n", err)
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, hookVerifyTimeout)
+	defer cancel()
+
+	check, err := client.CheckIssueKeyContext(ctx, key)
+	switch {
+	case err != nil:
+		return fmt.Errorf("failed to check %s: %w", key, err)
+	case check.Offline && !check.Known:
+		hint := ""
+		if !check.KnownProject {
+			hint = " (no other ticket of its project seen before: check for a typo)"
+		}
+		fmt.Fprintf(os.Stderr, "jet: Jira is unreachable; %s not checked%s\n", key, hint)
+	case !check.Exists:
+		return fmt.Errorf("%s does not exist (skip the check with --no-verify)", key)
+	case check.Done:
+		return fmt.Errorf("%s is %s (skip the check with --no-verify)", key, check.Status)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(hooksCmd)
+	hooksCmd.AddCommand(hooksInstallCmd, hooksUninstallCmd, hooksRunCmd)
+
+	hooksInstallCmd.Flags().BoolVar(&hooksVerify, "verify", false, "Also check with Jira that the leading key exists and is not done")
+	hooksInstallCmd.Flags().BoolVar(&hooksForce, "force", false, "Replace existing hooks (kept as HOOK.orig)")
+	hooksRunCmd.Flags().BoolVar(&hooksVerify, "verify", false, "Check keys with Jira (commit-msg)")
+}
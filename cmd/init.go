@@ -4,10 +4,13 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"path/filepath"
+	"os/exec"
+	"runtime"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"jet/internal/config"
+	"jet/internal/secrets"
 )
 
 var initCmd = &cobra.Command{
@@ -17,7 +20,12 @@ var initCmd = &cobra.Command{
 	
 This command will prompt you for JIRA connection details and help you configure
 the required environment variables. If values are already set, you can press
-Enter to keep the existing values.`,
+Enter to keep the existing values.
+
+When saving to the config file, the API token can be kept out of it: either in
+jet's secret store (OS keyring, or an encrypted file on headless machines) or
+in an external helper such as pass, read back through token_command. With
+--profile the values are written to that [profile NAME] section.`,
 	RunE: runInit,
 }
 
@@ -65,15 +73,85 @@ func runInit(cmd *cobra.Command, args []string) error {
 	}
 
 	// Ask whether to save as environment variables or config file
-	saveMethod := promptWithDefault(reader, "Save as (1) environment variables or (2) config file? (1/2)", "1")
-	
-	if strings.TrimSpace(saveMethod) == "2" {
-		return saveToConfigFile(url, email, username, token)
-	} else {
+	fmt.Println("Save as:")
+	fmt.Println("  (1) environment variables")
+	fmt.Println("  (2) config file (token in plaintext)")
+	fmt.Println("  (3) config file, token in jet's secret store (keyring or encrypted file)")
+	fmt.Println("  (4) config file, token from a helper command (e.g. pass)")
+	saveMethod := promptWithDefault(reader, "Choice (1-4)", "1")
+
+	section := "jira"
+	if profile, err := config.ActiveProfile(); err == nil && profile != "" {
+		section = "profile " + profile
+	}
+
+	switch strings.TrimSpace(saveMethod) {
+	case "2":
+		return saveToConfigFile(section, url, email, username, "token", token)
+	case "3":
+		return saveWithSecretStore(section, url, email, username, token)
+	case "4":
+		return saveWithTokenCommand(reader, section, url, email, username, token)
+	default:
 		return saveAsEnvVars(url, email, username, token)
 	}
 }
 
+// saveWithSecretStore stores the token in the default secret store and
+// points the config section at it with token_store.
+func saveWithSecretStore(section, url, email, username, token string) error {
+	store, err := secrets.Default()
+	if err != nil {
+		return fmt.Errorf("failed to open secret store: %w", err)
+	}
+	key := config.StoreKey(section)
+	if err := store.Set(key, token); err != nil {
+		return fmt.Errorf("failed to store token: %w", err)
+	}
+	fmt.Printf("Token stored in the %s secret store under %q\n", store.Name(), key)
+	if store.Name() == "file" {
+		fmt.Printf("Set %s to protect it with a passphrase instead of ~/.jet/secrets.key.\n", secrets.PassphraseEnv)
+	}
+	return saveToConfigFile(section, url, email, username, "token_store", store.Name())
+}
+
+// saveWithTokenCommand configures token_command, optionally piping the token
+// into a helper's store command first (e.g. "pass insert -m jira").
+func saveWithTokenCommand(reader *bufio.Reader, section, url, email, username, token string) error {
+	readCmd := promptWithDefault(reader, "Command that prints the token (e.g. pass show jira)", "")
+	if readCmd == "" {
+		return fmt.Errorf("token command cannot be empty")
+	}
+	storeCmd := promptWithDefault(reader, "Command that stores a token read from stdin (e.g. pass insert -m jira; blank to skip)", "")
+	if storeCmd != "" {
+		if err := runWithStdin(storeCmd, token+"\n"); err != nil {
+			return fmt.Errorf("failed to store token with %q: %w", storeCmd, err)
+		}
+	}
+
+	got, err := config.RunTokenCommand(readCmd)
+	if err != nil {
+		return err
+	}
+	if got != token {
+		fmt.Println("Warning: the token command's output does not match the token you entered.")
+	}
+	return saveToConfigFile(section, url, email, username, "token_command", readCmd)
+}
+
+func runWithStdin(command, stdin string) error {
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.Command("cmd", "/C", command)
+	} else {
+		c = exec.Command("sh", "-c", command)
+	}
+	c.Stdin = strings.NewReader(stdin)
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	return c.Run()
+}
+
 func promptWithDefault(reader *bufio.Reader, prompt, defaultValue string) string {
 	if defaultValue != "" {
 		fmt.Printf("%s [%s]: ", prompt, maskIfToken(prompt, defaultValue))
@@ -122,77 +200,35 @@ func saveAsEnvVars(url, email, username, token string) error {
 	return nil
 }
 
-func saveToConfigFile(jiraURL, email, username, token string) (retErr error) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
-	}
-
-	configPath := filepath.Join(homeDir, ".jira_config")
-
-	// Check if file exists and read existing content
-	var existingContent []string
-	if _, err := os.Stat(configPath); err == nil {
-		file, err := os.Open(configPath)
-		if err != nil {
-			return fmt.Errorf("failed to read existing config: %w", err)
+// saveToConfigFile writes the connection settings to the given section of
+// ~/.jira_config. tokenKey is "token", "token_command" or "token_store"; the
+// other two token keys are removed so only one source remains.
+func saveToConfigFile(section, jiraURL, email, username, tokenKey, tokenValue string) error {
+	values := [][2]string{{"url", jiraURL}, {"email", email}, {"username", username}, {tokenKey, tokenValue}}
+	for _, kv := range values {
+		var err error
+		if kv[1] == "" {
+			err = config.UnsetValue(section, kv[0])
+		} else {
+			err = config.SetValue(section, kv[0], kv[1])
 		}
-		defer file.Close()
-
-		scanner := bufio.NewScanner(file)
-		inJiraSection := false
-		
-		for scanner.Scan() {
-			line := scanner.Text()
-			
-			// Skip existing JIRA section entries
-			if strings.HasPrefix(strings.TrimSpace(line), "[jira]") {
-				inJiraSection = true
-				continue
-			}
-			if inJiraSection && strings.HasPrefix(strings.TrimSpace(line), "[") {
-				inJiraSection = false
-			}
-			if inJiraSection && (strings.Contains(line, "url=") || 
-				strings.Contains(line, "email=") || 
-				strings.Contains(line, "username=") || 
-				strings.Contains(line, "token=")) {
-				continue
-			}
-			
-			existingContent = append(existingContent, line)
+		if err != nil {
+			return fmt.Errorf("failed to write config file: %w", err)
 		}
 	}
-
-	// Create new config content with secure permissions
-	file, err := os.OpenFile(configPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
-	if err != nil {
-		return fmt.Errorf("failed to create config file: %w", err)
-	}
-	defer func() {
-		if cerr := file.Close(); cerr != nil && retErr == nil {
-			retErr = fmt.Errorf("failed to flush config file: %w", cerr)
+	for _, key := range []string{"token", "token_command", "token_store"} {
+		if key == tokenKey {
+			continue
+		}
+		if err := config.UnsetValue(section, key); err != nil {
+			return fmt.Errorf("failed to write config file: %w", err)
 		}
-	}()
-
-	// Write existing content first
-	for _, line := range existingContent {
-		fmt.Fprintln(file, line)
 	}
 
-	// Add JIRA section
-	fmt.Fprintln(file, "[jira]")
-	fmt.Fprintf(file, "url=%s\n", jiraURL)
-	if email != "" {
-		fmt.Fprintf(file, "email=%s\n", email)
-	}
-	if username != "" {
-		fmt.Fprintf(file, "username=%s\n", username)
+	fmt.Printf("Configuration saved to [%s] in %s with secure permissions (0600)\n", section, config.ConfigPath())
+	if tokenKey == "token" {
+		fmt.Println("Note: Your API token is stored in plaintext. Keep this file secure.")
 	}
-	fmt.Fprintf(file, "token=%s\n", token)
-
-	fmt.Printf("Configuration saved to %s with secure permissions (0600)\n", configPath)
-	fmt.Println("Note: Your API token is stored in plaintext. Keep this file secure.")
 	return nil
 }
 
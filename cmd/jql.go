@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"jet/internal/config"
+	"jet/internal/jira"
+	"jet/internal/output"
+)
+
+var (
+	jqlCheckFormat outputFlags
+	jqlCheckSaved  bool
+)
+
+var jqlCmd = &cobra.Command{
+	Use:   "jql",
+	Short: "Work with JQL queries",
+}
+
+var jqlCheckCmd = &cobra.Command{
+	Use:   "check [QUERY...]",
+	Short: "Validate JQL without running it",
+	Long: `Ask Jira to parse and strictly validate JQL queries: syntax errors, unknown
+fields, values and functions are reported with where they occur. Exits
+non-zero when any query is invalid.
+
+Examples:
+  jet jql check 'project = PROJ AND status = "In Progress"'
+  jet jql check --saved                 # Every query saved with 'jet query save'
+  jet jql check "$JQL" --format json`,
+	// A failed check is an answer, not a usage mistake; the details are
+	// already printed.
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		queries := args
+		if jqlCheckSaved {
+			saved, err := config.ListQueries()
+			if err != nil {
+				return fmt.Errorf("failed to read saved queries: %w", err)
+			}
+			for _, q := range saved {
+				queries = append(queries, q.JQL)
+			}
+		}
+		if len(queries) == 0 {
+			return fmt.Errorf("no query given: pass JQL as an argument or use --saved")
+		}
+
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+		results, err := client.ValidateJQLContext(cmd.Context(), queries...)
+		if err != nil {
+			return fmt.Errorf("failed to validate JQL: %w", err)
+		}
+
+		invalid := 0
+		for _, r := range results {
+			if !r.Valid() {
+				invalid++
+			}
+		}
+		if !jqlCheckFormat.readable() {
+			if err := output.Write(os.Stdout, results, jqlCheckColumns, jqlCheckFormat.options()); err != nil {
+				return err
+			}
+		} else {
+			for _, r := range results {
+				fmt.Print(formatJQLValidation(r))
+			}
+		}
+		if invalid > 0 {
+			return fmt.Errorf("%d of %d queries invalid", invalid, len(results))
+		}
+		return nil
+	},
+}
+
+// jqlCheckColumns are the columns of jet jql check.
+var jqlCheckColumns = []output.Column[jira.JQLValidation]{
+	{Name: "valid", Value: func(v jira.JQLValidation) string { return strconv.FormatBool(v.Valid()) }},
+	{Name: "query", Value: func(v jira.JQLValidation) string { return v.Query }},
+	{Name: "errors", Value: func(v jira.JQLValidation) string {
+		msgs := make([]string, len(v.Errors))
+		for i, e := range v.Errors {
+			msgs[i] = e.Message
+		}
+		return strings.Join(msgs, "; ")
+	}},
+}
+
+// formatJQLValidation prints a query with a mark, and under an invalid one
+// each error with a caret at its position.
+func formatJQLValidation(v jira.JQLValidation) string {
+	var sb strings.Builder
+	if v.Valid() {
+		fmt.Fprintf(&sb, "%s %s\n", colGreen.Sprint("✓"), v.Query)
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, "%s %s\n", colRed.Sprint("✗"), v.Query)
+	lines := strings.Split(v.Query, "\n")
+	for _, e := range v.Errors {
+		if e.Line >= 1 && e.Line <= len(lines) && e.Column >= 1 {
+			line := []rune(lines[e.Line-1])
+			col := min(e.Column-1, len(line))
+			fmt.Fprintf(&sb, "    %s\n", string(line))
+			fmt.Fprintf(&sb, "    %s%s\n", strings.Repeat(" ", col), colRed.Sprint("^"))
+		}
+		fmt.Fprintf(&sb, "    %s\n", colYellow.Sprint(e.Message))
+	}
+	return sb.String()
+}
+
+func init() {
+	rootCmd.AddCommand(jqlCmd)
+	jqlCmd.AddCommand(jqlCheckCmd)
+
+	addOutputFlags(jqlCheckCmd, &jqlCheckFormat, jqlCheckColumns)
+	jqlCheckCmd.Flags().BoolVar(&jqlCheckSaved, "saved", false, "Also check every saved query")
+}
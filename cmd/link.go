@@ -6,8 +6,6 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
-	"jet/internal/config"
-	"jet/internal/jira"
 )
 
 var linkCmd = &cobra.Command{
@@ -56,17 +54,13 @@ Examples:
 		// Determine if this is an inward or outward link
 		isInward := strings.HasPrefix(relationship, "is-")
 
-		// Load configuration
-		cfg, err := config.Load()
+		client, err := newJiraClient()
 		if err != nil {
-			return fmt.Errorf("configuration error: %w", err)
+			return err
 		}
 
-		// Create JIRA client
-		client := jira.NewClient(cfg.URL, cfg.Email, cfg.Username, cfg.Token)
-
 		// Create the link
-		if err := client.LinkIssues(inwardIssue, outwardIssue, linkTypeName, isInward); err != nil {
+		if err := client.LinkIssuesContext(cmd.Context(), inwardIssue, outwardIssue, linkTypeName, isInward); err != nil {
 			return err
 		}
 
@@ -2,12 +2,14 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"jet/internal/config"
 	"jet/internal/jira"
+	"jet/internal/output"
 )
 
 var (
@@ -15,6 +17,8 @@ var (
 	listStatus   string
 	listProject  string
 	listMaxResults int
+	listQuery    string
+	listFormat   outputFlags
 )
 
 var listCmd = &cobra.Command{
@@ -29,64 +33,31 @@ Examples:
   jet list --assignee=john.doe                # Tickets assigned to john.doe
   jet list --status="To Do,Done"              # Tickets with specific statuses
   jet list --project=PROJ                     # Tickets in specific project
-  jet list --assignee=unassigned              # Unassigned tickets`,
+  jet list --assignee=unassigned              # Unassigned tickets
+  jet list --query=bugs                       # A query saved with 'jet query save'
+  jet list --format csv --columns key,status,assignee
+  jet list --template '{{.Key}} {{.Fields.Summary}}'`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Load configuration
-		cfg, err := config.Load()
+		jql, err := listJQL(cmd)
 		if err != nil {
-			return fmt.Errorf("configuration error: %w", err)
+			return err
 		}
 
-		// Create JIRA client
-		client := jira.NewClient(cfg.URL, cfg.Email, cfg.Username, cfg.Token)
-
-		// Build JQL query
-		var jqlParts []string
-
-		// Handle assignee
-		if listAssignee == "me" {
-			// Use currentUser() function in JQL instead of fetching user details
-			jqlParts = append(jqlParts, "assignee = currentUser()")
-		} else if listAssignee == "unassigned" {
-			jqlParts = append(jqlParts, "assignee is EMPTY")
-		} else if listAssignee != "" {
-			jqlParts = append(jqlParts, fmt.Sprintf("assignee = \"%s\"", jira.EscapeString(listAssignee)))
-		}
-
-		// Handle status
-		if listStatus != "" {
-			statuses := strings.Split(listStatus, ",")
-			if len(statuses) == 1 {
-				jqlParts = append(jqlParts, fmt.Sprintf("status = \"%s\"", jira.EscapeString(strings.TrimSpace(statuses[0]))))
-			} else {
-				statusList := make([]string, len(statuses))
-				for i, s := range statuses {
-					statusList[i] = fmt.Sprintf("\"%s\"", jira.EscapeString(strings.TrimSpace(s)))
-				}
-				jqlParts = append(jqlParts, fmt.Sprintf("status IN (%s)", strings.Join(statusList, ",")))
-			}
-		}
-
-		// Handle project
-		if listProject != "" {
-			jqlParts = append(jqlParts, fmt.Sprintf("project = \"%s\"", jira.EscapeString(listProject)))
-		}
-
-		// Build final JQL
-		jql := strings.Join(jqlParts, " AND ")
-		if jql == "" {
-			jql = "order by updated DESC"
-		} else {
-			jql += " order by updated DESC"
+		client, err := newJiraClient()
+		if err != nil {
+			return err
 		}
 
-
 		// Search for issues
-		searchResp, err := client.SearchIssues(jql, listMaxResults)
+		searchResp, err := client.SearchIssuesContext(cmd.Context(), jql, listMaxResults)
 		if err != nil {
 			return fmt.Errorf("search failed: %w", err)
 		}
 
+		if !listFormat.readable() {
+			return output.Write(os.Stdout, searchResp.Issues, issueColumns, listFormat.options())
+		}
+
 		// Display results
 		if len(searchResp.Issues) == 0 {
 			fmt.Println("No tickets found matching the criteria.")
@@ -99,6 +70,60 @@ Examples:
 	},
 }
 
+// listJQL returns the saved query named by --query, or builds JQL from the
+// filter flags.
+func listJQL(cmd *cobra.Command) (string, error) {
+	if listQuery != "" {
+		for _, flag := range []string{"assignee", "status", "project"} {
+			if cmd.Flags().Changed(flag) {
+				return "", fmt.Errorf("--query cannot be combined with --%s", flag)
+			}
+		}
+		return config.GetQuery(listQuery)
+	}
+
+	// Build JQL query
+	var jqlParts []string
+
+	// Handle assignee
+	if listAssignee == "me" {
+		// Use currentUser() function in JQL instead of fetching user details
+		jqlParts = append(jqlParts, "assignee = currentUser()")
+	} else if listAssignee == "unassigned" {
+		jqlParts = append(jqlParts, "assignee is EMPTY")
+	} else if listAssignee != "" {
+		jqlParts = append(jqlParts, fmt.Sprintf("assignee = \"%s\"", jira.EscapeString(listAssignee)))
+	}
+
+	// Handle status
+	if listStatus != "" {
+		statuses := strings.Split(listStatus, ",")
+		if len(statuses) == 1 {
+			jqlParts = append(jqlParts, fmt.Sprintf("status = \"%s\"", jira.EscapeString(strings.TrimSpace(statuses[0]))))
+		} else {
+			statusList := make([]string, len(statuses))
+			for i, s := range statuses {
+				statusList[i] = fmt.Sprintf("\"%s\"", jira.EscapeString(strings.TrimSpace(s)))
+			}
+			jqlParts = append(jqlParts, fmt.Sprintf("status IN (%s)", strings.Join(statusList, ",")))
+		}
+	}
+
+	// Handle project
+	if listProject != "" {
+		jqlParts = append(jqlParts, fmt.Sprintf("project = \"%s\"", jira.EscapeString(listProject)))
+	}
+
+	// Build final JQL
+	jql := strings.Join(jqlParts, " AND ")
+	if jql == "" {
+		jql = "order by updated DESC"
+	} else {
+		jql += " order by updated DESC"
+	}
+	return jql, nil
+}
+
 func displayIssueList(issues []jira.Issue, total int) {
 	// Define colors
 	cyan := color.New(color.FgCyan, color.Bold)
@@ -163,4 +188,6 @@ func init() {
 	listCmd.Flags().StringVar(&listStatus, "status", "To Do,In Progress,Open,New,Backlog,In Review,In Development,In Validation", "Filter by status (comma-separated for multiple)")
 	listCmd.Flags().StringVar(&listProject, "project", "", "Filter by project key")
 	listCmd.Flags().IntVar(&listMaxResults, "max", 50, "Maximum number of results to return")
+	listCmd.Flags().StringVar(&listQuery, "query", "", "Run a saved query (see 'jet query') instead of the filters")
+	addOutputFlags(listCmd, &listFormat, issueColumns)
 }
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"jet/internal/config"
+	"jet/internal/jira"
+	"jet/internal/output"
+)
+
+var (
+	logStarted   string
+	logRemaining string
+	logList      bool
+	logUpdate    string
+	logDelete    string
+	logFormat    outputFlags
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log TICKET-KEY [DURATION] [MESSAGE]",
+	Short: "Log work on a ticket",
+	Long: `Log time spent on a ticket, or list, change and delete its worklogs.
+
+Durations use Jira's notation: 1h30m, 1h 30m, 90m, 1.5h, 2d, 1w. Days and
+weeks follow hours_per_day and days_per_week in the [jet] section of
+~/.jira_config (default 8 and 5). A bare number is minutes.
+
+Examples:
+  jet log PROJ-123 1h30m "Reviewed the API design"
+  jet log PROJ-123 2h --started "2024-03-01 13:00"
+  jet log PROJ-123 30m --started yesterday --remaining 4h
+  jet log PROJ-123 --list
+  jet log PROJ-123 45m "Pairing" --update 10231
+  jet log PROJ-123 --delete 10231`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if logList || logDelete != "" {
+			return cobra.ExactArgs(1)(cmd, args)
+		}
+		if len(args) < 2 {
+			return fmt.Errorf("requires a ticket key and a duration (e.g. jet log PROJ-123 1h30m)")
+		}
+		return cobra.RangeArgs(2, 3)(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		issueKey := args[0]
+
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+		units, err := durationUnits()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case logList:
+			worklogs, err := client.GetWorklogsContext(cmd.Context(), issueKey)
+			if err != nil {
+				return fmt.Errorf("failed to fetch worklogs: %w", err)
+			}
+			if !logFormat.readable() {
+				return output.Write(os.Stdout, worklogs, worklogColumns, logFormat.options())
+			}
+			fmt.Print(formatWorklogs(issueKey, worklogs, units))
+			return nil
+
+		case logDelete != "":
+			if err := client.DeleteWorklogContext(cmd.Context(), issueKey, logDelete); err != nil {
+				return fmt.Errorf("failed to delete worklog: %w", err)
+			}
+			fmt.Printf("Deleted worklog %s from %s\n", logDelete, issueKey)
+			return nil
+		}
+
+		spent, err := units.Parse(args[1])
+		if err != nil {
+			return err
+		}
+		in := jira.WorklogInput{TimeSpent: spent}
+		if len(args) > 2 {
+			in.Comment = args[2]
+		}
+		if logStarted != "" {
+			if in.Started, err = parseStarted(logStarted, time.Now()); err != nil {
+				return err
+			}
+		}
+		if logRemaining != "" {
+			if in.RemainingEstimate, err = units.Parse(logRemaining); err != nil {
+				return fmt.Errorf("invalid --remaining: %w", err)
+			}
+		}
+
+		if logUpdate != "" {
+			w, err := client.UpdateWorklogContext(cmd.Context(), issueKey, logUpdate, in)
+			if err != nil {
+				return fmt.Errorf("failed to update worklog: %w", err)
+			}
+			fmt.Printf("Updated worklog %s on %s: %s\n", w.ID, issueKey, units.Format(w.Duration()))
+			return nil
+		}
+
+		w, err := client.AddWorklogContext(cmd.Context(), issueKey, in)
+		if err != nil {
+			return fmt.Errorf("failed to log work: %w", err)
+		}
+		fmt.Printf("Logged %s on %s (worklog %s)\n", units.Format(w.Duration()), issueKey, w.ID)
+		return nil
+	},
+}
+
+// durationUnits returns the configured working-time units.
+func durationUnits() (jira.DurationUnits, error) {
+	ts, err := config.LoadTimeSettings()
+	if err != nil {
+		return jira.DefaultDurationUnits, err
+	}
+	return jira.DurationUnits{HoursPerDay: ts.HoursPerDay, DaysPerWeek: ts.DaysPerWeek}, nil
+}
+
+// parseStarted accepts "2024-03-01 13:00", "2024-03-01" (9:00 that day),
+// "13:00" (today), "today" or "yesterday" (9:00), all in local time.
+func parseStarted(s string, now time.Time) (time.Time, error) {
+	day := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 9, 0, 0, 0, time.Local)
+	}
+	switch strings.ToLower(s) {
+	case "now":
+		return now, nil
+	case "today":
+		return day(now), nil
+	case "yesterday":
+		return day(now.AddDate(0, 0, -1)), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02 15:04", s, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return day(t), nil
+	}
+	if t, err := time.ParseInLocation("15:04", s, time.Local); err == nil {
+		return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, time.Local), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --started %q: use \"2024-03-01 13:00\", 2024-03-01, 13:00, today or yesterday", s)
+}
+
+// worklogColumns are the columns of `jet log --list`.
+var worklogColumns = []output.Column[jira.Worklog]{
+	{Name: "id", Value: func(w jira.Worklog) string { return w.ID }},
+	{Name: "started", Value: func(w jira.Worklog) string { return w.Started }},
+	{Name: "author", Value: func(w jira.Worklog) string { return userName(&w.Author) }},
+	{Name: "time", Value: func(w jira.Worklog) string { return w.TimeSpent }},
+	{Name: "comment", Value: func(w jira.Worklog) string { return w.Comment }},
+	{Name: "seconds", Value: func(w jira.Worklog) string { return strconv.Itoa(w.TimeSpentSeconds) }, Extra: true},
+}
+
+func formatWorklogs(issueKey string, worklogs []jira.Worklog, units jira.DurationUnits) string {
+	var sb strings.Builder
+
+	colCyan.Fprintf(&sb, "Worklogs on %s\n", issueKey)
+	if len(worklogs) == 0 {
+		sb.WriteString("No work logged\n")
+		return sb.String()
+	}
+
+	var total time.Duration
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	colYellow.Fprintln(w, "ID\tSTARTED\tAUTHOR\tTIME\tCOMMENT")
+	for _, wl := range worklogs {
+		total += wl.Duration()
+		author := wl.Author.DisplayName
+		if author == "" {
+			author = wl.Author.Name
+		}
+		comment := strings.Join(strings.Fields(wl.Comment), " ")
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			colGray.Sprint(wl.ID),
+			wl.StartTime().Local().Format("2006-01-02 15:04"),
+			truncateString(author, 20),
+			colGreen.Sprint(units.Format(wl.Duration())),
+			truncateString(comment, 50))
+	}
+	w.Flush()
+	fmt.Fprintf(&sb, "\nTotal: %s\n", colGreen.Sprint(units.Format(total)))
+	return sb.String()
+}
+
+func init() {
+	rootCmd.AddCommand(logCmd)
+
+	logCmd.Flags().StringVar(&logStarted, "started", "", "When the work started (\"2024-03-01 13:00\", 2024-03-01, 13:00, today, yesterday; default now)")
+	logCmd.Flags().StringVar(&logRemaining, "remaining", "", "Set the remaining estimate instead of reducing it automatically")
+	logCmd.Flags().BoolVar(&logList, "list", false, "List the ticket's worklogs")
+	logCmd.Flags().StringVar(&logUpdate, "update", "", "Replace the worklog with this ID")
+	logCmd.Flags().StringVar(&logDelete, "delete", "", "Delete the worklog with this ID")
+	addOutputFlags(logCmd, &logFormat, worklogColumns)
+	logCmd.MarkFlagsMutuallyExclusive("list", "update", "delete")
+}
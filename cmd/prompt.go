@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+	"jet/internal/jira"
+)
+
+// stdinIsTerminal reports whether stdin is an interactive terminal, i.e.
+// whether it is safe to prompt.
+func stdinIsTerminal() bool {
+	fd := os.Stdin.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
+// promptCreateField asks for a required create-screen field until a valid
+// value is given. Fields with allowed values show a numbered list and accept
+// numbers, names or unique name prefixes; array fields take a comma-separated
+// list. The result is in the JSON shape Jira expects for the field.
+func promptCreateField(ctx context.Context, client *jira.Client, reader *bufio.Reader, f jira.CreateMetaField) (interface{}, error) {
+	cyan := color.New(color.FgCyan, color.Bold)
+	gray := color.New(color.FgHiBlack)
+
+	fmt.Println()
+	cyan.Printf("%s is required\n", f.Name)
+	if len(f.AllowedValues) > 0 {
+		for i, label := range f.Labels() {
+			fmt.Printf("  %s %s\n", gray.Sprintf("%2d)", i+1), label)
+		}
+	}
+
+	for {
+		hint := "value"
+		switch {
+		case len(f.AllowedValues) > 0 && f.IsArray():
+			hint = "numbers or names, comma-separated"
+		case len(f.AllowedValues) > 0:
+			hint = "number or name"
+		case f.IsArray():
+			hint = "comma-separated values"
+		}
+		fmt.Printf("%s (%s): ", f.Name, hint)
+
+		input, err := reader.ReadString('\n')
+		input = strings.TrimSpace(input)
+		if input == "" {
+			if err == io.EOF {
+				return nil, fmt.Errorf("no value given for required field %s", f.Name)
+			}
+			continue
+		}
+
+		value, verr := createFieldValue(ctx, client, f, input)
+		if verr == nil {
+			return value, nil
+		}
+		fmt.Printf("  %s\n", color.RedString(verr.Error()))
+		if err == io.EOF {
+			return nil, verr
+		}
+	}
+}
+
+// createFieldValue converts prompt input for a create-screen field.
+func createFieldValue(ctx context.Context, client *jira.Client, f jira.CreateMetaField, input string) (interface{}, error) {
+	if len(f.AllowedValues) == 0 {
+		field := jira.Field{ID: f.FieldID, Name: f.Name, Schema: f.Schema}
+		return field.Value(ctx, input, client.ResolveUserContext)
+	}
+
+	parts := []string{input}
+	if f.IsArray() {
+		parts = strings.Split(input, ",")
+	}
+	var refs []interface{}
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		v, err := matchAllowed(f, part)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, allowedRef(v))
+	}
+	if len(refs) == 0 {
+		return nil, fmt.Errorf("no value given for %s", f.Name)
+	}
+	if f.IsArray() {
+		return refs, nil
+	}
+	return refs[0], nil
+}
+
+// matchAllowed accepts a 1-based list number as well as a name or prefix.
+func matchAllowed(f jira.CreateMetaField, input string) (jira.AllowedValue, error) {
+	if n, err := strconv.Atoi(input); err == nil && n >= 1 && n <= len(f.AllowedValues) {
+		return f.AllowedValues[n-1], nil
+	}
+	return f.Match(input)
+}
+
+// allowedRef refers to an allowed value by ID when it has one.
+func allowedRef(v jira.AllowedValue) map[string]string {
+	switch {
+	case v.ID != "":
+		return map[string]string{"id": v.ID}
+	case v.Value != "":
+		return map[string]string{"value": v.Value}
+	}
+	return map[string]string{"name": v.Label()}
+}
+
+// createFieldFlag names the create flag that sets a field, for error hints.
+func createFieldFlag(f jira.CreateMetaField) string {
+	switch f.FieldID {
+	case "priority":
+		return "--priority"
+	case "labels":
+		return "--labels"
+	case "components":
+		return "--components"
+	case "fixVersions":
+		return "--fix-version"
+	case "assignee":
+		return "--assignee"
+	case "description":
+		return "--description"
+	case "parent":
+		return "--epic"
+	}
+	return fmt.Sprintf("--field %q", f.Name+"=...")
+}
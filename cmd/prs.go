@@ -1,14 +1,16 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"text/tabwriter"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"jet/internal/github"
+	"jet/internal/output"
 	"jet/internal/prs"
 )
 
@@ -16,6 +18,7 @@ var (
 	prsSource string
 	prsLimit  int
 	prsJSON   bool
+	prsFormat outputFlags
 )
 
 var prsCmd = &cobra.Command{
@@ -36,7 +39,7 @@ var prsMineCmd = &cobra.Command{
 	Use:   "mine",
 	Short: "Your open PRs across Gerrit and GitHub",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runPRs(prs.Mine, "Your open PRs")
+		return runPRs(cmd.Context(), prs.MineContext, "Your open PRs")
 	},
 }
 
@@ -44,7 +47,7 @@ var prsTeamCmd = &cobra.Command{
 	Use:   "team",
 	Short: "PRs awaiting your review across Gerrit and GitHub",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runPRs(prs.Team, "PRs awaiting your review")
+		return runPRs(cmd.Context(), prs.TeamContext, "PRs awaiting your review")
 	},
 }
 
@@ -121,25 +124,26 @@ func runReposList() error {
 	return nil
 }
 
-func runPRs(fetch func(*prs.Config, prs.Options) ([]prs.PR, []error), heading string) error {
+func runPRs(ctx context.Context, fetch func(context.Context, *prs.Config, prs.Options) ([]prs.PR, []error), heading string) error {
 	cfg, err := prs.LoadConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load [prs] config: %w", err)
 	}
 
-	list, errs := fetch(cfg, prs.Options{Source: prsSource, Limit: prsLimit})
-
-	if prsJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(list)
-	}
+	list, errs := fetch(ctx, cfg, prs.Options{Source: prsSource, Limit: prsLimit})
 
 	// Surface per-source errors without aborting — partial results are useful.
 	for _, e := range errs {
 		fmt.Fprintln(os.Stderr, color.YellowString("! %v", e))
 	}
 
+	if prsJSON {
+		prsFormat.format = output.JSON
+	}
+	if !prsFormat.readable() {
+		return output.Write(os.Stdout, list, prColumns, prsFormat.options())
+	}
+
 	if len(list) == 0 {
 		fmt.Println("No PRs found.")
 		return nil
@@ -183,6 +187,21 @@ func runPRs(fetch func(*prs.Config, prs.Options) ([]prs.PR, []error), heading st
 	return nil
 }
 
+// prColumns are the columns of jet prs mine and team.
+var prColumns = []output.Column[prs.PR]{
+	{Name: "source", Value: func(p prs.PR) string { return string(p.Source) }},
+	{Name: "repo", Value: func(p prs.PR) string { return p.Repo }},
+	{Name: "number", Value: func(p prs.PR) string { return strconv.Itoa(p.Number) }},
+	{Name: "title", Value: func(p prs.PR) string { return p.Title }},
+	{Name: "status", Value: func(p prs.PR) string { return p.Status }},
+	{Name: "author", Value: func(p prs.PR) string { return p.Author }},
+	{Name: "reviewable", Value: func(p prs.PR) string { return strconv.FormatBool(p.Reviewable) }},
+	{Name: "block_reason", Value: func(p prs.PR) string { return p.BlockReason }, Extra: true},
+	{Name: "draft", Value: func(p prs.PR) string { return strconv.FormatBool(p.Draft) }, Extra: true},
+	{Name: "url", Value: func(p prs.PR) string { return p.URL }, Extra: true},
+	{Name: "updated", Value: func(p prs.PR) string { return p.Updated }, Extra: true},
+}
+
 func statusColor(s string) string {
 	switch s {
 	case "approved", "CR+2":
@@ -215,5 +234,7 @@ func init() {
 		c.Flags().StringVar(&prsSource, "source", "all", "Which source to query (all, gerrit, github)")
 		c.Flags().IntVarP(&prsLimit, "limit", "n", 25, "Max results per source")
 		c.Flags().BoolVar(&prsJSON, "json", false, "Output raw JSON")
+		c.Flags().MarkDeprecated("json", "use --format json")
+		addOutputFlags(c, &prsFormat, prColumns)
 	}
 }
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"jet/internal/config"
+	"jet/internal/output"
+)
+
+var queryFormat outputFlags
+
+var queryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "Manage saved JQL queries",
+	Long: `Manage named JQL queries stored in the [queries] section of ~/.jira_config:
+
+  [queries]
+  bugs = project = PROJ AND type = Bug AND resolution is EMPTY
+  review = status = "In Review" ORDER BY updated DESC
+
+Run a saved query with 'jet list --query NAME'. 'jet tui' shows one tab per
+saved query, switched with the number keys. Run with no subcommand to list
+saved queries.
+
+Examples:
+  jet query save bugs "project = PROJ AND type = Bug"
+  jet query list
+  jet list --query bugs
+  jet query delete bugs`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error { return runQueryList() },
+}
+
+var querySaveCmd = &cobra.Command{
+	Use:   "save NAME JQL",
+	Short: "Save a JQL query under a name (replacing any query of that name)",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		// Allow the JQL unquoted: jet query save bugs type = Bug
+		jql := strings.Join(args[1:], " ")
+		if err := config.SaveQuery(name, jql); err != nil {
+			return err
+		}
+		fmt.Printf("Saved query %s\n", strings.ToLower(name))
+		return nil
+	},
+}
+
+var queryListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List saved queries",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runQueryList()
+	},
+}
+
+var queryShowCmd = &cobra.Command{
+	Use:   "show NAME",
+	Short: "Print a saved query's JQL",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		jql, err := config.GetQuery(args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Println(jql)
+		return nil
+	},
+}
+
+var queryDeleteCmd = &cobra.Command{
+	Use:     "delete NAME",
+	Aliases: []string{"rm"},
+	Short:   "Delete a saved query",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.DeleteQuery(args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("Deleted query %s\n", strings.ToLower(args[0]))
+		return nil
+	},
+}
+
+func runQueryList() error {
+	queries, err := config.ListQueries()
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	if !queryFormat.readable() {
+		return output.Write(os.Stdout, queries, queryColumns, queryFormat.options())
+	}
+	if len(queries) == 0 {
+		fmt.Println("No saved queries. Save one with 'jet query save NAME JQL'.")
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	colYellow.Fprintln(w, "NAME\tJQL")
+	for _, q := range queries {
+		fmt.Fprintf(w, "%s\t%s\n", colCyan.Sprint(q.Name), q.JQL)
+	}
+	return w.Flush()
+}
+
+// queryColumns are the columns of `jet query list`.
+var queryColumns = []output.Column[config.SavedQuery]{
+	{Name: "name", Value: func(q config.SavedQuery) string { return q.Name }},
+	{Name: "jql", Value: func(q config.SavedQuery) string { return q.JQL }},
+}
+
+func init() {
+	rootCmd.AddCommand(queryCmd)
+	queryCmd.AddCommand(querySaveCmd)
+	queryCmd.AddCommand(queryListCmd)
+	queryCmd.AddCommand(queryShowCmd)
+	queryCmd.AddCommand(queryDeleteCmd)
+
+	addOutputFlags(queryCmd, &queryFormat, queryColumns)
+	addOutputFlags(queryListCmd, &queryFormat, queryColumns)
+}
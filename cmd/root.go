@@ -1,9 +1,17 @@
 package cmd
 
 import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
 	"github.com/spf13/cobra"
+	"jet/internal/config"
 )
 
+var profileFlag string
+
 var rootCmd = &cobra.Command{
 	Use:   "jet",
 	Short: "A command-line tool for interacting with JIRA",
@@ -17,13 +25,39 @@ Set environment variables or create ~/.jira_config:
   JIRA_URL - Your JIRA instance URL (e.g., https://yourcompany.atlassian.net)
   JIRA_EMAIL - Your email address (for cloud instances)
   JIRA_API_TOKEN - Your API token
-  JIRA_USERNAME - Your username (for server instances)`,
+  JIRA_USERNAME - Your username (for server instances)
+
+Retries:
+Requests that hit rate limits (429) or transient gateway errors are retried
+with backoff. Tune with max_retries / max_retry_wait in the [jet] section or
+JET_MAX_RETRIES / JET_MAX_RETRY_WAIT.
+
+Profiles:
+Define [profile NAME] sections in ~/.jira_config and pick one with --profile,
+the JET_PROFILE environment variable, or 'jet config profiles use NAME'.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		config.SelectProfile(profileFlag)
+		return configureHTTP()
+	},
 }
 
+// Execute runs the root command. Interrupts (Ctrl-C) and SIGTERM cancel the
+// command's context so in-flight requests stop promptly.
 func Execute() error {
-	return rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return rootCmd.ExecuteContext(ctx)
+}
+
+// argsAccepted stops cmd printing its usage on errors: once its arguments
+// have been checked, failures are no longer about how it was called.
+func argsAccepted(cmd *cobra.Command) {
+	cmd.SilenceUsage = true
 }
 
 func init() {
-	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	// Shell completion (jet completion bash|zsh|fish) stays out of the help
+	// listing; create uses it for allowed-value completion.
+	rootCmd.CompletionOptions.HiddenDefaultCmd = true
+	rootCmd.PersistentFlags().StringVar(&profileFlag, "profile", "", "Configuration profile to use (overrides JET_PROFILE and default_profile)")
 }
\ No newline at end of file
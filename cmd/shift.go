@@ -2,42 +2,42 @@ package cmd
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
-	"jet/internal/config"
 	"jet/internal/jira"
 )
 
 var shiftCmd = &cobra.Command{
-	Use:   "shift TICKET-KEY STATUS",
+	Use:   "shift [TICKET-KEY] STATUS",
 	Short: "Transition a JIRA ticket to a new status",
 	Long: `Transition a JIRA ticket to a new status.
 
 The command will find the appropriate transition based on the target status name.
 Status names are case-insensitive and can be partial matches.
+Without TICKET-KEY, the key is taken from the current git branch or recent
+commits.
 
 Examples:
   jet shift LX-123 "In Progress"
   jet shift LX-123 done
-  jet shift LX-123 closed`,
-	Args: cobra.ExactArgs(2),
+  jet shift LX-123 closed
+  jet shift review                 # The ticket of the current branch`,
+	Args: cobra.RangeArgs(1, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ticketKey := args[0]
-		targetStatus := args[1]
-
-		// Load configuration
-		cfg, err := config.Load()
+		targetStatus := args[len(args)-1]
+		ticketKey, err := ticketArg(cmd.Context(), args[:len(args)-1])
 		if err != nil {
-			return fmt.Errorf("configuration error: %w", err)
+			return err
 		}
 
-		// Create JIRA client
-		client := jira.NewClient(cfg.URL, cfg.Email, cfg.Username, cfg.Token)
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
 
 		// Get available transitions
-		transitions, err := client.GetTransitions(ticketKey)
+		transitions, err := client.GetTransitionsContext(cmd.Context(), ticketKey)
 		if err != nil {
 			return fmt.Errorf("failed to get transitions: %w", err)
 		}
@@ -47,16 +47,7 @@ Examples:
 		}
 
 		// Find matching transition
-		var matchedTransition *jira.Transition
-		targetStatusLower := strings.ToLower(targetStatus)
-
-		for i := range transitions {
-			transitionStatusLower := strings.ToLower(transitions[i].To.Name)
-			if transitionStatusLower == targetStatusLower || strings.Contains(transitionStatusLower, targetStatusLower) {
-				matchedTransition = &transitions[i]
-				break
-			}
-		}
+		matchedTransition := jira.MatchTransition(transitions, targetStatus)
 
 		if matchedTransition == nil {
 			// Show available transitions
@@ -72,7 +63,7 @@ Examples:
 		}
 
 		// Perform the transition
-		if err := client.TransitionIssue(ticketKey, matchedTransition.ID); err != nil {
+		if err := client.TransitionIssueContext(cmd.Context(), ticketKey, matchedTransition.ID); err != nil {
 			return fmt.Errorf("failed to transition issue: %w", err)
 		}
 
@@ -0,0 +1,548 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"jet/internal/config"
+	"jet/internal/jira"
+	"jet/internal/output"
+)
+
+var (
+	sprintBoard      string
+	sprintFormat     outputFlags
+	sprintListFormat outputFlags
+	sprintStates     []string
+	sprintEnd        string
+	sprintMoveTo     string
+)
+
+var sprintCmd = &cobra.Command{
+	Use:   "sprint [SPRINT]",
+	Short: "Show the active sprint grouped by status",
+	Long: `Show a sprint's issues grouped by status, with issue and story point totals.
+
+Without an argument the board's active sprint is shown. SPRINT is a sprint
+ID, "active" or "next" (the board's first future sprint). The board is
+--board (an ID or name) or the 'board' key of the active profile (or [jira]
+section) in ~/.jira_config.
+
+Examples:
+  jet sprint                               # Active sprint of the default board
+  jet sprint --board "Team Rocket"
+  jet sprint next --format json
+  jet sprint list --state closed
+  jet sprint add next PROJ-1 PROJ-2        # Plan issues into the next sprint
+  jet sprint backlog PROJ-3                # Take an issue out of its sprint
+  jet sprint start next --end 2w
+  jet sprint complete active --move-to next`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+
+		var sprints []jira.Sprint
+		if len(args) == 1 {
+			sprint, err := resolveSprint(ctx, client, args[0])
+			if err != nil {
+				return err
+			}
+			sprints = []jira.Sprint{*sprint}
+		} else {
+			board, err := resolveBoard(ctx, client, sprintBoard)
+			if err != nil {
+				return err
+			}
+			all, err := client.GetSprintsContext(ctx, board.ID, jira.SprintActive)
+			if err != nil {
+				return fmt.Errorf("failed to fetch sprints: %w", err)
+			}
+			for _, s := range all {
+				if s.State == jira.SprintActive {
+					sprints = append(sprints, s)
+				}
+			}
+			if len(sprints) == 0 && sprintFormat.readable() {
+				fmt.Printf("No active sprint on board %s\n", board.Name)
+				return nil
+			}
+		}
+
+		// Boards may run parallel sprints; show each, as one row per sprint
+		// however many there are.
+		reports := []sprintReport{}
+		for _, s := range sprints {
+			issues, err := client.GetSprintIssuesContext(ctx, s.ID)
+			if err != nil {
+				return fmt.Errorf("failed to fetch issues of sprint %s: %w", s.Name, err)
+			}
+			reports = append(reports, buildSprintReport(s, issues))
+		}
+
+		if !sprintFormat.readable() {
+			return output.Write(os.Stdout, reports, sprintReportColumns, sprintFormat.options())
+		}
+		for i, r := range reports {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Print(formatSprintReport(r, time.Now()))
+		}
+		return nil
+	},
+}
+
+var sprintListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List a board's sprints",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+		board, err := resolveBoard(cmd.Context(), client, sprintBoard)
+		if err != nil {
+			return err
+		}
+		sprints, err := client.GetSprintsContext(cmd.Context(), board.ID, sprintStates...)
+		if err != nil {
+			return fmt.Errorf("failed to fetch sprints: %w", err)
+		}
+
+		if !sprintListFormat.readable() {
+			return output.Write(os.Stdout, sprints, sprintColumns, sprintListFormat.options())
+		}
+		fmt.Print(formatSprintList(board, sprints))
+		return nil
+	},
+}
+
+var sprintAddCmd = &cobra.Command{
+	Use:   "add SPRINT TICKET-KEY...",
+	Short: "Move tickets into a sprint",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+		sprint, err := resolveSprint(cmd.Context(), client, args[0])
+		if err != nil {
+			return err
+		}
+		keys := upperKeys(args[1:])
+		if err := client.MoveIssuesToSprintContext(cmd.Context(), sprint.ID, keys); err != nil {
+			return fmt.Errorf("failed to move issues: %w", err)
+		}
+		fmt.Printf("Moved %s to %s\n", strings.Join(keys, ", "), sprint.Name)
+		return nil
+	},
+}
+
+var sprintBacklogCmd = &cobra.Command{
+	Use:   "backlog TICKET-KEY...",
+	Short: "Move tickets out of their sprint into the backlog",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+		keys := upperKeys(args)
+		if err := client.MoveIssuesToBacklogContext(cmd.Context(), keys); err != nil {
+			return fmt.Errorf("failed to move issues: %w", err)
+		}
+		fmt.Printf("Moved %s to the backlog\n", strings.Join(keys, ", "))
+		return nil
+	},
+}
+
+var sprintStartCmd = &cobra.Command{
+	Use:   "start SPRINT",
+	Short: "Start a future sprint",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+		sprint, err := resolveSprint(cmd.Context(), client, args[0])
+		if err != nil {
+			return err
+		}
+		if sprint.State != jira.SprintFuture {
+			return fmt.Errorf("sprint %s is %s; only future sprints can be started", sprint.Name, sprint.State)
+		}
+
+		start := time.Now()
+		end := sprint.End()
+		if sprintEnd != "" || end.IsZero() || !end.After(start) {
+			spec := sprintEnd
+			if spec == "" {
+				spec = "2w"
+			}
+			if end, err = parseSprintEnd(spec, start); err != nil {
+				return err
+			}
+		}
+
+		started, err := client.StartSprintContext(cmd.Context(), sprint.ID, start, end)
+		if err != nil {
+			return fmt.Errorf("failed to start sprint: %w", err)
+		}
+		fmt.Printf("Started %s, ending %s\n", started.Name, end.Local().Format("Mon 2006-01-02"))
+		return nil
+	},
+}
+
+var sprintCompleteCmd = &cobra.Command{
+	Use:   "complete SPRINT",
+	Short: "Complete an active sprint",
+	Long: `Complete an active sprint. Unfinished issues go to the backlog, or to the
+sprint given with --move-to (a sprint ID or "next").`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		sprint, err := resolveSprint(ctx, client, args[0])
+		if err != nil {
+			return err
+		}
+		if sprint.State != jira.SprintActive {
+			return fmt.Errorf("sprint %s is %s; only active sprints can be completed", sprint.Name, sprint.State)
+		}
+
+		issues, err := client.GetSprintIssuesContext(ctx, sprint.ID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch sprint issues: %w", err)
+		}
+		var open []string
+		for _, issue := range issues {
+			if !issue.Fields.Status.IsDone() {
+				open = append(open, issue.Key)
+			}
+		}
+
+		target := "the backlog"
+		if sprintMoveTo != "" && sprintMoveTo != "backlog" && len(open) > 0 {
+			next, err := resolveSprint(ctx, client, sprintMoveTo)
+			if err != nil {
+				return err
+			}
+			if next.ID == sprint.ID {
+				return fmt.Errorf("--move-to is the sprint being completed")
+			}
+			if err := client.MoveIssuesToSprintContext(ctx, next.ID, open); err != nil {
+				return fmt.Errorf("failed to move unfinished issues: %w", err)
+			}
+			target = next.Name
+		}
+
+		if _, err := client.CompleteSprintContext(ctx, sprint.ID); err != nil {
+			return fmt.Errorf("failed to complete sprint: %w", err)
+		}
+		fmt.Printf("Completed %s: %d of %d issue(s) done", sprint.Name, len(issues)-len(open), len(issues))
+		if len(open) > 0 {
+			fmt.Printf(", %d moved to %s", len(open), target)
+		}
+		fmt.Println()
+		return nil
+	},
+}
+
+// resolveBoard finds the board named by arg (an ID or a name), falling back
+// to the 'board' key of the active profile.
+func resolveBoard(ctx context.Context, client *jira.Client, arg string) (*jira.Board, error) {
+	if arg == "" {
+		v, err := config.ProfileValue("board")
+		if err != nil {
+			return nil, err
+		}
+		arg = strings.TrimSpace(v)
+	}
+	if arg == "" {
+		return nil, fmt.Errorf("no board selected: use --board or add 'board = ID' to your profile in ~/.jira_config (see 'jet board' for IDs)")
+	}
+	return client.ResolveBoardContext(ctx, arg)
+}
+
+// resolveSprint finds a sprint by ID, or "active"/"next" on the board.
+func resolveSprint(ctx context.Context, client *jira.Client, arg string) (*jira.Sprint, error) {
+	if id, err := strconv.Atoi(arg); err == nil {
+		sprint, err := client.GetSprintContext(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch sprint: %w", err)
+		}
+		return sprint, nil
+	}
+
+	state := map[string]string{"active": jira.SprintActive, "next": jira.SprintFuture}[strings.ToLower(arg)]
+	if state == "" {
+		return nil, fmt.Errorf("invalid sprint %q: use a sprint ID, active or next", arg)
+	}
+	board, err := resolveBoard(ctx, client, sprintBoard)
+	if err != nil {
+		return nil, err
+	}
+	sprints, err := client.GetSprintsContext(ctx, board.ID, state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sprints: %w", err)
+	}
+	if len(sprints) == 0 {
+		return nil, fmt.Errorf("board %s has no %s sprint", board.Name, state)
+	}
+	return &sprints[0], nil
+}
+
+// parseSprintEnd accepts a date (2024-03-15) or a length from start (10d, 2w).
+func parseSprintEnd(s string, start time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t.Add(17 * time.Hour), nil
+	}
+	for unit, days := range map[string]int{"d": 1, "w": 7} {
+		if num, ok := strings.CutSuffix(s, unit); ok {
+			if n, err := strconv.Atoi(num); err == nil && n > 0 {
+				return start.AddDate(0, 0, n*days), nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid --end %q: use a date (2024-03-15) or a length (10d, 2w)", s)
+}
+
+func upperKeys(keys []string) []string {
+	out := make([]string, len(keys))
+	for i, k := range keys {
+		out[i] = strings.ToUpper(k)
+	}
+	return out
+}
+
+// sprintReport is a sprint's issues grouped by status, also its JSON shape.
+type sprintReport struct {
+	Sprint     jira.Sprint   `json:"sprint"`
+	Issues     int           `json:"issues"`
+	Done       int           `json:"done"`
+	Points     float64       `json:"points"`
+	DonePoints float64       `json:"donePoints"`
+	Groups     []statusGroup `json:"groups"`
+}
+
+type statusGroup struct {
+	Status   string       `json:"status"`
+	Category string       `json:"category,omitempty"`
+	Points   float64      `json:"points"`
+	Issues   []jira.Issue `json:"issues"`
+}
+
+// categoryOrder puts to-do statuses first and done ones last.
+var categoryOrder = map[string]int{"new": 0, "indeterminate": 1, "done": 2}
+
+// buildSprintReport groups issues by status, ordered by status category and
+// then by first appearance, which follows the board's rank.
+func buildSprintReport(sprint jira.Sprint, issues []jira.Issue) sprintReport {
+	r := sprintReport{Sprint: sprint, Issues: len(issues), Groups: []statusGroup{}}
+	index := map[string]int{}
+	for _, issue := range issues {
+		st := issue.Fields.Status
+		i, ok := index[st.Name]
+		if !ok {
+			i = len(r.Groups)
+			index[st.Name] = i
+			g := statusGroup{Status: st.Name}
+			if st.Category != nil {
+				g.Category = st.Category.Key
+			}
+			r.Groups = append(r.Groups, g)
+		}
+		r.Groups[i].Issues = append(r.Groups[i].Issues, issue)
+
+		var points float64
+		if issue.Fields.StoryPoints != nil {
+			points = *issue.Fields.StoryPoints
+		}
+		r.Groups[i].Points += points
+		r.Points += points
+		if st.IsDone() {
+			r.Done++
+			r.DonePoints += points
+		}
+	}
+
+	rank := func(g statusGroup) int {
+		if n, ok := categoryOrder[g.Category]; ok {
+			return n
+		}
+		return 1
+	}
+	// Insertion sort keeps first-appearance order within a category.
+	for i := 1; i < len(r.Groups); i++ {
+		for j := i; j > 0 && rank(r.Groups[j]) < rank(r.Groups[j-1]); j-- {
+			r.Groups[j], r.Groups[j-1] = r.Groups[j-1], r.Groups[j]
+		}
+	}
+	return r
+}
+
+func formatSprintReport(r sprintReport, now time.Time) string {
+	var sb strings.Builder
+
+	colCyan.Fprintf(&sb, "🏃 %s", r.Sprint.Name)
+	sb.WriteString(colGray.Sprintf(" (%s)", r.Sprint.State))
+	if start, end := r.Sprint.Start(), r.Sprint.End(); !start.IsZero() && !end.IsZero() {
+		fmt.Fprintf(&sb, "  %s → %s", start.Local().Format("Jan 2"), end.Local().Format("Jan 2"))
+		if r.Sprint.State == jira.SprintActive {
+			if days := int(end.Sub(now).Hours() / 24); days >= 0 {
+				sb.WriteString(colGray.Sprintf("  %d day(s) left", days))
+			} else {
+				sb.WriteString(colRed.Sprint("  overdue"))
+			}
+		}
+	}
+	sb.WriteString("\n")
+	if r.Sprint.Goal != "" {
+		fmt.Fprintf(&sb, "%s %s\n", colYellow.Sprint("🎯 Goal:"), r.Sprint.Goal)
+	}
+	sb.WriteString("\n")
+
+	if r.Issues == 0 {
+		sb.WriteString("No issues in this sprint\n")
+		return sb.String()
+	}
+
+	// Pad columns across all groups so the groups line up; tabwriter would
+	// align each group on its own.
+	type row struct{ key, typ, assignee, points, summary string }
+	rows := make([][]row, len(r.Groups))
+	var wKey, wType, wAssignee, wPoints int
+	for i, g := range r.Groups {
+		for _, issue := range g.Issues {
+			rw := row{key: issue.Key, typ: issue.Fields.IssueType.Name, assignee: "Unassigned", summary: truncateString(issue.Fields.Summary, 60)}
+			if a := issue.Fields.Assignee; a != nil {
+				rw.assignee = a.DisplayName
+				if rw.assignee == "" {
+					rw.assignee = a.Name
+				}
+				rw.assignee = truncateString(rw.assignee, 20)
+			}
+			if issue.Fields.StoryPoints != nil {
+				rw.points = formatPoints(*issue.Fields.StoryPoints)
+			}
+			wKey, wType = max(wKey, len(rw.key)), max(wType, len(rw.typ))
+			wAssignee, wPoints = max(wAssignee, len(rw.assignee)), max(wPoints, len(rw.points))
+			rows[i] = append(rows[i], rw)
+		}
+	}
+
+	hasPoints := r.Points > 0
+	for i, g := range r.Groups {
+		header := fmt.Sprintf("%s (%d", g.Status, len(g.Issues))
+		if hasPoints {
+			header += ", " + formatPoints(g.Points) + " pts"
+		}
+		header += ")"
+		getStatusColor(g.Status).Add(color.Bold).Fprintln(&sb, header)
+
+		for _, rw := range rows[i] {
+			assignee := fmt.Sprintf("%-*s", wAssignee, rw.assignee)
+			if rw.assignee == "Unassigned" {
+				assignee = colGray.Sprint(assignee)
+			}
+			fmt.Fprintf(&sb, "  %s  %-*s  %s  ", colBlue.Sprintf("%-*s", wKey, rw.key), wType, rw.typ, assignee)
+			if hasPoints {
+				fmt.Fprintf(&sb, "%*s  ", wPoints, rw.points)
+			}
+			sb.WriteString(rw.summary + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	pct := 100 * r.Done / r.Issues
+	fmt.Fprintf(&sb, "Total: %d issue(s), %s done (%d%%)", r.Issues, colGreen.Sprint(r.Done), pct)
+	if hasPoints {
+		fmt.Fprintf(&sb, "; %s of %s pts done", colGreen.Sprint(formatPoints(r.DonePoints)), formatPoints(r.Points))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+func formatSprintList(board *jira.Board, sprints []jira.Sprint) string {
+	var sb strings.Builder
+	colCyan.Fprintf(&sb, "Sprints of %s\n", board.Name)
+	if len(sprints) == 0 {
+		sb.WriteString("No sprints\n")
+		return sb.String()
+	}
+
+	date := func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return t.Local().Format("2006-01-02")
+	}
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	colYellow.Fprintln(w, "ID\tSTATE\tSTART\tEND\tNAME")
+	for _, s := range sprints {
+		state := s.State
+		switch s.State {
+		case jira.SprintActive:
+			state = colGreen.Sprint(state)
+		case jira.SprintClosed:
+			state = colGray.Sprint(state)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", s.ID, state, date(s.Start()), date(s.End()), s.Name)
+	}
+	w.Flush()
+	return sb.String()
+}
+
+// sprintColumns are the columns of `jet sprint list`.
+var sprintColumns = []output.Column[jira.Sprint]{
+	{Name: "id", Value: func(s jira.Sprint) string { return strconv.Itoa(s.ID) }},
+	{Name: "state", Value: func(s jira.Sprint) string { return s.State }},
+	{Name: "start", Value: func(s jira.Sprint) string { return s.StartDate }},
+	{Name: "end", Value: func(s jira.Sprint) string { return s.EndDate }},
+	{Name: "name", Value: func(s jira.Sprint) string { return s.Name }},
+	{Name: "goal", Value: func(s jira.Sprint) string { return s.Goal }, Extra: true},
+	{Name: "completed", Value: func(s jira.Sprint) string { return s.CompleteDate }, Extra: true},
+}
+
+// sprintReportColumns are the columns of jet sprint: the sprint's, then its
+// totals.
+var sprintReportColumns = append(output.Via(sprintColumns, func(r sprintReport) jira.Sprint { return r.Sprint }),
+	output.Column[sprintReport]{Name: "issues", Value: func(r sprintReport) string { return strconv.Itoa(r.Issues) }},
+	output.Column[sprintReport]{Name: "done", Value: func(r sprintReport) string { return strconv.Itoa(r.Done) }},
+	output.Column[sprintReport]{Name: "points", Value: func(r sprintReport) string { return formatPoints(r.Points) }},
+	output.Column[sprintReport]{Name: "donepoints", Value: func(r sprintReport) string { return formatPoints(r.DonePoints) }},
+)
+
+// formatPoints prints story points without a trailing .0.
+func formatPoints(p float64) string {
+	return strconv.FormatFloat(p, 'f', -1, 64)
+}
+
+func init() {
+	rootCmd.AddCommand(sprintCmd)
+	sprintCmd.AddCommand(sprintListCmd, sprintAddCmd, sprintBacklogCmd, sprintStartCmd, sprintCompleteCmd)
+
+	sprintCmd.PersistentFlags().StringVar(&sprintBoard, "board", "", "Board ID or name (default: 'board' in ~/.jira_config)")
+	addOutputFlags(sprintCmd, &sprintFormat, sprintReportColumns)
+	addOutputFlags(sprintListCmd, &sprintListFormat, sprintColumns)
+	sprintListCmd.Flags().StringSliceVar(&sprintStates, "state", []string{jira.SprintActive, jira.SprintFuture}, "Sprint states to list (active, future, closed)")
+	sprintStartCmd.Flags().StringVar(&sprintEnd, "end", "", "End date (2024-03-15) or length (10d, 2w); default the planned end or 2w")
+	sprintCompleteCmd.Flags().StringVar(&sprintMoveTo, "move-to", "", "Where unfinished issues go: a sprint ID, next, or backlog (default)")
+}
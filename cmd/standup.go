@@ -2,17 +2,19 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
-	"jet/internal/config"
 	"jet/internal/jira"
+	"jet/internal/output"
 )
 
 var (
 	standupDays    int
 	standupProject string
+	standupFormat  outputFlags
 )
 
 var standupCmd = &cobra.Command{
@@ -25,18 +27,17 @@ All tickets are scoped to the current user (assignee = currentUser()).
 Examples:
   jet standup                    # Default: last 2 days of completed + in progress
   jet standup --days 5           # Look back 5 days for completed tickets
-  jet standup --project PROJ     # Scope to a specific project`,
+  jet standup --project PROJ     # Scope to a specific project
+  jet standup --format yaml      # One entry per ticket, with its section`,
 	RunE: runStandup,
 }
 
 func runStandup(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load()
+	client, err := newJiraClient()
 	if err != nil {
-		return fmt.Errorf("configuration error: %w", err)
+		return err
 	}
 
-	client := jira.NewClient(cfg.URL, cfg.Email, cfg.Username, cfg.Token)
-
 	projectClause := ""
 	if standupProject != "" {
 		projectClause = fmt.Sprintf(" AND project = \"%s\"", jira.EscapeString(standupProject))
@@ -48,7 +49,7 @@ func runStandup(cmd *cobra.Command, args []string) error {
 		projectClause, standupDays,
 	)
 
-	completedResp, err := client.SearchIssues(completedJQL, 50)
+	completedResp, err := client.SearchIssuesContext(cmd.Context(), completedJQL, 50)
 	if err != nil {
 		return fmt.Errorf("failed to fetch completed tickets: %w", err)
 	}
@@ -59,16 +60,38 @@ func runStandup(cmd *cobra.Command, args []string) error {
 		projectClause,
 	)
 
-	wipResp, err := client.SearchIssues(wipJQL, 50)
+	wipResp, err := client.SearchIssuesContext(cmd.Context(), wipJQL, 50)
 	if err != nil {
 		return fmt.Errorf("failed to fetch in-progress tickets: %w", err)
 	}
 
+	if !standupFormat.readable() {
+		var items []standupItem
+		for _, issue := range completedResp.Issues {
+			items = append(items, standupItem{Section: "completed", Issue: issue})
+		}
+		for _, issue := range wipResp.Issues {
+			items = append(items, standupItem{Section: "in-progress", Issue: issue})
+		}
+		return output.Write(os.Stdout, items, standupColumns, standupFormat.options())
+	}
+
 	displayStandupReport(completedResp.Issues, wipResp.Issues)
 
 	return nil
 }
 
+// standupItem is a ticket of the report with its section, "completed" or
+// "in-progress", for the non-readable formats.
+type standupItem struct {
+	Section string `json:"section"`
+	jira.Issue
+}
+
+var standupColumns = append([]output.Column[standupItem]{
+	{Name: "section", Value: func(s standupItem) string { return s.Section }},
+}, output.Via(issueColumns, func(s standupItem) jira.Issue { return s.Issue })...)
+
 func displayStandupReport(completed []jira.Issue, wip []jira.Issue) {
 	cyan := color.New(color.FgCyan, color.Bold)
 	yellow := color.New(color.FgYellow)
@@ -160,4 +183,5 @@ func init() {
 	rootCmd.AddCommand(standupCmd)
 	standupCmd.Flags().IntVar(&standupDays, "days", 2, "Number of days to look back for completed tickets")
 	standupCmd.Flags().StringVar(&standupProject, "project", "", "Filter by project key")
+	addOutputFlags(standupCmd, &standupFormat, standupColumns)
 }
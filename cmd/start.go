@@ -6,34 +6,35 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
-	"jet/internal/config"
 	"jet/internal/jira"
 )
 
 var startCmd = &cobra.Command{
-	Use:   "start TICKET-KEY",
+	Use:   "start [TICKET-KEY]",
 	Short: "Start work on a ticket (transition to In Progress)",
 	Long: `Start work on a JIRA ticket by transitioning it to "In Progress" status.
 
 This is a shortcut for: jet shift TICKET-KEY "In Progress"
 
+Without TICKET-KEY, the key is taken from the current git branch or recent
+commits.
+
 Example:
   jet start LX-123`,
-	Args: cobra.ExactArgs(1),
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ticketKey := args[0]
-
-		// Load configuration
-		cfg, err := config.Load()
+		ticketKey, err := ticketArg(cmd.Context(), args)
 		if err != nil {
-			return fmt.Errorf("configuration error: %w", err)
+			return err
 		}
 
-		// Create JIRA client
-		client := jira.NewClient(cfg.URL, cfg.Email, cfg.Username, cfg.Token)
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
 
 		// Get available transitions
-		transitions, err := client.GetTransitions(ticketKey)
+		transitions, err := client.GetTransitionsContext(cmd.Context(), ticketKey)
 		if err != nil {
 			return fmt.Errorf("failed to get transitions: %w", err)
 		}
@@ -73,7 +74,7 @@ Example:
 		}
 
 		// Perform the transition
-		if err := client.TransitionIssue(ticketKey, matchedTransition.ID); err != nil {
+		if err := client.TransitionIssueContext(cmd.Context(), ticketKey, matchedTransition.ID); err != nil {
 			return fmt.Errorf("failed to transition issue: %w", err)
 		}
 
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"jet/internal/templates"
+)
+
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "List the issue templates for jet create --template",
+	Long: `List the issue templates in ~/.jet/templates/. Each template is a YAML
+file; bug.yaml is used with 'jet create --template bug':
+
+  about: Bug with a triage checklist
+  vars:
+    component:            # no default, so --var component=... is required
+    severity: Medium
+  issue:
+    project: PROJ
+    type: Bug
+    summary: "[${component}] ${title}"
+    description: |
+      Found in ${component}.
+    priority: ${severity}
+    labels: [bug]
+    components: ["${component}"]
+    assignee: me
+    fields:
+      Story Points: 3
+  children:               # created under the issue, as sub-tasks by default
+    - summary: Reproduce ${title}
+    - type: Task
+      summary: Add a regression test for ${title}
+
+${name} is replaced by the value of --var name=..., or by the default under
+vars; $$ stands for a literal $. List items that come out empty are dropped.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, err := templates.List()
+		if err != nil {
+			return err
+		}
+		if len(all) == 0 {
+			fmt.Printf("No templates in %s. See 'jet templates --help' for the format.\n", templates.Dir())
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		colYellow.Fprintln(w, "NAME\tCHILDREN\tVARIABLES\tABOUT")
+		for _, t := range all {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", colCyan.Sprint(t.Name), len(t.Children), strings.Join(t.Variables(), ", "), t.About)
+		}
+		return w.Flush()
+	},
+}
+
+// completeTemplates completes the names of the templates in ~/.jet/templates/.
+func completeTemplates(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	all, err := templates.List()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []string
+	for _, t := range all {
+		if strings.HasPrefix(t.Name, toComplete) {
+			names = append(names, t.Name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	rootCmd.AddCommand(templatesCmd)
+}
@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"jet/internal/config"
+	"jet/internal/jira"
+	"jet/internal/timer"
+)
+
+var (
+	timerComment string
+	timerSwitch  bool
+	timerDiscard bool
+	timerFormat  string
+)
+
+var timerCmd = &cobra.Command{
+	Use:   "timer",
+	Short: "Track time on a ticket and log it as a worklog",
+	Long: `Start a timer on a ticket and stop it to log the elapsed time as a worklog.
+
+The running timer is kept in ~/.jet/timer.json, so it survives restarts and
+is shared with the TUI. On stop the elapsed time is rounded using the [jet]
+settings in ~/.jira_config:
+
+  timer_round = 15m          # round to a multiple of this (default 1m)
+  timer_round_mode = up      # up, down or nearest (default)
+  timer_minimum = 5m         # shorter times are not logged (default 1m)
+
+Examples:
+  jet timer start PROJ-123
+  jet timer start PROJ-456 --switch      # Log PROJ-123 and start PROJ-456
+  jet timer status
+  jet timer stop --comment "Reviewed the API design"
+  jet timer stop --discard               # Stop without logging`,
+}
+
+var timerStartCmd = &cobra.Command{
+	Use:   "start TICKET-KEY",
+	Short: "Start a timer on a ticket",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		issueKey := strings.ToUpper(args[0])
+		store, err := newTimerStore()
+		if err != nil {
+			return err
+		}
+
+		running, err := store.Load()
+		if err != nil {
+			return err
+		}
+		if running != nil {
+			if running.IssueKey == issueKey {
+				fmt.Printf("Timer already running for %s (%s)\n", issueKey, formatElapsed(running.Elapsed(time.Now())))
+				return nil
+			}
+			if !timerSwitch {
+				return fmt.Errorf("a timer is already running for %s (%s); stop it first or use --switch",
+					running.IssueKey, formatElapsed(running.Elapsed(time.Now())))
+			}
+			client, err := newJiraClient()
+			if err != nil {
+				return err
+			}
+			res, err := store.Stop(cmd.Context(), client, "", time.Now())
+			if err != nil {
+				return err
+			}
+			printTimerStopped(res)
+		}
+
+		t, err := store.Start(issueKey, timerComment, time.Now())
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Timer started for %s at %s\n", t.IssueKey, t.Started.Format("15:04"))
+		return nil
+	},
+}
+
+var timerStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the timer and log the time",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := newTimerStore()
+		if err != nil {
+			return err
+		}
+
+		if timerDiscard {
+			t, err := store.Load()
+			if err != nil {
+				return err
+			}
+			if t == nil {
+				return timer.ErrNotRunning
+			}
+			if err := store.Clear(); err != nil {
+				return err
+			}
+			fmt.Printf("Timer for %s discarded (%s not logged)\n", t.IssueKey, formatElapsed(t.Elapsed(time.Now())))
+			return nil
+		}
+
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+		res, err := store.Stop(cmd.Context(), client, timerComment, time.Now())
+		if err != nil {
+			return err
+		}
+		printTimerStopped(res)
+		return nil
+	},
+}
+
+var timerStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the running timer",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := newTimerStore()
+		if err != nil {
+			return err
+		}
+		t, err := store.Load()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if timerFormat == "json" {
+			out := struct {
+				Running        bool    `json:"running"`
+				IssueKey       string  `json:"issueKey,omitempty"`
+				Started        *string `json:"started,omitempty"`
+				ElapsedSeconds int     `json:"elapsedSeconds,omitempty"`
+				LogSeconds     int     `json:"logSeconds,omitempty"`
+			}{Running: t != nil}
+			if t != nil {
+				started := t.Started.Format(time.RFC3339)
+				out.IssueKey = t.IssueKey
+				out.Started = &started
+				out.ElapsedSeconds = int(t.Elapsed(now).Seconds())
+				out.LogSeconds = int(store.Rounding.Apply(t.Elapsed(now)).Seconds())
+			}
+			jsonData, err := json.MarshalIndent(out, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format JSON: %w", err)
+			}
+			fmt.Println(string(jsonData))
+			return nil
+		}
+
+		if t == nil {
+			fmt.Println("No timer running")
+			return nil
+		}
+		elapsed := t.Elapsed(now)
+		fmt.Printf("%s  %s  %s\n", colCyan.Sprint(t.IssueKey), colGreen.Sprint(formatElapsed(elapsed)),
+			colGray.Sprintf("since %s", t.Started.Format("Mon 15:04")))
+		if logged := store.Rounding.Apply(elapsed); logged > 0 {
+			fmt.Printf("Stopping now would log %s\n", jira.FormatDuration(logged))
+		} else {
+			fmt.Println("Stopping now would log nothing (below the minimum)")
+		}
+		return nil
+	},
+}
+
+// newTimerStore returns the timer store with the configured rounding rules.
+func newTimerStore() (*timer.Store, error) {
+	ts, err := config.LoadTimeSettings()
+	if err != nil {
+		return nil, err
+	}
+	return &timer.Store{
+		Path: timer.DefaultPath(),
+		Rounding: timer.Rounding{
+			Increment: ts.TimerRound,
+			Mode:      ts.TimerRoundMode,
+			Minimum:   ts.TimerMinimum,
+		},
+	}, nil
+}
+
+func printTimerStopped(res *timer.StopResult) {
+	if res.Logged == 0 {
+		fmt.Printf("Timer for %s stopped after %s; too short to log\n", res.Timer.IssueKey, formatElapsed(res.Elapsed))
+		return
+	}
+	fmt.Printf("Logged %s on %s (timer ran %s)\n", jira.FormatDuration(res.Logged), res.Timer.IssueKey, formatElapsed(res.Elapsed))
+}
+
+// formatElapsed renders a running time as h:mm:ss.
+func formatElapsed(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	return fmt.Sprintf("%d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+}
+
+func init() {
+	rootCmd.AddCommand(timerCmd)
+	timerCmd.AddCommand(timerStartCmd, timerStopCmd, timerStatusCmd)
+
+	timerStartCmd.Flags().StringVar(&timerComment, "comment", "", "Worklog comment to use when the timer stops")
+	timerStartCmd.Flags().BoolVar(&timerSwitch, "switch", false, "Stop and log a timer running on another ticket first")
+	timerStopCmd.Flags().StringVar(&timerComment, "comment", "", "Worklog comment (replaces the one given at start)")
+	timerStopCmd.Flags().BoolVar(&timerDiscard, "discard", false, "Stop without logging any time")
+	// The status is one record, not a list, so like jet view it only has
+	// readable and JSON output rather than the list formats.
+	timerStatusCmd.Flags().StringVar(&timerFormat, "format", "readable", "Output format (readable or json)")
+}
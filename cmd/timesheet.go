@@ -0,0 +1,264 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"jet/internal/jira"
+	"jet/internal/output"
+)
+
+var (
+	timesheetWeek   bool
+	timesheetFrom   string
+	timesheetTo     string
+	timesheetFormat outputFlags
+)
+
+var timesheetCmd = &cobra.Command{
+	Use:   "timesheet",
+	Short: "Show the time you logged per day and ticket",
+	Long: `Report your worklogs aggregated per day and ticket.
+
+Without flags the report covers today. --week covers the current week from
+Monday; --from and --to pick any range of days (inclusive). Other formats
+than readable have one row per day and ticket with time logged.
+
+Examples:
+  jet timesheet                              # Today
+  jet timesheet --week                       # This week, one column per day
+  jet timesheet --from 2024-03-01 --to 2024-03-31
+  jet timesheet --week --format csv`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, to, err := timesheetRange(time.Now())
+		if err != nil {
+			return err
+		}
+
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+		me, err := client.GetCurrentUserContext(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("failed to get current user: %w", err)
+		}
+		worklogs, err := client.GetUserWorklogsContext(cmd.Context(), me, from, to)
+		if err != nil {
+			return fmt.Errorf("failed to fetch worklogs: %w", err)
+		}
+		sheet := buildTimesheet(worklogs, from, to)
+
+		if !timesheetFormat.readable() {
+			return output.Write(os.Stdout, sheet.rows(), timesheetColumns, timesheetFormat.options())
+		}
+		fmt.Print(formatTimesheet(sheet))
+		return nil
+	},
+}
+
+// timesheetRange returns the [from, to) range selected by the flags, in
+// local days.
+func timesheetRange(now time.Time) (time.Time, time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if timesheetWeek {
+		monday := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+		return monday, monday.AddDate(0, 0, 7), nil
+	}
+
+	from, to := today, today
+	var err error
+	if timesheetFrom != "" {
+		if from, err = time.ParseInLocation("2006-01-02", timesheetFrom, time.Local); err != nil {
+			return from, to, fmt.Errorf("invalid --from %q: use YYYY-MM-DD", timesheetFrom)
+		}
+	}
+	if timesheetTo != "" {
+		if to, err = time.ParseInLocation("2006-01-02", timesheetTo, time.Local); err != nil {
+			return from, to, fmt.Errorf("invalid --to %q: use YYYY-MM-DD", timesheetTo)
+		}
+	}
+	if to.Before(from) {
+		return from, to, fmt.Errorf("--to is before --from")
+	}
+	return from, to.AddDate(0, 0, 1), nil
+}
+
+// timesheet is the aggregated report.
+type timesheet struct {
+	From    string
+	To      string // inclusive
+	Total   int    // seconds
+	Days    []timesheetDay
+	Tickets []timesheetEntry
+}
+
+type timesheetDay struct {
+	Date    string
+	Total   int
+	Tickets []timesheetEntry
+}
+
+type timesheetEntry struct {
+	Key     string
+	Summary string
+	Seconds int
+}
+
+// timesheetRow is the time logged on one ticket on one day, the row of the
+// output formats.
+type timesheetRow struct {
+	Date    string `json:"date"`
+	Key     string `json:"key"`
+	Summary string `json:"summary"`
+	Seconds int    `json:"seconds"`
+}
+
+// rows lists the time of every ticket worked on, by day.
+func (sheet timesheet) rows() []timesheetRow {
+	rows := []timesheetRow{}
+	for _, d := range sheet.Days {
+		for _, e := range d.Tickets {
+			rows = append(rows, timesheetRow{Date: d.Date, Key: e.Key, Summary: e.Summary, Seconds: e.Seconds})
+		}
+	}
+	return rows
+}
+
+// timesheetColumns are the columns of jet timesheet.
+var timesheetColumns = []output.Column[timesheetRow]{
+	{Name: "date", Value: func(r timesheetRow) string { return r.Date }},
+	{Name: "key", Value: func(r timesheetRow) string { return r.Key }},
+	{Name: "time", Value: func(r timesheetRow) string { return jira.FormatHours(time.Duration(r.Seconds) * time.Second) }},
+	{Name: "summary", Value: func(r timesheetRow) string { return r.Summary }},
+	{Name: "seconds", Value: func(r timesheetRow) string { return strconv.Itoa(r.Seconds) }, Extra: true},
+}
+
+// buildTimesheet sums worklogs per local day and ticket. Every day of the
+// range is listed, including days without work.
+func buildTimesheet(worklogs []jira.Worklog, from, to time.Time) timesheet {
+	sheet := timesheet{From: from.Format("2006-01-02"), To: to.AddDate(0, 0, -1).Format("2006-01-02")}
+
+	perDay := map[string]map[string]int{}
+	perTicket := map[string]int{}
+	summaries := map[string]string{}
+	for _, w := range worklogs {
+		date := w.StartTime().Local().Format("2006-01-02")
+		if perDay[date] == nil {
+			perDay[date] = map[string]int{}
+		}
+		perDay[date][w.IssueKey] += w.TimeSpentSeconds
+		perTicket[w.IssueKey] += w.TimeSpentSeconds
+		summaries[w.IssueKey] = w.IssueSummary
+		sheet.Total += w.TimeSpentSeconds
+	}
+
+	for d := from; d.Before(to); d = d.AddDate(0, 0, 1) {
+		date := d.Format("2006-01-02")
+		day := timesheetDay{Date: date, Tickets: []timesheetEntry{}}
+		for key, secs := range perDay[date] {
+			day.Tickets = append(day.Tickets, timesheetEntry{Key: key, Summary: summaries[key], Seconds: secs})
+			day.Total += secs
+		}
+		sort.Slice(day.Tickets, func(i, j int) bool { return day.Tickets[i].Key < day.Tickets[j].Key })
+		sheet.Days = append(sheet.Days, day)
+	}
+
+	sheet.Tickets = []timesheetEntry{}
+	for key, secs := range perTicket {
+		sheet.Tickets = append(sheet.Tickets, timesheetEntry{Key: key, Summary: summaries[key], Seconds: secs})
+	}
+	sort.Slice(sheet.Tickets, func(i, j int) bool { return sheet.Tickets[i].Key < sheet.Tickets[j].Key })
+	return sheet
+}
+
+// formatTimesheet prints a ticket-by-day grid for up to a week, and a
+// per-day listing for longer ranges. Times are in hours so they add up
+// without day-length conversions.
+func formatTimesheet(sheet timesheet) string {
+	var sb strings.Builder
+	hours := func(n int) string { return jira.FormatHours(time.Duration(n) * time.Second) }
+	cell := func(n int) string {
+		if n == 0 {
+			return colGray.Sprint("-")
+		}
+		return hours(n)
+	}
+
+	if sheet.From == sheet.To {
+		colCyan.Fprintf(&sb, "Timesheet for %s\n\n", sheet.From)
+	} else {
+		colCyan.Fprintf(&sb, "Timesheet for %s to %s\n\n", sheet.From, sheet.To)
+	}
+	if sheet.Total == 0 {
+		sb.WriteString("No work logged\n")
+		return sb.String()
+	}
+
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	if len(sheet.Days) <= 7 {
+		header := []string{"TICKET"}
+		for _, d := range sheet.Days {
+			t, _ := time.Parse("2006-01-02", d.Date)
+			header = append(header, t.Format("Mon 01-02"))
+		}
+		header = append(header, "TOTAL", "SUMMARY")
+		colYellow.Fprintln(w, strings.Join(header, "\t"))
+
+		for _, ticket := range sheet.Tickets {
+			row := []string{colBlue.Sprint(ticket.Key)}
+			for _, d := range sheet.Days {
+				n := 0
+				for _, e := range d.Tickets {
+					if e.Key == ticket.Key {
+						n = e.Seconds
+					}
+				}
+				row = append(row, cell(n))
+			}
+			row = append(row, colGreen.Sprint(hours(ticket.Seconds)), truncateString(ticket.Summary, 40))
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+
+		row := []string{colYellow.Sprint("TOTAL")}
+		for _, d := range sheet.Days {
+			row = append(row, cell(d.Total))
+		}
+		row = append(row, colGreen.Sprint(hours(sheet.Total)), "")
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+		w.Flush()
+		return sb.String()
+	}
+
+	for _, d := range sheet.Days {
+		if d.Total == 0 {
+			continue
+		}
+		t, _ := time.Parse("2006-01-02", d.Date)
+		fmt.Fprintf(w, "%s\t%s\t\n", colYellow.Sprint(t.Format("Mon 2006-01-02")), colGreen.Sprint(hours(d.Total)))
+		for _, e := range d.Tickets {
+			fmt.Fprintf(w, "  %s\t%s\t%s\n", colBlue.Sprint(e.Key), hours(e.Seconds), truncateString(e.Summary, 50))
+		}
+	}
+	w.Flush()
+	fmt.Fprintf(&sb, "\nTotal: %s across %d ticket(s)\n", colGreen.Sprint(hours(sheet.Total)), len(sheet.Tickets))
+	return sb.String()
+}
+
+func init() {
+	rootCmd.AddCommand(timesheetCmd)
+
+	timesheetCmd.Flags().BoolVar(&timesheetWeek, "week", false, "Report the current week (Monday to Sunday)")
+	timesheetCmd.Flags().StringVar(&timesheetFrom, "from", "", "First day of the report (YYYY-MM-DD)")
+	timesheetCmd.Flags().StringVar(&timesheetTo, "to", "", "Last day of the report (YYYY-MM-DD, default today)")
+	addOutputFlags(timesheetCmd, &timesheetFormat, timesheetColumns)
+	timesheetCmd.MarkFlagsMutuallyExclusive("week", "from")
+	timesheetCmd.MarkFlagsMutuallyExclusive("week", "to")
+}
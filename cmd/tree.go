@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"jet/internal/graph"
+)
+
+var (
+	treeJSON  bool
+	treeDepth int
+	treeUp    bool
+)
+
+var treeCmd = &cobra.Command{
+	Use:   "tree [TICKET-KEY]",
+	Short: "Show the hierarchy below a ticket as a tree",
+	Long: `Show the whole hierarchy below a ticket, such as initiative, epics,
+stories and sub-tasks, as an indented tree with each ticket's status and
+assignee. Tickets with children show how many of the tickets below them are
+done.
+
+Without a key, the ticket of the current git branch is used.
+
+Examples:
+  jet tree PROJ-100
+  jet tree PROJ-123 --up        # The whole hierarchy PROJ-123 belongs to
+  jet tree PROJ-100 --depth 2
+  jet tree PROJ-100 --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, err := ticketArg(cmd.Context(), args)
+		if err != nil {
+			return err
+		}
+		key = strings.ToUpper(key)
+
+		client, err := newJiraClient()
+		if err != nil {
+			return err
+		}
+
+		root := key
+		if treeUp {
+			if root, err = graph.TopOf(cmd.Context(), client, key); err != nil {
+				return err
+			}
+		}
+		tree, err := graph.Tree(cmd.Context(), client, root, graph.TreeOptions{Depth: treeDepth})
+		if err != nil {
+			return err
+		}
+
+		if treeJSON {
+			data, err := json.MarshalIndent(tree, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+		mark := ""
+		if root != key {
+			mark = key
+		}
+		fmt.Print(formatTree(tree, mark))
+		return nil
+	},
+}
+
+// formatTree draws the tree with box-drawing branches, highlighting mark.
+func formatTree(root *graph.TreeNode, mark string) string {
+	var sb strings.Builder
+	var draw func(n *graph.TreeNode, prefix, branch string)
+	draw = func(n *graph.TreeNode, prefix, branch string) {
+		sb.WriteString(colGray.Sprint(prefix + branch))
+		sb.WriteString(formatTreeNode(n, n.Key == mark))
+		sb.WriteString("\n")
+
+		childPrefix := prefix
+		switch branch {
+		case "├── ":
+			childPrefix += "│   "
+		case "└── ":
+			childPrefix += "    "
+		}
+		for i, c := range n.Children {
+			b := "├── "
+			if i == len(n.Children)-1 {
+				b = "└── "
+			}
+			draw(c, childPrefix, b)
+		}
+	}
+	draw(root, "", "")
+	return sb.String()
+}
+
+func formatTreeNode(n *graph.TreeNode, marked bool) string {
+	head := colCyan.Sprint(n.Key)
+	if marked {
+		head = colYellow.Sprint("▶ " + n.Key)
+	}
+	if n.Type != "" {
+		head += " " + colMagenta.Sprint("["+n.Type+"]")
+	}
+	head += " " + truncateString(n.Summary, 60)
+
+	details := []string{getStatusColor(n.Status).Sprint(n.Status)}
+	if n.Assignee != "" {
+		details = append(details, colGray.Sprint(n.Assignee))
+	}
+	if n.Total > 0 {
+		progress := fmt.Sprintf("%d/%d done", n.Done, n.Total)
+		if n.Done == n.Total {
+			details = append(details, colGreen.Sprint(progress))
+		} else {
+			details = append(details, colYellow.Sprint(progress))
+		}
+	}
+	return head + "  " + strings.Join(details, colGray.Sprint(" · "))
+}
+
+func init() {
+	rootCmd.AddCommand(treeCmd)
+
+	treeCmd.Flags().BoolVar(&treeJSON, "json", false, "Output the tree as JSON")
+	treeCmd.Flags().IntVar(&treeDepth, "depth", 0, "Levels to show below the ticket (default: all)")
+	treeCmd.Flags().BoolVar(&treeUp, "up", false, "Start from the top of the ticket's hierarchy")
+}
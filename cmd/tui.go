@@ -5,7 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"jet/internal/config"
-	"jet/internal/jira"
+	"jet/internal/httpclient"
 	"jet/internal/tui"
 )
 
@@ -20,18 +20,22 @@ var tuiCmd = &cobra.Command{
 	Long: `Launch a full-screen interactive terminal UI for browsing,
 viewing, creating, editing, and transitioning JIRA tickets.
 
+Each query saved with 'jet query save' gets a tab after the dashboard;
+press 1-9 to switch tabs.
+
 Examples:
   jet tui                          # Dashboard with your open tickets
   jet tui --project=PROJ           # Filter to a specific project
   jet tui --jql="assignee = me"    # Custom JQL query`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := config.Load()
+		// Retry notices on stderr would corrupt the full-screen UI.
+		httpclient.DefaultRetryPolicy.Notify = nil
+
+		client, err := newJiraClient()
 		if err != nil {
-			return fmt.Errorf("configuration error: %w", err)
+			return err
 		}
 
-		client := jira.NewClient(cfg.URL, cfg.Email, cfg.Username, cfg.Token)
-
 		// Build initial JQL
 		jql := tuiJQL
 		if jql == "" {
@@ -41,7 +45,31 @@ Examples:
 			}
 		}
 
-		return tui.Run(client, jql)
+		// Catch mistakes in --jql before the screen takes over.
+		if tuiJQL != "" {
+			results, err := client.ValidateJQLContext(cmd.Context(), tuiJQL)
+			if err == nil && len(results) == 1 && !results[0].Valid() {
+				fmt.Print(formatJQLValidation(results[0]))
+				return fmt.Errorf("invalid --jql")
+			}
+		}
+
+		// One tab per saved query after the dashboard.
+		tabs := []tui.Tab{{JQL: jql}}
+		queries, err := config.ListQueries()
+		if err != nil {
+			return fmt.Errorf("failed to read saved queries: %w", err)
+		}
+		for _, q := range queries {
+			tabs = append(tabs, tui.Tab{Name: q.Name, JQL: q.JQL})
+		}
+
+		timers, err := newTimerStore()
+		if err != nil {
+			return err
+		}
+
+		return tui.Run(cmd.Context(), client, tabs, timers)
 	},
 }
 
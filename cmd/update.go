@@ -7,7 +7,6 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
-	"jet/internal/config"
 	"jet/internal/jira"
 )
 
@@ -18,6 +17,8 @@ var (
 	updateEpic        string
 	updateParent      string
 	assignToMe        bool
+	updateMarkdown    bool
+	updateFields      []string
 )
 
 var editCmd = &cobra.Command{
@@ -25,27 +26,37 @@ var editCmd = &cobra.Command{
 	Short: "Edit a JIRA ticket",
 	Long: `Edit fields of a JIRA ticket.
 
-Currently supports editing the summary/title, description field, epic/parent linking, and assignment.`,
+Currently supports editing the summary/title, description field, epic/parent linking, and assignment.
+Any other field can be set by name or ID with --field "NAME=VALUE"; array
+fields such as labels take comma-separated values, and user fields take
+"me", an account ID, username or email.
+
+Use --markdown to convert the description from Markdown to rich text.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ticketKey := args[0]
 
 		// Check if any update flags are provided
-		if updateSummary == "" && updateDescription == "" && updateDescFile == "" && updateEpic == "" && updateParent == "" && !assignToMe {
-			return fmt.Errorf("no update fields specified. Use --summary, --description, --description-file, --epic, --parent, or --assign-to-me")
+		if updateSummary == "" && updateDescription == "" && updateDescFile == "" && updateEpic == "" && updateParent == "" && !assignToMe && len(updateFields) == 0 {
+			return fmt.Errorf("no update fields specified. Use --summary, --description, --description-file, --epic, --parent, --assign-to-me, or --field")
 		}
 
-		// Load configuration
-		cfg, err := config.Load()
+		client, err := newJiraClient()
 		if err != nil {
-			return fmt.Errorf("configuration error: %w", err)
+			return err
 		}
 
-		// Create JIRA client
-		client := jira.NewClient(cfg.URL, cfg.Email, cfg.Username, cfg.Token)
-
-		// Prepare fields to update
+		// Prepare fields to update, starting with any --field values
 		fields := make(map[string]interface{})
+		if len(updateFields) > 0 {
+			reg, err := client.FieldRegistry(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load field list: %w", err)
+			}
+			if fields, err = reg.ParseFieldValuesContext(cmd.Context(), updateFields); err != nil {
+				return err
+			}
+		}
 
 		// Handle summary update
 		if updateSummary != "" {
@@ -72,6 +83,9 @@ Currently supports editing the summary/title, description field, epic/parent lin
 		} else if updateDescription != "" {
 			fields["description"] = updateDescription
 		}
+		if desc, ok := fields["description"].(string); ok && updateMarkdown {
+			fields["description"] = jira.MarkdownToADF(desc)
+		}
 
 		// Handle epic/parent update
 		if updateEpic != "" {
@@ -84,7 +98,7 @@ Currently supports editing the summary/title, description field, epic/parent lin
 
 		// Handle self-assignment
 		if assignToMe {
-			currentUser, err := client.GetCurrentUser()
+			currentUser, err := client.GetCurrentUserContext(cmd.Context())
 			if err != nil {
 				return fmt.Errorf("failed to get current user: %w", err)
 			}
@@ -98,7 +112,12 @@ Currently supports editing the summary/title, description field, epic/parent lin
 		}
 
 		// Update the ticket
-		if err := client.UpdateIssue(ticketKey, fields); err != nil {
+		if updateMarkdown {
+			err = client.UpdateIssueADFContext(cmd.Context(), ticketKey, fields)
+		} else {
+			err = client.UpdateIssueContext(cmd.Context(), ticketKey, fields)
+		}
+		if err != nil {
 			return err
 		}
 
@@ -116,4 +135,6 @@ func init() {
 	editCmd.Flags().StringVar(&updateEpic, "epic", "", "Epic key to link this ticket to")
 	editCmd.Flags().StringVar(&updateParent, "parent", "", "Parent ticket key to link this ticket to")
 	editCmd.Flags().BoolVar(&assignToMe, "assign-to-me", false, "Assign the ticket to yourself")
+	editCmd.Flags().BoolVar(&updateMarkdown, "markdown", false, "Convert the description from Markdown to rich text (uses the v3 API)")
+	editCmd.Flags().StringArrayVar(&updateFields, "field", nil, "Set a field by name or ID as NAME=VALUE (repeatable)")
 }
\ No newline at end of file
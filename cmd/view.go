@@ -6,17 +6,19 @@ import (
 	"net/url"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
-	"jet/internal/config"
 	"jet/internal/jira"
 )
 
 var (
-	viewFormat string
-	viewOutput string
+	viewFormat    string
+	viewOutput    string
+	viewFields    []string
+	viewAllFields bool
 )
 
 // Pre-compiled regexes for formatHTMLContent — avoids recompiling on every call.
@@ -52,13 +54,17 @@ var (
 )
 
 var viewCmd = &cobra.Command{
-	Use:   "view TICKET-KEY|URL",
+	Use:   "view [TICKET-KEY|URL]",
 	Short: "View a JIRA ticket",
 	Long:  `Fetch and display information about a JIRA ticket.
-You can provide either a ticket key (e.g., LX-2894) or a full JIRA URL (e.g., https://company.atlassian.net/browse/ABC-123).`,
-	Args:  cobra.ExactArgs(1),
+You can provide either a ticket key (e.g., LX-2894) or a full JIRA URL (e.g., https://company.atlassian.net/browse/ABC-123).
+Without one, the key is taken from the current git branch or recent commits.`,
+	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ticketKey := args[0]
+		ticketKey, err := ticketArg(cmd.Context(), args)
+		if err != nil {
+			return err
+		}
 
 		// Check if the argument is a URL and extract the ticket key
 		if strings.Contains(ticketKey, "://") {
@@ -88,32 +94,61 @@ You can provide either a ticket key (e.g., LX-2894) or a full JIRA URL (e.g., ht
 			}
 		}
 
-		// Load configuration
-		cfg, err := config.Load()
+		client, err := newJiraClient()
 		if err != nil {
-			return fmt.Errorf("configuration error: %w", err)
+			return err
 		}
 
-		// Create JIRA client
-		client := jira.NewClient(cfg.URL, cfg.Email, cfg.Username, cfg.Token)
+		// Resolve requested custom fields to IDs
+		var reg *jira.FieldRegistry
+		var wanted []*jira.Field
+		var extraFields []string
+		if len(viewFields) > 0 || viewAllFields {
+			reg, err = client.FieldRegistry(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load field list: %w", err)
+			}
+			if viewAllFields {
+				extraFields = append(extraFields, "*all")
+			}
+			for _, name := range viewFields {
+				f, err := reg.Lookup(name)
+				if err != nil {
+					return err
+				}
+				if !f.Custom {
+					return fmt.Errorf("%q is a system field; only custom fields can be added with --field", f.Name)
+				}
+				wanted = append(wanted, f)
+				extraFields = append(extraFields, f.ID)
+			}
+		}
 
 		// Fetch the ticket
-		issue, err := client.GetIssue(ticketKey)
+		issue, err := client.GetIssueWithFieldsContext(cmd.Context(), ticketKey, extraFields)
 		if err != nil {
 			return err
 		}
+		named := namedCustomFields(reg, issue, wanted, viewAllFields)
 
 		// Format output
 		var output string
 		switch viewFormat {
 		case "json":
-			jsonData, err := json.MarshalIndent(issue, "", "  ")
+			out := issueJSON{Issue: issue}
+			if len(named) > 0 {
+				out.CustomFields = make(map[string]json.RawMessage, len(named))
+				for _, f := range named {
+					out.CustomFields[f.Key] = f.Raw
+				}
+			}
+			jsonData, err := json.MarshalIndent(out, "", "  ")
 			if err != nil {
 				return fmt.Errorf("failed to format JSON: %w", err)
 			}
 			output = string(jsonData)
 		default:
-			output = formatIssueReadable(issue)
+			output = formatIssueReadable(issue, named)
 		}
 
 		// Write output
@@ -147,13 +182,76 @@ var (
 	colGray    = color.New(color.FgHiBlack)
 )
 
-func formatIssueReadable(issue *jira.Issue) string {
+// issueJSON is the --format json shape: the issue plus any requested custom
+// fields keyed by name.
+type issueJSON struct {
+	*jira.Issue
+	CustomFields map[string]json.RawMessage `json:"customFields,omitempty"`
+}
+
+// namedField is a custom field value labelled for display. Key is the field
+// name, or the ID when several fields share that name.
+type namedField struct {
+	Key  string
+	ID   string
+	Name string
+	Raw  json.RawMessage
+}
+
+// namedCustomFields labels the issue's custom field values: the wanted fields
+// in order, or with all set every non-empty one sorted by name.
+func namedCustomFields(reg *jira.FieldRegistry, issue *jira.Issue, wanted []*jira.Field, all bool) []namedField {
+	if reg == nil {
+		return nil
+	}
+	var out []namedField
+	seen := make(map[string]bool)
+	add := func(id string) {
+		raw, ok := issue.Fields.Custom[id]
+		if !ok || seen[id] {
+			return
+		}
+		seen[id] = true
+		out = append(out, namedField{ID: id, Name: reg.Name(id), Raw: raw})
+	}
+	for _, f := range wanted {
+		add(f.ID)
+	}
+	if all {
+		ids := make([]string, 0, len(issue.Fields.Custom))
+		for id := range issue.Fields.Custom {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return reg.Name(ids[i]) < reg.Name(ids[j]) })
+		for _, id := range ids {
+			if jira.FormatFieldValue(issue.Fields.Custom[id]) != "" {
+				add(id)
+			}
+		}
+	}
+
+	counts := make(map[string]int)
+	for _, f := range out {
+		counts[f.Name]++
+	}
+	for i := range out {
+		out[i].Key = out[i].Name
+		if counts[out[i].Name] > 1 {
+			out[i].Key = out[i].ID
+		}
+	}
+	return out
+}
+
+func formatIssueReadable(issue *jira.Issue, custom []namedField) string {
 	var output strings.Builder
 	formatIssueHeader(&output, issue)
 	formatIssueFields(&output, issue)
 	formatIssueLinks(&output, issue)
 	formatIssuePeople(&output, issue)
 	formatIssueMetadata(&output, issue)
+	formatIssueTimeTracking(&output, issue)
+	formatIssueCustomFields(&output, custom)
 	formatIssueDescription(&output, issue)
 	formatIssueAttachments(&output, issue)
 	formatIssueComments(&output, issue)
@@ -271,6 +369,23 @@ func formatIssueMetadata(w *strings.Builder, issue *jira.Issue) {
 	}
 }
 
+func formatIssueTimeTracking(w *strings.Builder, issue *jira.Issue) {
+	tt := issue.Fields.TimeTracking
+	if tt.IsEmpty() {
+		return
+	}
+	value := func(s string) string {
+		if s == "" {
+			return colGray.Sprint("-")
+		}
+		return s
+	}
+	w.WriteString(fmt.Sprintf("%s %s  %s %s  %s %s\n",
+		colGreen.Sprint("⏱️  Estimate:"), value(tt.OriginalEstimate),
+		colGray.Sprint("Remaining:"), value(tt.RemainingEstimate),
+		colGray.Sprint("Logged:"), value(tt.TimeSpent)))
+}
+
 func formatIssueDescription(w *strings.Builder, issue *jira.Issue) {
 	if issue.Fields.DescriptionText == "" {
 		return
@@ -278,7 +393,11 @@ func formatIssueDescription(w *strings.Builder, issue *jira.Issue) {
 	w.WriteString("\n")
 	w.WriteString(colYellow.Sprint("📖 Description:\n"))
 	w.WriteString(colGray.Sprint("━━━━━━━━━━━━━━━━━━━━\n"))
-	w.WriteString(formatHTMLContent(issue.Fields.DescriptionText) + "\n")
+	if jira.IsADF(issue.Fields.Description) {
+		w.WriteString(issue.Fields.DescriptionPlain() + "\n")
+	} else {
+		w.WriteString(formatHTMLContent(issue.Fields.DescriptionText) + "\n")
+	}
 }
 
 func formatIssueAttachments(w *strings.Builder, issue *jira.Issue) {
@@ -309,6 +428,16 @@ func formatIssueAttachments(w *strings.Builder, issue *jira.Issue) {
 	}
 }
 
+func formatIssueCustomFields(w *strings.Builder, fields []namedField) {
+	for _, f := range fields {
+		value := jira.FormatFieldValue(f.Raw)
+		if value == "" {
+			value = colGray.Sprint("None")
+		}
+		w.WriteString(fmt.Sprintf("%s %s\n", colMagenta.Sprint("🧩 "+f.Key+":"), value))
+	}
+}
+
 func formatIssueComments(w *strings.Builder, issue *jira.Issue) {
 	comments := issue.Fields.Comment.Comments
 	if len(comments) == 0 {
@@ -332,7 +461,11 @@ func formatIssueComments(w *strings.Builder, issue *jira.Issue) {
 			created = created[:10]
 		}
 		w.WriteString(fmt.Sprintf("%s %s (%s):\n", colYellow.Sprintf("%d.", i+1), authorName, colGray.Sprint(created)))
-		w.WriteString(fmt.Sprintf("   %s\n\n", formatHTMLContent(c.Body)))
+		body := formatHTMLContent(c.Body)
+		if len(c.BodyADF) > 0 {
+			body = strings.ReplaceAll(c.PlainBody(), "\n", "\n   ")
+		}
+		w.WriteString(fmt.Sprintf("   %s\n\n", body))
 	}
 }
 
@@ -507,4 +640,6 @@ func init() {
 	
 	viewCmd.Flags().StringVar(&viewFormat, "format", "readable", "Output format (readable or json)")
 	viewCmd.Flags().StringVarP(&viewOutput, "output", "o", "", "Output file (default: stdout)")
+	viewCmd.Flags().StringArrayVar(&viewFields, "field", nil, "Include a custom field by name or ID (repeatable)")
+	viewCmd.Flags().BoolVar(&viewAllFields, "all-fields", false, "Include every custom field that has a value")
 }
\ No newline at end of file
@@ -0,0 +1,92 @@
+// Package auth implements the authentication strategies shared by the Jira
+// and Confluence clients: HTTP Basic (email/username + API token), Bearer
+// personal access tokens, and OAuth 2.0 (3LO) with refresh-token persistence.
+package auth
+
+import (
+	"fmt"
+	"net/http"
+
+	"jet/internal/config"
+	"jet/internal/secrets"
+)
+
+// Authenticator adds credentials to an outgoing request.
+type Authenticator interface {
+	Apply(req *http.Request) error
+}
+
+// Basic is HTTP Basic authentication with an email or username and an API
+// token, as used by Jira Cloud and older Server instances.
+type Basic struct {
+	User  string
+	Token string
+}
+
+func (b Basic) Apply(req *http.Request) error {
+	if b.User == "" || b.Token == "" {
+		return fmt.Errorf("authentication credentials not provided")
+	}
+	req.SetBasicAuth(b.User, b.Token)
+	return nil
+}
+
+// Bearer sends a personal access token (Jira/Confluence Data Center).
+type Bearer struct {
+	Token string
+}
+
+func (b Bearer) Apply(req *http.Request) error {
+	if b.Token == "" {
+		return fmt.Errorf("authentication credentials not provided")
+	}
+	req.Header.Set("Authorization", "Bearer "+b.Token)
+	return nil
+}
+
+// NewBasic returns Basic auth using email when set, otherwise username.
+func NewBasic(email, username, token string) Basic {
+	user := email
+	if user == "" {
+		user = username
+	}
+	return Basic{User: user, Token: token}
+}
+
+// FromConfig returns the Authenticator selected by cfg.Auth.
+func FromConfig(cfg *config.Config) (Authenticator, error) {
+	switch cfg.AuthMode() {
+	case "bearer":
+		return Bearer{Token: cfg.Token}, nil
+	case "oauth2":
+		return OAuth2FromConfig(cfg)
+	default:
+		return NewBasic(cfg.Email, cfg.Username, cfg.Token), nil
+	}
+}
+
+// OAuth2FromConfig builds the OAuth 2.0 authenticator for cfg. Tokens are
+// persisted in the secret store named by token_store (default "file") under
+// "<section>/oauth".
+func OAuth2FromConfig(cfg *config.Config) (*OAuth2, error) {
+	if cfg.OAuth.ClientID == "" {
+		return nil, fmt.Errorf("oauth2 auth requires oauth_client_id in ~/.jira_config")
+	}
+	backend := "file"
+	key := cfg.SecretKey + "/oauth"
+	if cfg.TokenStore != "" {
+		backend, key = config.ParseTokenStore(cfg.TokenStore, key)
+	}
+	store, err := secrets.Open(backend)
+	if err != nil {
+		return nil, err
+	}
+	return NewOAuth2(OAuth2Config{
+		ClientID:     cfg.OAuth.ClientID,
+		ClientSecret: cfg.OAuth.ClientSecret,
+		AuthURL:      cfg.OAuth.AuthURL,
+		TokenURL:     cfg.OAuth.TokenURL,
+		RedirectURL:  cfg.OAuth.RedirectURL,
+		Scopes:       cfg.OAuth.Scopes,
+	}, &SecretTokenStore{Store: store, Key: key}), nil
+}
@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"jet/internal/config"
+)
+
+type memTokenStore struct {
+	token *Token
+	saves int
+}
+
+func (m *memTokenStore) Load() (*Token, error) { return m.token, nil }
+func (m *memTokenStore) Save(t *Token) error   { m.token = t; m.saves++; return nil }
+
+func TestFromConfigSelectsStrategy(t *testing.T) {
+	basic, err := FromConfig(&config.Config{Email: "me@x", Token: "t"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest("GET", "/", nil)
+	if err := basic.Apply(req); err != nil {
+		t.Fatal(err)
+	}
+	if user, pass, ok := req.BasicAuth(); !ok || user != "me@x" || pass != "t" {
+		t.Errorf("basic auth header = %q", req.Header.Get("Authorization"))
+	}
+
+	bearer, err := FromConfig(&config.Config{Auth: "PAT", Token: "pat-123"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = httptest.NewRequest("GET", "/", nil)
+	if err := bearer.Apply(req); err != nil {
+		t.Fatal(err)
+	}
+	if got := req.Header.Get("Authorization"); got != "Bearer pat-123" {
+		t.Errorf("bearer header = %q", got)
+	}
+
+	if _, err := FromConfig(&config.Config{Auth: "oauth2"}); err == nil {
+		t.Error("oauth2 without client id should fail")
+	}
+}
+
+func TestOAuth2RefreshesAndPersistsRotatedToken(t *testing.T) {
+	var got map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  "new-access",
+			"refresh_token": "rotated-refresh",
+			"expires_in":    3600,
+		})
+	}))
+	defer server.Close()
+
+	store := &memTokenStore{token: &Token{AccessToken: "old", RefreshToken: "old-refresh", Expiry: time.Now().Add(-time.Minute)}}
+	o := NewOAuth2(OAuth2Config{ClientID: "cid", ClientSecret: "secret", TokenURL: server.URL}, store)
+
+	req := httptest.NewRequest("GET", "/", nil)
+	if err := o.Apply(req); err != nil {
+		t.Fatal(err)
+	}
+	if h := req.Header.Get("Authorization"); h != "Bearer new-access" {
+		t.Errorf("Authorization = %q", h)
+	}
+	if got["grant_type"] != "refresh_token" || got["refresh_token"] != "old-refresh" || got["client_id"] != "cid" {
+		t.Errorf("refresh request = %v", got)
+	}
+	if store.saves != 1 || store.token.RefreshToken != "rotated-refresh" {
+		t.Errorf("rotated refresh token not persisted: %+v", store.token)
+	}
+
+	// A valid token is reused without another refresh.
+	if err := o.Apply(httptest.NewRequest("GET", "/", nil)); err != nil {
+		t.Fatal(err)
+	}
+	if store.saves != 1 {
+		t.Errorf("valid token was refreshed again")
+	}
+}
+
+func TestOAuth2NotLoggedIn(t *testing.T) {
+	o := NewOAuth2(OAuth2Config{ClientID: "cid"}, &memTokenStore{})
+	if err := o.Apply(httptest.NewRequest("GET", "/", nil)); err != ErrNotLoggedIn {
+		t.Errorf("got %v, want ErrNotLoggedIn", err)
+	}
+}
@@ -0,0 +1,290 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"jet/internal/httpclient"
+	"jet/internal/secrets"
+)
+
+// Atlassian OAuth 2.0 (3LO) defaults.
+const (
+	DefaultAuthURL     = "https://auth.atlassian.com/authorize"
+	DefaultTokenURL    = "https://auth.atlassian.com/oauth/token"
+	DefaultRedirectURL = "http://localhost:8765/callback"
+	DefaultScopes      = "read:jira-work write:jira-work read:jira-user"
+
+	// AccessibleResourcesURL lists the sites (cloud IDs) a token can access.
+	AccessibleResourcesURL = "https://api.atlassian.com/oauth/token/accessible-resources"
+
+	// expirySkew refreshes tokens slightly before they actually expire.
+	expirySkew = time.Minute
+)
+
+// ErrNotLoggedIn is returned when OAuth is configured but no token has been
+// stored yet.
+var ErrNotLoggedIn = errors.New("not logged in: run 'jet auth login'")
+
+// Token is a persisted OAuth 2.0 token pair.
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	Expiry       time.Time `json:"expiry"`
+}
+
+// Valid reports whether the access token can be used without refreshing.
+func (t *Token) Valid() bool {
+	return t != nil && t.AccessToken != "" && time.Now().Add(expirySkew).Before(t.Expiry)
+}
+
+// TokenStore persists OAuth tokens between runs.
+type TokenStore interface {
+	Load() (*Token, error) // returns nil, nil when no token is stored
+	Save(*Token) error
+}
+
+// SecretTokenStore keeps the token as JSON in a secrets.Store.
+type SecretTokenStore struct {
+	Store secrets.Store
+	Key   string
+}
+
+func (s *SecretTokenStore) Load() (*Token, error) {
+	raw, err := s.Store.Get(s.Key)
+	if errors.Is(err, secrets.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var t Token
+	if err := json.Unmarshal([]byte(raw), &t); err != nil {
+		return nil, fmt.Errorf("failed to decode stored OAuth token: %w", err)
+	}
+	return &t, nil
+}
+
+func (s *SecretTokenStore) Save(t *Token) error {
+	raw, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	return s.Store.Set(s.Key, string(raw))
+}
+
+// OAuth2Config describes an OAuth 2.0 (3LO) app.
+type OAuth2Config struct {
+	ClientID     string
+	ClientSecret string
+	AuthURL      string
+	TokenURL     string
+	RedirectURL  string
+	Scopes       string // space-separated
+}
+
+// OAuth2 authenticates with a bearer access token, refreshing it with the
+// stored refresh token when it expires. Atlassian rotates refresh tokens, so
+// every refresh is persisted.
+type OAuth2 struct {
+	Config     OAuth2Config
+	Store      TokenStore
+	HTTPClient *http.Client
+
+	mu    sync.Mutex
+	token *Token
+}
+
+// NewOAuth2 returns an OAuth2 authenticator, filling in Atlassian defaults.
+func NewOAuth2(cfg OAuth2Config, store TokenStore) *OAuth2 {
+	if cfg.AuthURL == "" {
+		cfg.AuthURL = DefaultAuthURL
+	}
+	if cfg.TokenURL == "" {
+		cfg.TokenURL = DefaultTokenURL
+	}
+	if cfg.RedirectURL == "" {
+		cfg.RedirectURL = DefaultRedirectURL
+	}
+	if cfg.Scopes == "" {
+		cfg.Scopes = DefaultScopes
+	}
+	return &OAuth2{Config: cfg, Store: store, HTTPClient: httpclient.New(httpclient.DefaultTimeout)}
+}
+
+func (o *OAuth2) Apply(req *http.Request) error {
+	token, err := o.Token(req.Context())
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	return nil
+}
+
+// Token returns a valid access token, loading it from the store and
+// refreshing it as needed.
+func (o *OAuth2) Token(ctx context.Context) (*Token, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.token == nil {
+		t, err := o.Store.Load()
+		if err != nil {
+			return nil, err
+		}
+		if t == nil {
+			return nil, ErrNotLoggedIn
+		}
+		o.token = t
+	}
+	if o.token.Valid() {
+		return o.token, nil
+	}
+	if o.token.RefreshToken == "" {
+		return nil, fmt.Errorf("OAuth access token expired and no refresh token is stored: run 'jet auth login'")
+	}
+
+	t, err := o.request(ctx, map[string]string{
+		"grant_type":    "refresh_token",
+		"refresh_token": o.token.RefreshToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh OAuth token: %w", err)
+	}
+	if t.RefreshToken == "" {
+		t.RefreshToken = o.token.RefreshToken
+	}
+	if err := o.Store.Save(t); err != nil {
+		return nil, fmt.Errorf("failed to persist refreshed OAuth token: %w", err)
+	}
+	o.token = t
+	return t, nil
+}
+
+// AuthCodeURL returns the URL the user visits to authorize the app.
+func (o *OAuth2) AuthCodeURL(state string) string {
+	scopes := o.Config.Scopes
+	if !strings.Contains(" "+scopes+" ", " offline_access ") {
+		// offline_access is what makes Atlassian issue a refresh token.
+		scopes += " offline_access"
+	}
+	params := url.Values{}
+	params.Set("audience", "api.atlassian.com")
+	params.Set("client_id", o.Config.ClientID)
+	params.Set("scope", scopes)
+	params.Set("redirect_uri", o.Config.RedirectURL)
+	params.Set("state", state)
+	params.Set("response_type", "code")
+	params.Set("prompt", "consent")
+	return o.Config.AuthURL + "?" + params.Encode()
+}
+
+// Exchange trades an authorization code for a token and persists it.
+func (o *OAuth2) Exchange(ctx context.Context, code string) (*Token, error) {
+	t, err := o.request(ctx, map[string]string{
+		"grant_type":   "authorization_code",
+		"code":         code,
+		"redirect_uri": o.Config.RedirectURL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+	if err := o.Store.Save(t); err != nil {
+		return nil, fmt.Errorf("failed to persist OAuth token: %w", err)
+	}
+	o.mu.Lock()
+	o.token = t
+	o.mu.Unlock()
+	return t, nil
+}
+
+func (o *OAuth2) request(ctx context.Context, params map[string]string) (*Token, error) {
+	params["client_id"] = o.Config.ClientID
+	if o.Config.ClientSecret != "" {
+		params["client_secret"] = o.Config.ClientSecret
+	}
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", o.Config.TokenURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := o.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		AccessToken      string `json:"access_token"`
+		RefreshToken     string `json:"refresh_token"`
+		ExpiresIn        int    `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("HTTP %d: failed to decode token response: %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || payload.AccessToken == "" {
+		msg := payload.ErrorDescription
+		if msg == "" {
+			msg = payload.Error
+		}
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, msg)
+	}
+	return &Token{
+		AccessToken:  payload.AccessToken,
+		RefreshToken: payload.RefreshToken,
+		Expiry:       time.Now().Add(time.Duration(payload.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// Resource is a site returned by the accessible-resources endpoint.
+type Resource struct {
+	ID   string `json:"id"`
+	URL  string `json:"url"`
+	Name string `json:"name"`
+}
+
+// APIBaseURL returns the base URL to use as `url` for 3LO API calls.
+func (r Resource) APIBaseURL(product string) string {
+	return "https://api.atlassian.com/ex/" + product + "/" + r.ID
+}
+
+// AccessibleResources lists the sites the current token can access.
+func (o *OAuth2) AccessibleResources(ctx context.Context) ([]Resource, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", AccessibleResourcesURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.Apply(req); err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := o.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d: failed to list accessible resources", resp.StatusCode)
+	}
+	var resources []Resource
+	if err := json.NewDecoder(resp.Body).Decode(&resources); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return resources, nil
+}
@@ -0,0 +1,457 @@
+// Package bulk applies one change, such as a transition, a new assignee or
+// labels, to many issues at once: it plans what would change for each issue,
+// applies the plan a few issues at a time and reports the outcome per issue,
+// failures included.
+package bulk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"jet/internal/jira"
+)
+
+// DefaultConcurrency is how many issues are changed at once by default.
+const DefaultConcurrency = 4
+
+// Outcomes of a change to one issue.
+const (
+	Applied = "applied"
+	Skipped = "skipped"
+	Failed  = "failed"
+)
+
+// Client is the part of the Jira client that bulk changes use.
+type Client interface {
+	SearchIssuesPageContext(ctx context.Context, jql, pageToken string, maxResults int) (*jira.SearchResponse, error)
+	GetTransitionsContext(ctx context.Context, issueKey string) ([]jira.Transition, error)
+	TransitionIssueContext(ctx context.Context, issueKey, transitionID string) error
+	UpdateIssueContext(ctx context.Context, issueKey string, fields map[string]interface{}) error
+	UpdateIssueOperationsContext(ctx context.Context, issueKey string, ops map[string][]jira.FieldOperation) error
+}
+
+// Search returns the issues jql matches, a page at a time. With a positive
+// limit it stops after limit issues and reports whether more matched.
+func Search(ctx context.Context, c Client, jql string, limit int) (issues []jira.Issue, more bool, err error) {
+	const pageSize = 100
+	token := ""
+	for {
+		size := pageSize
+		if limit > 0 {
+			// One past the limit tells whether there are more.
+			size = min(pageSize, limit+1-len(issues))
+		}
+		resp, err := c.SearchIssuesPageContext(ctx, jql, token, size)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to search issues: %w", err)
+		}
+		issues = append(issues, resp.Issues...)
+		if limit > 0 && len(issues) > limit {
+			return issues[:limit], true, nil
+		}
+		if resp.IsLast || len(resp.Issues) == 0 {
+			return issues, false, nil
+		}
+		if resp.NextPageToken == "" {
+			if len(resp.Issues) < size {
+				return issues, false, nil
+			}
+			// A full page without a way to the next one: rather than
+			// change only some of the matches, give up.
+			return nil, false, fmt.Errorf("failed to search issues: Jira stopped paging after %d tickets", len(issues))
+		}
+		token = resp.NextPageToken
+	}
+}
+
+// Change is what a bulk run does to each issue.
+type Change interface {
+	// String names the change, e.g. "shift to Done".
+	String() string
+	// Plan says what the change would do to issue, or why it is skipped
+	// when the issue already is as the change would leave it.
+	Plan(issue *jira.Issue) (what string, skip bool)
+	// Apply changes issue and says what was done.
+	Apply(ctx context.Context, c Client, issue *jira.Issue) (string, error)
+}
+
+// Item is one issue of a plan.
+type Item struct {
+	Issue  jira.Issue
+	Change string // what would change, or why nothing does
+	Skip   bool
+}
+
+// NewPlan lists what change would do to each issue.
+func NewPlan(issues []jira.Issue, change Change) []Item {
+	plan := make([]Item, len(issues))
+	for i := range issues {
+		what, skip := change.Plan(&issues[i])
+		plan[i] = Item{Issue: issues[i], Change: what, Skip: skip}
+	}
+	return plan
+}
+
+// Pending counts the items of a plan that would be changed.
+func Pending(plan []Item) int {
+	n := 0
+	for _, item := range plan {
+		if !item.Skip {
+			n++
+		}
+	}
+	return n
+}
+
+// Result is the outcome of a change to one issue.
+type Result struct {
+	Key     string `json:"key"`
+	Summary string `json:"summary"`
+	Outcome string `json:"outcome"` // Applied, Skipped or Failed
+	Change  string `json:"change"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Report is the outcome of a bulk run.
+type Report struct {
+	Operation string    `json:"operation"`
+	JQL       string    `json:"jql,omitempty"`
+	Started   time.Time `json:"started"`
+	Finished  time.Time `json:"finished"`
+	Applied   int       `json:"applied"`
+	Skipped   int       `json:"skipped"`
+	Failed    int       `json:"failed"`
+	Results   []Result  `json:"results"`
+}
+
+// Options tune Run.
+type Options struct {
+	Concurrency int // issues changed at once; DefaultConcurrency when 0
+
+	// Progress, when set, is called as each issue finishes, one call at a
+	// time.
+	Progress func(Result)
+}
+
+// Run applies change to the issues of plan that are not skipped. One issue
+// failing does not stop the others; the report has a result for every item,
+// in plan order.
+func Run(ctx context.Context, c Client, change Change, plan []Item, opts Options) *Report {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	report := &Report{Operation: change.String(), Started: time.Now(), Results: make([]Result, len(plan))}
+	var mu sync.Mutex
+	done := func(i int, r Result) {
+		mu.Lock()
+		defer mu.Unlock()
+		report.Results[i] = r
+		if opts.Progress != nil {
+			opts.Progress(r)
+		}
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, item := range plan {
+		result := Result{Key: item.Issue.Key, Summary: item.Issue.Fields.Summary, Change: item.Change}
+		if item.Skip {
+			result.Outcome = Skipped
+			done(i, result)
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			err := ctx.Err()
+			if err == nil {
+				result.Change, err = change.Apply(ctx, c, &item.Issue)
+			}
+			if err != nil {
+				result.Change = item.Change
+				result.Outcome = Failed
+				result.Error = err.Error()
+			} else {
+				result.Outcome = Applied
+			}
+			done(i, result)
+		}()
+	}
+	wg.Wait()
+
+	report.Finished = time.Now()
+	for _, r := range report.Results {
+		switch r.Outcome {
+		case Applied:
+			report.Applied++
+		case Skipped:
+			report.Skipped++
+		case Failed:
+			report.Failed++
+		}
+	}
+	return report
+}
+
+// DefaultReportPath returns ~/.jet/bulk/<time>.json for a run started at t.
+func DefaultReportPath(t time.Time) string {
+	return filepath.Join(os.Getenv("HOME"), ".jet", "bulk", t.Format("20060102-150405.000")+".json")
+}
+
+// Save writes the report to path as JSON, creating its directory.
+func (r *Report) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create report directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// Shift moves issues to the status named status, matched like jet shift:
+// case-insensitively, exactly or else by part of the name.
+func Shift(status string) Change {
+	return shift{name: status, statuses: []string{status}}
+}
+
+// Close moves issues to the first of Done, Closed, Resolved or Complete
+// they can move to, like jet close. Issues already done are skipped.
+func Close() Change {
+	return shift{name: "done", statuses: jira.CloseStatuses, close: true}
+}
+
+type shift struct {
+	name     string   // the status shown in plans
+	statuses []string // the statuses looked for, in order
+	close    bool
+}
+
+func (s shift) String() string {
+	if s.close {
+		return "close"
+	}
+	return "shift to " + s.name
+}
+
+func (s shift) Plan(issue *jira.Issue) (string, bool) {
+	current := issue.Fields.Status.Name
+	done := false
+	if s.close {
+		if c := issue.Fields.Status.Category; c != nil && c.Key == "done" {
+			done = true
+		}
+	}
+	for _, status := range s.statuses {
+		if strings.EqualFold(current, status) {
+			done = true
+		}
+	}
+	if done {
+		return "already " + current, true
+	}
+	return current + " → " + s.name, false
+}
+
+func (s shift) Apply(ctx context.Context, c Client, issue *jira.Issue) (string, error) {
+	transitions, err := c.GetTransitionsContext(ctx, issue.Key)
+	if err != nil {
+		return "", fmt.Errorf("failed to get transitions: %w", err)
+	}
+	t := jira.MatchAnyTransition(transitions, s.statuses)
+	if t == nil {
+		var names []string
+		for _, t := range transitions {
+			names = append(names, t.To.Name)
+		}
+		if len(names) == 0 {
+			return "", fmt.Errorf("no transitions available from %s", issue.Fields.Status.Name)
+		}
+		return "", fmt.Errorf("no transition to %q from %s (available: %s)", s.name, issue.Fields.Status.Name, strings.Join(names, ", "))
+	}
+	if err := c.TransitionIssueContext(ctx, issue.Key, t.ID); err != nil {
+		return "", fmt.Errorf("failed to transition issue: %w", err)
+	}
+	return issue.Fields.Status.Name + " → " + t.To.Name, nil
+}
+
+// Assign assigns issues to user, shown as name; a nil user unassigns them.
+func Assign(user *jira.UserRef, name string) Change {
+	if user == nil {
+		name = "unassigned"
+	}
+	return assign{user: user, name: name}
+}
+
+type assign struct {
+	user *jira.UserRef
+	name string
+}
+
+func (a assign) String() string {
+	if a.user == nil {
+		return "unassign"
+	}
+	return "assign to " + a.name
+}
+
+func (a assign) Plan(issue *jira.Issue) (string, bool) {
+	current := issue.Fields.Assignee
+	if sameUser(current, a.user) {
+		return "already " + a.name, true
+	}
+	from := "unassigned"
+	if current != nil {
+		from = current.DisplayName
+		if from == "" {
+			from = current.Name
+		}
+	}
+	return from + " → " + a.name, false
+}
+
+func (a assign) Apply(ctx context.Context, c Client, issue *jira.Issue) (string, error) {
+	var assignee interface{}
+	if a.user != nil {
+		assignee = a.user
+	}
+	if err := c.UpdateIssueContext(ctx, issue.Key, map[string]interface{}{"assignee": assignee}); err != nil {
+		return "", err
+	}
+	what, _ := a.Plan(issue)
+	return what, nil
+}
+
+// sameUser reports whether u is the user ref points to; a nil ref matches
+// nobody.
+func sameUser(u *jira.User, ref *jira.UserRef) bool {
+	if u == nil || ref == nil {
+		return u == nil && ref == nil
+	}
+	if ref.AccountID != "" {
+		return u.AccountID == ref.AccountID
+	}
+	return ref.Name != "" && strings.EqualFold(u.Name, ref.Name)
+}
+
+// AddLabels adds labels to issues, keeping the labels they have.
+func AddLabels(labels ...string) Change {
+	return labelChange{add: labels}
+}
+
+// RemoveLabels removes labels from issues, keeping their other labels.
+func RemoveLabels(labels ...string) Change {
+	return labelChange{remove: labels}
+}
+
+// EditLabels adds and removes labels in one change, keeping the others.
+func EditLabels(add, remove []string) Change {
+	return labelChange{add: add, remove: remove}
+}
+
+type labelChange struct {
+	add, remove []string
+}
+
+func (l labelChange) String() string {
+	var parts []string
+	if len(l.add) > 0 {
+		parts = append(parts, "add labels "+strings.Join(l.add, ", "))
+	}
+	if len(l.remove) > 0 {
+		parts = append(parts, "remove labels "+strings.Join(l.remove, ", "))
+	}
+	return strings.Join(parts, " and ")
+}
+
+// todo returns the labels issue does not have yet and the ones it has, of
+// those to add and remove.
+func (l labelChange) todo(issue *jira.Issue) (add, remove []string) {
+	for _, label := range l.add {
+		if !slices.Contains(issue.Fields.Labels, label) {
+			add = append(add, label)
+		}
+	}
+	for _, label := range l.remove {
+		if slices.Contains(issue.Fields.Labels, label) {
+			remove = append(remove, label)
+		}
+	}
+	return add, remove
+}
+
+func (l labelChange) Plan(issue *jira.Issue) (string, bool) {
+	add, remove := l.todo(issue)
+	if len(add)+len(remove) == 0 {
+		switch {
+		case len(l.remove) == 0:
+			return "already labelled", true
+		case len(l.add) == 0:
+			return "no such labels", true
+		}
+		return "labels unchanged", true
+	}
+	var parts []string
+	for _, label := range add {
+		parts = append(parts, "+"+label)
+	}
+	for _, label := range remove {
+		parts = append(parts, "-"+label)
+	}
+	return strings.Join(parts, " "), false
+}
+
+func (l labelChange) Apply(ctx context.Context, c Client, issue *jira.Issue) (string, error) {
+	add, remove := l.todo(issue)
+	var ops []jira.FieldOperation
+	for _, label := range add {
+		ops = append(ops, jira.FieldOperation{"add": label})
+	}
+	for _, label := range remove {
+		ops = append(ops, jira.FieldOperation{"remove": label})
+	}
+	if err := c.UpdateIssueOperationsContext(ctx, issue.Key, map[string][]jira.FieldOperation{"labels": ops}); err != nil {
+		return "", err
+	}
+	what, _ := l.Plan(issue)
+	return what, nil
+}
+
+// SetFields sets fields, keyed by field ID as from
+// jira.FieldRegistry.ParseFieldValues, on issues; what describes them, e.g.
+// "Story Points = 3".
+func SetFields(fields map[string]interface{}, what string) Change {
+	return setFields{fields: fields, what: what}
+}
+
+type setFields struct {
+	fields map[string]interface{}
+	what   string
+}
+
+func (s setFields) String() string { return "set " + s.what }
+
+func (s setFields) Plan(issue *jira.Issue) (string, bool) { return s.what, false }
+
+func (s setFields) Apply(ctx context.Context, c Client, issue *jira.Issue) (string, error) {
+	if err := c.UpdateIssueContext(ctx, issue.Key, s.fields); err != nil {
+		return "", err
+	}
+	return s.what, nil
+}
@@ -0,0 +1,213 @@
+package bulk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+
+	"jet/internal/jira"
+)
+
+// fakeJira serves a fixed search result and records the changes made.
+type fakeJira struct {
+	issues      []jira.Issue
+	transitions []jira.Transition
+	fail        map[string]bool // keys whose changes fail
+	noTokens    bool            // search pages never say how to go on
+
+	mu       sync.Mutex
+	pages    int
+	changes  map[string]string
+	inFlight int
+	maxIn    int
+}
+
+// SearchIssuesPageContext pages like /rest/api/3/search/jql: by token,
+// without a total.
+func (f *fakeJira) SearchIssuesPageContext(ctx context.Context, jql, pageToken string, maxResults int) (*jira.SearchResponse, error) {
+	f.pages++
+	start := 0
+	if pageToken != "" {
+		start, _ = strconv.Atoi(pageToken)
+	}
+	end := min(start+maxResults, len(f.issues))
+	resp := &jira.SearchResponse{Issues: f.issues[start:end], IsLast: end == len(f.issues)}
+	if f.noTokens {
+		resp.IsLast = false
+	} else if !resp.IsLast {
+		resp.NextPageToken = strconv.Itoa(end)
+	}
+	return resp, nil
+}
+
+func (f *fakeJira) GetTransitionsContext(ctx context.Context, issueKey string) ([]jira.Transition, error) {
+	return f.transitions, nil
+}
+
+func (f *fakeJira) TransitionIssueContext(ctx context.Context, issueKey, transitionID string) error {
+	return f.record(issueKey, "transition "+transitionID)
+}
+
+func (f *fakeJira) UpdateIssueContext(ctx context.Context, issueKey string, fields map[string]interface{}) error {
+	data, _ := json.Marshal(fields)
+	return f.record(issueKey, string(data))
+}
+
+func (f *fakeJira) UpdateIssueOperationsContext(ctx context.Context, issueKey string, ops map[string][]jira.FieldOperation) error {
+	data, _ := json.Marshal(ops)
+	return f.record(issueKey, string(data))
+}
+
+func (f *fakeJira) record(key, change string) error {
+	f.mu.Lock()
+	f.inFlight++
+	f.maxIn = max(f.maxIn, f.inFlight)
+	f.mu.Unlock()
+	defer func() {
+		f.mu.Lock()
+		f.inFlight--
+		f.mu.Unlock()
+	}()
+
+	if f.fail[key] {
+		return fmt.Errorf("%s is locked", key)
+	}
+	f.mu.Lock()
+	f.changes[key] = change
+	f.mu.Unlock()
+	return nil
+}
+
+func newIssue(key, status string, labels ...string) jira.Issue {
+	return jira.Issue{Key: key, Fields: jira.Fields{Summary: "Summary of " + key, Status: jira.Status{Name: status}, Labels: labels}}
+}
+
+func TestSearch(t *testing.T) {
+	f := &fakeJira{}
+	for i := 1; i <= 250; i++ {
+		f.issues = append(f.issues, newIssue(fmt.Sprintf("P-%d", i), "To Do"))
+	}
+
+	issues, more, err := Search(context.Background(), f, "project = P", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 250 || more || f.pages != 3 {
+		t.Errorf("got %d issues, more %v, in %d pages", len(issues), more, f.pages)
+	}
+
+	issues, more, err = Search(context.Background(), f, "project = P", 120)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 120 || !more {
+		t.Errorf("limited: got %d issues, more %v", len(issues), more)
+	}
+	if _, more, _ := Search(context.Background(), f, "project = P", 250); more {
+		t.Error("more reported at exactly the limit")
+	}
+
+	f.noTokens = true
+	if _, _, err := Search(context.Background(), f, "project = P", 0); err == nil {
+		t.Error("no error when a full page has no next page token")
+	}
+}
+
+func TestRunShift(t *testing.T) {
+	f := &fakeJira{
+		transitions: []jira.Transition{
+			{ID: "11", To: jira.Status{Name: "Not Done"}},
+			{ID: "31", To: jira.Status{Name: "Done"}},
+		},
+		fail:    map[string]bool{"P-3": true},
+		changes: map[string]string{},
+	}
+	var issues []jira.Issue
+	for i := 1; i <= 6; i++ {
+		issues = append(issues, newIssue(fmt.Sprintf("P-%d", i), "In Progress"))
+	}
+	issues[1].Fields.Status.Name = "Done"
+
+	plan := NewPlan(issues, Shift("done"))
+	if Pending(plan) != 5 || !plan[1].Skip || plan[0].Change != "In Progress → done" {
+		t.Fatalf("plan = %+v", plan)
+	}
+
+	var progress int
+	report := Run(context.Background(), f, Shift("done"), plan, Options{Concurrency: 2, Progress: func(Result) { progress++ }})
+	if report.Applied != 4 || report.Skipped != 1 || report.Failed != 1 || progress != 6 {
+		t.Errorf("report = %+v, %d progress calls", report, progress)
+	}
+	if f.maxIn > 2 {
+		t.Errorf("%d changes at once, want at most 2", f.maxIn)
+	}
+	if r := report.Results[0]; r.Key != "P-1" || r.Outcome != Applied || r.Change != "In Progress → Done" {
+		t.Errorf("first result = %+v", r)
+	}
+	if r := report.Results[2]; r.Outcome != Failed || r.Error != "failed to transition issue: P-3 is locked" {
+		t.Errorf("failed result = %+v", r)
+	}
+	if f.changes["P-1"] != "transition 31" {
+		t.Errorf("P-1 changed by %q, want the exact match", f.changes["P-1"])
+	}
+	if _, ok := f.changes["P-2"]; ok {
+		t.Error("skipped issue changed")
+	}
+
+	f.transitions = nil
+	report = Run(context.Background(), f, Shift("Done"), plan[:1], Options{})
+	if r := report.Results[0]; r.Outcome != Failed || r.Error != "no transitions available from In Progress" {
+		t.Errorf("without transitions: %+v", r)
+	}
+}
+
+func TestChanges(t *testing.T) {
+	jane := &jira.User{AccountID: "abc", DisplayName: "Jane"}
+	labelled := newIssue("P-1", "To Do", "backend", "urgent")
+	assigned := newIssue("P-2", "To Do")
+	assigned.Fields.Assignee = jane
+	shipped := newIssue("P-4", "Shipped")
+	shipped.Fields.Status.Category = &jira.StatusCategory{Key: "done"}
+
+	for _, tc := range []struct {
+		change Change
+		issue  jira.Issue
+		what   string
+		skip   bool
+		sent   string
+	}{
+		{AddLabels("backend", "infra"), labelled, "+infra", false, `{"labels":[{"add":"infra"}]}`},
+		{AddLabels("urgent"), labelled, "already labelled", true, ""},
+		{RemoveLabels("urgent", "infra"), labelled, "-urgent", false, `{"labels":[{"remove":"urgent"}]}`},
+		{RemoveLabels("infra"), labelled, "no such labels", true, ""},
+		{Assign(&jira.UserRef{AccountID: "abc"}, "Jane"), assigned, "already Jane", true, ""},
+		{Assign(&jira.UserRef{AccountID: "xyz"}, "Joe"), assigned, "Jane → Joe", false, `{"assignee":{"accountId":"xyz"}}`},
+		{Assign(nil, ""), assigned, "Jane → unassigned", false, `{"assignee":null}`},
+		{Assign(nil, ""), labelled, "already unassigned", true, ""},
+		{EditLabels([]string{"infra"}, []string{"urgent", "old"}), labelled, "+infra -urgent", false, `{"labels":[{"add":"infra"},{"remove":"urgent"}]}`},
+		{EditLabels([]string{"backend"}, []string{"old"}), labelled, "labels unchanged", true, ""},
+		{Close(), assigned, "To Do → done", false, "transition 21"},
+		{Close(), newIssue("P-3", "Closed"), "already Closed", true, ""},
+		{Close(), shipped, "already Shipped", true, ""},
+		{SetFields(map[string]interface{}{"customfield_1": 3}, "Story Points = 3"), labelled, "Story Points = 3", false, `{"customfield_1":3}`},
+	} {
+		what, skip := tc.change.Plan(&tc.issue)
+		if what != tc.what || skip != tc.skip {
+			t.Errorf("%s on %s: plan = %q, %v; want %q, %v", tc.change, tc.issue.Key, what, skip, tc.what, tc.skip)
+		}
+		if skip {
+			continue
+		}
+		// Nothing is called Done, so closing falls back to Closed.
+		f := &fakeJira{transitions: []jira.Transition{{ID: "21", To: jira.Status{Name: "Closed"}}}, changes: map[string]string{}}
+		if _, err := tc.change.Apply(context.Background(), f, &tc.issue); err != nil {
+			t.Fatal(err)
+		}
+		if got := f.changes[tc.issue.Key]; got != tc.sent {
+			t.Errorf("%s on %s: sent %s, want %s", tc.change, tc.issue.Key, got, tc.sent)
+		}
+	}
+}
@@ -13,13 +13,76 @@ type Config struct {
 	Email    string
 	Username string
 	Token    string
+	Profile  string // profile the values came from; "" for the legacy [jira]/[confluence] sections
+
+	// TokenCommand is a helper (e.g. "pass show jira") whose stdout is the
+	// token; TokenStore names a secret-store backend ("file", "keyring" or
+	// "backend:key"). Both are only consulted when Token is empty.
+	TokenCommand string
+	TokenStore   string
+
+	// Auth selects the authentication strategy: "basic" (default), "bearer"
+	// for Data Center personal access tokens, or "oauth2" for OAuth 2.0 (3LO).
+	Auth  string
+	OAuth OAuthConfig
+
+	// SecretKey is the default secret-store key for this section, used for
+	// token_store and for persisting OAuth tokens.
+	SecretKey string
+}
+
+// OAuthConfig holds the oauth_* settings of a section or profile.
+type OAuthConfig struct {
+	ClientID     string
+	ClientSecret string
+	AuthURL      string
+	TokenURL     string
+	RedirectURL  string
+	Scopes       string
+}
+
+// AuthMode returns the normalized authentication strategy name.
+func (c *Config) AuthMode() string {
+	switch strings.ToLower(strings.TrimSpace(c.Auth)) {
+	case "bearer", "pat":
+		return "bearer"
+	case "oauth2", "oauth":
+		return "oauth2"
+	default:
+		return "basic"
+	}
 }
 
+// needsToken reports whether the auth mode requires an API token.
+func (c *Config) needsToken() bool { return c.AuthMode() != "oauth2" }
+
+// needsUser reports whether the auth mode requires an email or username.
+func (c *Config) needsUser() bool { return c.AuthMode() == "basic" }
+
+// Load returns the Jira configuration for the active profile, or the legacy
+// [jira] section and JIRA_* environment variables when no profile is active.
 func Load() (*Config, error) {
+	profile, err := ActiveProfile()
+	if err != nil {
+		return nil, err
+	}
+	if profile != "" {
+		return loadProfile(profile, "")
+	}
 	return loadSection("jira", "JIRA")
 }
 
+// LoadConfluence returns the Confluence configuration. Within a profile the
+// confluence_* keys are used, falling back to the profile's Jira values.
 func LoadConfluence() (*Config, error) {
+	profile, err := ActiveProfile()
+	if err != nil {
+		return nil, err
+	}
+	if profile != "" {
+		return loadProfile(profile, "confluence_")
+	}
+
 	// Try loading Confluence section first
 	config, err := loadSection("confluence", "CONFLUENCE")
 	if err == nil {
@@ -45,6 +108,7 @@ func loadSection(section, envPrefix string) (*Config, error) {
 	config.Email = os.Getenv(envPrefix + "_EMAIL")
 	config.Username = os.Getenv(envPrefix + "_USERNAME")
 	config.Token = os.Getenv(envPrefix + "_API_TOKEN")
+	config.Auth = os.Getenv(envPrefix + "_AUTH")
 
 	// Load from config file if env vars are missing
 	configFile := filepath.Join(os.Getenv("HOME"), ".jira_config")
@@ -66,6 +130,19 @@ func loadSection(section, envPrefix string) (*Config, error) {
 		}
 		if config.Token == "" {
 			config.Token = fileConfig.Token
+			config.TokenCommand = fileConfig.TokenCommand
+			config.TokenStore = fileConfig.TokenStore
+		}
+		if config.Auth == "" {
+			config.Auth = fileConfig.Auth
+		}
+		config.OAuth = fileConfig.OAuth
+	}
+
+	config.SecretKey = StoreKey(section)
+	if config.needsToken() {
+		if err := resolveToken(config, config.SecretKey); err != nil {
+			return nil, err
 		}
 	}
 
@@ -73,10 +150,10 @@ func loadSection(section, envPrefix string) (*Config, error) {
 	if config.URL == "" {
 		return nil, fmt.Errorf("%s URL not configured. Set %s_URL environment variable or add 'url' to ~/.jira_config [%s] section", envPrefix, envPrefix, section)
 	}
-	if config.Token == "" {
-		return nil, fmt.Errorf("%s API token not configured. Set %s_API_TOKEN environment variable or add 'token' to ~/.jira_config [%s] section", envPrefix, envPrefix, section)
+	if config.Token == "" && config.needsToken() {
+		return nil, fmt.Errorf("%s API token not configured. Set %s_API_TOKEN environment variable or add 'token', 'token_command' or 'token_store' to ~/.jira_config [%s] section", envPrefix, envPrefix, section)
 	}
-	if config.Email == "" && config.Username == "" {
+	if config.Email == "" && config.Username == "" && config.needsUser() {
 		return nil, fmt.Errorf("%s email or username not configured. Set %s_EMAIL/%s_USERNAME environment variable or add 'email'/'username' to ~/.jira_config [%s] section", envPrefix, envPrefix, envPrefix, section)
 	}
 
@@ -84,6 +161,64 @@ func loadSection(section, envPrefix string) (*Config, error) {
 }
 
 func loadFromFileSection(filename, section string) (*Config, error) {
+	sections, err := readSections(filename)
+	if err != nil {
+		return nil, err
+	}
+	return configFromValues(sections.values(section), ""), nil
+}
+
+// configFromValues builds a Config from a section's key/value pairs, reading
+// keys with the given prefix (e.g. "confluence_").
+func configFromValues(values map[string]string, prefix string) *Config {
+	return &Config{
+		URL:      values[prefix+"url"],
+		Email:    values[prefix+"email"],
+		Username: values[prefix+"username"],
+		Token:    values[prefix+"token"],
+
+		TokenCommand: values[prefix+"token_command"],
+		TokenStore:   values[prefix+"token_store"],
+
+		Auth: values[prefix+"auth"],
+		OAuth: OAuthConfig{
+			ClientID:     values[prefix+"oauth_client_id"],
+			ClientSecret: values[prefix+"oauth_client_secret"],
+			AuthURL:      values[prefix+"oauth_auth_url"],
+			TokenURL:     values[prefix+"oauth_token_url"],
+			RedirectURL:  values[prefix+"oauth_redirect_url"],
+			Scopes:       values[prefix+"oauth_scopes"],
+		},
+	}
+}
+
+// sectionSet holds the parsed sections of the config file, keyed by the
+// lower-cased section name, plus the order in which they and their keys
+// appeared.
+type sectionSet struct {
+	byName map[string]map[string]string
+	order  []string
+	keys   map[string][]string
+}
+
+func (s *sectionSet) values(section string) map[string]string {
+	return s.byName[normalizeSection(section)]
+}
+
+// keyOrder returns the keys of a section in file order.
+func (s *sectionSet) keyOrder(section string) []string {
+	return s.keys[normalizeSection(section)]
+}
+
+// normalizeSection lower-cases a section name and collapses inner whitespace
+// so that "[Profile  Work]" and "[profile work]" are the same section.
+func normalizeSection(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// readSections parses an INI-style config file into its sections. Keys are
+// lower-cased; surrounding quotes are stripped from values.
+func readSections(filename string) (*sectionSet, error) {
 	// Check file permissions
 	fileInfo, err := os.Stat(filename)
 	if err != nil {
@@ -106,9 +241,10 @@ func loadFromFileSection(filename, section string) (*Config, error) {
 	}
 	defer file.Close()
 
-	config := &Config{}
+	sections := &sectionSet{byName: make(map[string]map[string]string), keys: make(map[string][]string)}
 	scanner := bufio.NewScanner(file)
-	inTargetSection := false
+	var current map[string]string
+	var currentName string
 
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
@@ -120,35 +256,32 @@ func loadFromFileSection(filename, section string) (*Config, error) {
 
 		// Check for section headers
 		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
-			sectionName := strings.Trim(line, "[]")
-			inTargetSection = strings.ToLower(sectionName) == strings.ToLower(section)
+			name := normalizeSection(strings.Trim(line, "[]"))
+			currentName = name
+			current = sections.byName[name]
+			if current == nil {
+				current = make(map[string]string)
+				sections.byName[name] = current
+				sections.order = append(sections.order, name)
+			}
 			continue
 		}
 
-		// Parse key=value pairs only in target section
-		if inTargetSection && strings.Contains(line, "=") {
+		// Parse key=value pairs inside a section
+		if current != nil && strings.Contains(line, "=") {
 			parts := strings.SplitN(line, "=", 2)
-			if len(parts) == 2 {
-				key := strings.TrimSpace(parts[0])
-				value := strings.TrimSpace(parts[1])
-
-				// Remove quotes if present
-				if (strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"")) ||
-				   (strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'")) {
-					value = value[1 : len(value)-1]
-				}
-
-				switch strings.ToLower(key) {
-				case "url":
-					config.URL = value
-				case "email":
-					config.Email = value
-				case "username":
-					config.Username = value
-				case "token":
-					config.Token = value
-				}
+			key := strings.ToLower(strings.TrimSpace(parts[0]))
+			value := strings.TrimSpace(parts[1])
+
+			// Remove quotes if present
+			if len(value) >= 2 && ((strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"")) ||
+				(strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'"))) {
+				value = value[1 : len(value)-1]
+			}
+			if _, seen := current[key]; !seen {
+				sections.keys[currentName] = append(sections.keys[currentName], key)
 			}
+			current[key] = value
 		}
 	}
 
@@ -156,5 +289,5 @@ func loadFromFileSection(filename, section string) (*Config, error) {
 		return nil, err
 	}
 
-	return config, nil
-}
\ No newline at end of file
+	return sections, nil
+}
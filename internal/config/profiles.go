@@ -0,0 +1,265 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const profilePrefix = "profile "
+
+// selectedProfile is the profile chosen on the command line (--profile). It
+// takes precedence over JET_PROFILE and the default_profile setting.
+var selectedProfile string
+
+// ConfigPath returns the path to ~/.jira_config.
+func ConfigPath() string {
+	return filepath.Join(os.Getenv("HOME"), ".jira_config")
+}
+
+// CacheDir returns ~/.jet/cache, where per-site data such as the Jira field
+// list is kept.
+func CacheDir() string {
+	return filepath.Join(os.Getenv("HOME"), ".jet", "cache")
+}
+
+// SelectProfile sets the profile chosen on the command line. An empty name
+// leaves profile selection to JET_PROFILE and the default_profile setting.
+func SelectProfile(name string) {
+	selectedProfile = strings.TrimSpace(name)
+}
+
+// ActiveProfile returns the name of the profile to use: the --profile flag,
+// then JET_PROFILE, then default_profile from the [jet] section. It returns ""
+// when none is set, meaning the legacy [jira]/[confluence] sections apply.
+func ActiveProfile() (string, error) {
+	name, _, err := activeProfile()
+	return name, err
+}
+
+// ActiveProfileSource describes where the active profile was chosen
+// ("--profile", "JET_PROFILE", "default_profile" or "").
+func ActiveProfileSource() string {
+	_, source, _ := activeProfile()
+	return source
+}
+
+func activeProfile() (name, source string, err error) {
+	if selectedProfile != "" {
+		return selectedProfile, "--profile", nil
+	}
+	if env := strings.TrimSpace(os.Getenv("JET_PROFILE")); env != "" {
+		return env, "JET_PROFILE", nil
+	}
+	def, err := DefaultProfile()
+	if err != nil || def == "" {
+		return "", "", err
+	}
+	return def, "default_profile", nil
+}
+
+// DefaultProfile returns the default_profile setting from the [jet] section.
+func DefaultProfile() (string, error) {
+	sections, err := readConfigFile()
+	if err != nil || sections == nil {
+		return "", err
+	}
+	return sections.values(settingsSection)["default_profile"], nil
+}
+
+// ListProfiles returns the names of all [profile NAME] sections in file order.
+func ListProfiles() ([]string, error) {
+	sections, err := readConfigFile()
+	if err != nil || sections == nil {
+		return nil, err
+	}
+	var names []string
+	for _, s := range sections.order {
+		if strings.HasPrefix(s, profilePrefix) {
+			names = append(names, strings.TrimPrefix(s, profilePrefix))
+		}
+	}
+	return names, nil
+}
+
+// LoadProfile returns the Jira configuration of a named profile.
+func LoadProfile(name string) (*Config, error) {
+	return loadProfile(name, "")
+}
+
+// SetDefaultProfile writes default_profile to the [jet] section, preserving
+// the rest of the file. The profile must exist.
+func SetDefaultProfile(name string) error {
+	names, err := ListProfiles()
+	if err != nil {
+		return err
+	}
+	if !containsFold(names, name) {
+		return profileNotFound(name, names)
+	}
+	return SetValue(settingsSection, "default_profile", strings.ToLower(name))
+}
+
+// loadProfile reads [profile NAME]. keyPrefix selects service-specific keys
+// (e.g. "confluence_"); missing ones fall back to the unprefixed Jira keys.
+// Environment variables are not consulted so profiles never mix credentials.
+func loadProfile(name, keyPrefix string) (*Config, error) {
+	sections, err := readConfigFile()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config file: %w", err)
+	}
+	var values map[string]string
+	if sections != nil {
+		values = sections.values(profilePrefix + name)
+	}
+	if values == nil {
+		names, _ := ListProfiles()
+		return nil, profileNotFound(name, names)
+	}
+
+	config := configFromValues(values, keyPrefix)
+	config.Profile = strings.ToLower(name)
+	config.SecretKey = StoreKey(profilePrefix + name)
+	if keyPrefix != "" {
+		// Service-specific credentials get their own secret-store key;
+		// otherwise the profile's Jira credentials are shared.
+		if config.Token != "" || config.TokenCommand != "" || config.TokenStore != "" || config.Auth != "" {
+			config.SecretKey += "/" + strings.TrimSuffix(keyPrefix, "_")
+		}
+		fallbackTo(config, configFromValues(values, ""))
+	}
+
+	if config.needsToken() {
+		if err := resolveToken(config, config.SecretKey); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.URL == "" {
+		return nil, fmt.Errorf("profile %q: URL not configured. Add '%surl' to the [profile %s] section of ~/.jira_config", name, keyPrefix, name)
+	}
+	if config.Token == "" && config.needsToken() {
+		return nil, fmt.Errorf("profile %q: API token not configured. Add '%stoken', '%stoken_command' or '%stoken_store' to the [profile %s] section of ~/.jira_config", name, keyPrefix, keyPrefix, keyPrefix, name)
+	}
+	if config.Email == "" && config.Username == "" && config.needsUser() {
+		return nil, fmt.Errorf("profile %q: email or username not configured. Add 'email'/'username' to the [profile %s] section of ~/.jira_config", name, name)
+	}
+	return config, nil
+}
+
+// fallbackTo fills empty fields of config from fallback. The token sources
+// and auth settings are taken as a group so credentials are never mixed.
+func fallbackTo(config, fallback *Config) {
+	if config.URL == "" {
+		config.URL = fallback.URL
+	}
+	if config.Email == "" {
+		config.Email = fallback.Email
+	}
+	if config.Username == "" {
+		config.Username = fallback.Username
+	}
+	if config.Token == "" && config.TokenCommand == "" && config.TokenStore == "" && config.Auth == "" {
+		config.Token = fallback.Token
+		config.TokenCommand = fallback.TokenCommand
+		config.TokenStore = fallback.TokenStore
+		config.Auth = fallback.Auth
+		config.OAuth = fallback.OAuth
+	}
+}
+
+// readConfigFile parses ~/.jira_config. A missing file yields nil, nil.
+func readConfigFile() (*sectionSet, error) {
+	sections, err := readSections(ConfigPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return sections, err
+}
+
+func profileNotFound(name string, names []string) error {
+	if len(names) == 0 {
+		return fmt.Errorf("profile %q not found: no [profile NAME] sections in ~/.jira_config", name)
+	}
+	return fmt.Errorf("profile %q not found in ~/.jira_config (available: %s)", name, strings.Join(names, ", "))
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetValue writes key = value in the given section of ~/.jira_config,
+// preserving the rest of the file. Creates the key or the section if absent.
+func SetValue(section, key, value string) error {
+	return editValue(section, key, value, false)
+}
+
+// UnsetValue removes key from the given section of ~/.jira_config.
+func UnsetValue(section, key string) error {
+	return editValue(section, key, "", true)
+}
+
+func editValue(section, key, value string, remove bool) error {
+	path := ConfigPath()
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	var lines []string
+	if len(data) > 0 {
+		lines = strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	}
+
+	entry := key + " = " + value
+	start, end, keyLine := -1, len(lines), -1
+	inSection := false
+	for i, line := range lines {
+		t := strings.TrimSpace(line)
+		if strings.HasPrefix(t, "[") && strings.HasSuffix(t, "]") {
+			if inSection {
+				end = i
+				break
+			}
+			if normalizeSection(strings.Trim(t, "[]")) == normalizeSection(section) {
+				inSection = true
+				start = i
+			}
+			continue
+		}
+		if inSection && strings.Contains(t, "=") &&
+			strings.EqualFold(strings.TrimSpace(strings.SplitN(t, "=", 2)[0]), key) {
+			keyLine = i
+		}
+	}
+
+	switch {
+	case remove && keyLine >= 0:
+		lines = append(lines[:keyLine], lines[keyLine+1:]...)
+	case remove:
+		return nil
+	case keyLine >= 0:
+		lines[keyLine] = entry
+	case start >= 0:
+		// Insert after the last non-blank line of the section.
+		at := end
+		for at > start+1 && strings.TrimSpace(lines[at-1]) == "" {
+			at--
+		}
+		lines = append(lines[:at], append([]string{entry}, lines[at:]...)...)
+	default:
+		if len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) != "" {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "["+section+"]", entry)
+	}
+
+	out := strings.Join(lines, "\n") + "\n"
+	return os.WriteFile(path, []byte(out), 0600)
+}
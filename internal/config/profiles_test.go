@@ -0,0 +1,127 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const profilesConfig = `[jira]
+url = https://legacy.example.com
+email = legacy@example.com
+token = legacy-token
+
+[profile work]
+url = https://work.atlassian.net
+email = me@work.com
+token = "work-token"
+confluence_url = https://work.atlassian.net/wiki
+
+[Profile  OnPrem]
+url = https://jira.internal
+username = me
+token = onprem-token
+`
+
+func writeConfig(t *testing.T, body string) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	for _, v := range []string{"JET_PROFILE", "JIRA_URL", "JIRA_EMAIL", "JIRA_USERNAME", "JIRA_API_TOKEN"} {
+		t.Setenv(v, "")
+	}
+	SelectProfile("")
+	t.Cleanup(func() { SelectProfile("") })
+	path := filepath.Join(dir, ".jira_config")
+	if err := os.WriteFile(path, []byte(body), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadWithoutProfileUsesJiraSection(t *testing.T) {
+	writeConfig(t, profilesConfig)
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.URL != "https://legacy.example.com" || cfg.Profile != "" {
+		t.Errorf("got %+v", cfg)
+	}
+}
+
+func TestProfileSelectionPrecedence(t *testing.T) {
+	path := writeConfig(t, profilesConfig)
+
+	if err := SetDefaultProfile("onprem"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(read(t, path), "[jet]\ndefault_profile = onprem\n") {
+		t.Errorf("default_profile not written:\n%s", read(t, path))
+	}
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Profile != "onprem" || cfg.Username != "me" {
+		t.Errorf("default profile: got %+v", cfg)
+	}
+
+	t.Setenv("JET_PROFILE", "work")
+	if cfg, _ := Load(); cfg == nil || cfg.Profile != "work" {
+		t.Errorf("JET_PROFILE should override default_profile, got %+v", cfg)
+	}
+
+	SelectProfile("OnPrem")
+	if cfg, _ := Load(); cfg == nil || cfg.Profile != "onprem" {
+		t.Errorf("--profile should override JET_PROFILE, got %+v", cfg)
+	}
+	if src := ActiveProfileSource(); src != "--profile" {
+		t.Errorf("source = %q", src)
+	}
+}
+
+func TestProfileConfluenceFallsBackToJiraKeys(t *testing.T) {
+	writeConfig(t, profilesConfig)
+	SelectProfile("work")
+	cfg, err := LoadConfluence()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.URL != "https://work.atlassian.net/wiki" || cfg.Email != "me@work.com" || cfg.Token != "work-token" {
+		t.Errorf("got %+v", cfg)
+	}
+}
+
+func TestUnknownProfile(t *testing.T) {
+	writeConfig(t, profilesConfig)
+	SelectProfile("nope")
+	_, err := Load()
+	if err == nil || !strings.Contains(err.Error(), "available: work, onprem") {
+		t.Errorf("expected not-found error listing profiles, got %v", err)
+	}
+	if err := SetDefaultProfile("nope"); err == nil {
+		t.Error("SetDefaultProfile should reject unknown profiles")
+	}
+}
+
+func TestListProfiles(t *testing.T) {
+	writeConfig(t, profilesConfig)
+	names, err := ListProfiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(names, ",") != "work,onprem" {
+		t.Errorf("got %v", names)
+	}
+}
+
+func read(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// queriesSection holds saved JQL queries, one "name = JQL" line each.
+const queriesSection = "queries"
+
+// SavedQuery is a named JQL query from the [queries] section.
+type SavedQuery struct {
+	Name string `json:"name"`
+	JQL  string `json:"jql"`
+}
+
+var queryNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ValidateQueryName checks that name can be used as a key of the [queries]
+// section: letters, digits, '-' and '_', starting with a letter or digit.
+func ValidateQueryName(name string) error {
+	if !queryNamePattern.MatchString(strings.ToLower(name)) {
+		return fmt.Errorf("invalid query name %q: use letters, digits, '-' and '_'", name)
+	}
+	return nil
+}
+
+// ListQueries returns the saved queries in file order.
+func ListQueries() ([]SavedQuery, error) {
+	sections, err := readConfigFile()
+	if err != nil || sections == nil {
+		return nil, err
+	}
+	values := sections.values(queriesSection)
+	var queries []SavedQuery
+	for _, name := range sections.keyOrder(queriesSection) {
+		if jql := values[name]; jql != "" {
+			queries = append(queries, SavedQuery{Name: name, JQL: jql})
+		}
+	}
+	return queries, nil
+}
+
+// GetQuery returns the JQL of a saved query. Names are case-insensitive.
+func GetQuery(name string) (string, error) {
+	queries, err := ListQueries()
+	if err != nil {
+		return "", err
+	}
+	var names []string
+	for _, q := range queries {
+		if strings.EqualFold(q.Name, name) {
+			return q.JQL, nil
+		}
+		names = append(names, q.Name)
+	}
+	if len(names) == 0 {
+		return "", fmt.Errorf("query %q not found: save one with 'jet query save NAME JQL'", name)
+	}
+	return "", fmt.Errorf("query %q not found (saved: %s)", name, strings.Join(names, ", "))
+}
+
+// SaveQuery stores jql under name, replacing any query of that name.
+func SaveQuery(name, jql string) error {
+	if err := ValidateQueryName(name); err != nil {
+		return err
+	}
+	jql = strings.TrimSpace(jql)
+	if jql == "" {
+		return fmt.Errorf("query %q has no JQL", name)
+	}
+	if strings.ContainsAny(jql, "\r\n") {
+		return fmt.Errorf("query %q: JQL must be a single line", name)
+	}
+	// The reader strips one pair of surrounding quotes, which would eat the
+	// quotes of JQL such as `"Epic Link" = X AND status = "Done"`.
+	if strings.HasPrefix(jql, `"`) && strings.HasSuffix(jql, `"`) ||
+		strings.HasPrefix(jql, `'`) && strings.HasSuffix(jql, `'`) {
+		jql = "'" + jql + "'"
+	}
+	return SetValue(queriesSection, strings.ToLower(name), jql)
+}
+
+// DeleteQuery removes a saved query.
+func DeleteQuery(name string) error {
+	if _, err := GetQuery(name); err != nil {
+		return err
+	}
+	return UnsetValue(queriesSection, strings.ToLower(name))
+}
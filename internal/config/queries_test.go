@@ -0,0 +1,55 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSavedQueries(t *testing.T) {
+	writeConfig(t, "[queries]\nreview = status = \"In Review\"\nbugs = project = X AND type = Bug\n")
+
+	queries, err := ListQueries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queries) != 2 || queries[0].Name != "review" || queries[1].JQL != "project = X AND type = Bug" {
+		t.Fatalf("queries = %+v", queries)
+	}
+
+	// Quoted at both ends: must survive the reader's quote stripping.
+	quoted := `"Epic Link" = X-1 AND status = "Done"`
+	if err := SaveQuery("Epic", quoted); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := GetQuery("epic"); err != nil || got != quoted {
+		t.Errorf("GetQuery(epic) = %q, %v", got, err)
+	}
+	if err := SaveQuery("bugs", "type = Bug"); err != nil {
+		t.Fatal(err)
+	}
+	queries, _ = ListQueries()
+	if len(queries) != 3 || queries[1].JQL != "type = Bug" {
+		t.Errorf("after overwrite = %+v", queries)
+	}
+
+	if err := DeleteQuery("review"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetQuery("review"); err == nil || !strings.Contains(err.Error(), "saved: bugs, epic") {
+		t.Errorf("GetQuery(review) err = %v", err)
+	}
+}
+
+func TestSaveQueryRejects(t *testing.T) {
+	writeConfig(t, "")
+	for _, tt := range []struct{ name, jql string }{
+		{"has space", "type = Bug"},
+		{"-dash", "type = Bug"},
+		{"empty", "  "},
+		{"multi", "type = Bug\nstatus = Done"},
+	} {
+		if err := SaveQuery(tt.name, tt.jql); err == nil {
+			t.Errorf("SaveQuery(%q, %q) accepted", tt.name, tt.jql)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// settingsSection holds jet-wide settings such as default_profile.
+const settingsSection = "jet"
+
+// ProfileValue returns a key of the active profile's section, or of the
+// legacy [jira] section when no profile is active, without resolving any
+// credentials. It is used for per-site preferences such as the default board.
+func ProfileValue(key string) (string, error) {
+	profile, err := ActiveProfile()
+	if err != nil {
+		return "", err
+	}
+	sections, err := readConfigFile()
+	if err != nil || sections == nil {
+		return "", err
+	}
+	section := "jira"
+	if profile != "" {
+		section = profilePrefix + profile
+	}
+	return sections.values(section)[key], nil
+}
+
+// RetrySettings holds the max_retries and max_retry_wait settings from the
+// [jet] section, overridden by JET_MAX_RETRIES and JET_MAX_RETRY_WAIT.
+type RetrySettings struct {
+	MaxRetries int           // -1 when unset
+	MaxWait    time.Duration // 0 when unset
+}
+
+// LoadRetrySettings reads the HTTP retry settings.
+func LoadRetrySettings() (RetrySettings, error) {
+	rs := RetrySettings{MaxRetries: -1}
+	var values map[string]string
+	sections, err := readConfigFile()
+	if err != nil {
+		return rs, err
+	}
+	if sections != nil {
+		values = sections.values(settingsSection)
+	}
+
+	retries := values["max_retries"]
+	if env := os.Getenv("JET_MAX_RETRIES"); env != "" {
+		retries = env
+	}
+	if retries != "" {
+		n, err := strconv.Atoi(retries)
+		if err != nil || n < 0 {
+			return rs, fmt.Errorf("invalid max_retries %q: must be a non-negative integer", retries)
+		}
+		rs.MaxRetries = n
+	}
+
+	wait := values["max_retry_wait"]
+	if env := os.Getenv("JET_MAX_RETRY_WAIT"); env != "" {
+		wait = env
+	}
+	if wait != "" {
+		d, err := time.ParseDuration(wait)
+		if err != nil || d <= 0 {
+			return rs, fmt.Errorf("invalid max_retry_wait %q: use a duration such as 30s or 2m", wait)
+		}
+		rs.MaxWait = d
+	}
+	return rs, nil
+}
+
+// TimeSettings holds the time tracking settings of the [jet] section:
+// hours_per_day and days_per_week, the working-time units used to read and
+// print Jira durations (they should match the site's time tracking settings;
+// Jira defaults to 8 and 5), and timer_round, timer_round_mode and
+// timer_minimum, which decide how timer time becomes a worklog.
+type TimeSettings struct {
+	HoursPerDay float64
+	DaysPerWeek float64
+
+	TimerRound     time.Duration // rounding increment, default 1m
+	TimerRoundMode string        // "up", "down" or "nearest" (default)
+	TimerMinimum   time.Duration // shorter times are not logged, default 1m
+}
+
+// LoadTimeSettings reads the time tracking settings, defaulting to 8h days,
+// 5-day weeks and timers rounded to the nearest minute.
+func LoadTimeSettings() (TimeSettings, error) {
+	ts := TimeSettings{HoursPerDay: 8, DaysPerWeek: 5, TimerRound: time.Minute, TimerRoundMode: "nearest", TimerMinimum: time.Minute}
+	sections, err := readConfigFile()
+	if err != nil || sections == nil {
+		return ts, err
+	}
+	values := sections.values(settingsSection)
+
+	for _, s := range []struct {
+		key string
+		dst *float64
+		max float64
+	}{
+		{"hours_per_day", &ts.HoursPerDay, 24},
+		{"days_per_week", &ts.DaysPerWeek, 7},
+	} {
+		v := values[s.key]
+		if v == "" {
+			continue
+		}
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil || n <= 0 || n > s.max {
+			return ts, fmt.Errorf("invalid %s %q: must be a number between 0 and %g", s.key, v, s.max)
+		}
+		*s.dst = n
+	}
+
+	for _, s := range []struct {
+		key string
+		dst *time.Duration
+	}{
+		{"timer_round", &ts.TimerRound},
+		{"timer_minimum", &ts.TimerMinimum},
+	} {
+		v := values[s.key]
+		if v == "" {
+			continue
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return ts, fmt.Errorf("invalid %s %q: use a duration such as 15m", s.key, v)
+		}
+		*s.dst = d
+	}
+
+	switch mode := strings.ToLower(values["timer_round_mode"]); mode {
+	case "":
+	case "up", "down", "nearest":
+		ts.TimerRoundMode = mode
+	default:
+		return ts, fmt.Errorf("invalid timer_round_mode %q: use up, down or nearest", values["timer_round_mode"])
+	}
+	return ts, nil
+}
+
+// BranchTemplate returns the branch_template setting of the [jet] section,
+// the template 'jet branch' names branches with, or "" when unset.
+func BranchTemplate() (string, error) {
+	sections, err := readConfigFile()
+	if err != nil || sections == nil {
+		return "", err
+	}
+	return sections.values(settingsSection)["branch_template"], nil
+}
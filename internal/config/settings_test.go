@@ -0,0 +1,83 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProfileValue(t *testing.T) {
+	writeConfig(t, profilesConfig+"board = 42\n")
+	if got, err := ProfileValue("board"); err != nil || got != "" {
+		t.Errorf("legacy ProfileValue(board) = %q, %v", got, err)
+	}
+	SelectProfile("onprem")
+	if got, err := ProfileValue("board"); err != nil || got != "42" {
+		t.Errorf("onprem ProfileValue(board) = %q, %v", got, err)
+	}
+}
+
+func TestLoadRetrySettings(t *testing.T) {
+	writeConfig(t, "[jira]\nurl = https://example.atlassian.net\n")
+	t.Setenv("JET_MAX_RETRIES", "")
+	t.Setenv("JET_MAX_RETRY_WAIT", "")
+	rs, err := LoadRetrySettings()
+	if err != nil || rs.MaxRetries != -1 || rs.MaxWait != 0 {
+		t.Errorf("unset: %+v, %v", rs, err)
+	}
+
+	writeConfig(t, "[jet]\nmax_retries = 2\nmax_retry_wait = 30s\n")
+	t.Setenv("JET_MAX_RETRIES", "5")
+	rs, err = LoadRetrySettings()
+	if err != nil || rs.MaxRetries != 5 || rs.MaxWait != 30*time.Second {
+		t.Errorf("env over file: %+v, %v", rs, err)
+	}
+
+	t.Setenv("JET_MAX_RETRY_WAIT", "soon")
+	if _, err := LoadRetrySettings(); err == nil || !strings.Contains(err.Error(), "max_retry_wait") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestLoadTimeSettings(t *testing.T) {
+	writeConfig(t, "[jet]\nhours_per_day = 7.5\n")
+	ts, err := LoadTimeSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ts.HoursPerDay != 7.5 || ts.DaysPerWeek != 5 {
+		t.Errorf("got %+v", ts)
+	}
+
+	writeConfig(t, "[jet]\ntimer_round = 15m\ntimer_round_mode = Up\n")
+	ts, err = LoadTimeSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ts.TimerRound != 15*time.Minute || ts.TimerRoundMode != "up" || ts.TimerMinimum != time.Minute {
+		t.Errorf("timer settings = %+v", ts)
+	}
+
+	writeConfig(t, "[jet]\ntimer_round_mode = sideways\n")
+	if _, err := LoadTimeSettings(); err == nil {
+		t.Error("bad timer_round_mode accepted")
+	}
+
+	writeConfig(t, "[jet]\ndays_per_week = eight\n")
+	if _, err := LoadTimeSettings(); err == nil || !strings.Contains(err.Error(), "days_per_week") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestBranchTemplate(t *testing.T) {
+	writeConfig(t, "[jet]\nbranch_template = feature/{{lower key}}-{{slug summary}}\n")
+	tmpl, err := BranchTemplate()
+	if err != nil || tmpl != "feature/{{lower key}}-{{slug summary}}" {
+		t.Errorf("BranchTemplate() = %q, %v", tmpl, err)
+	}
+
+	writeConfig(t, "[jira]\nurl = https://example.atlassian.net\n")
+	if tmpl, err := BranchTemplate(); err != nil || tmpl != "" {
+		t.Errorf("unset: BranchTemplate() = %q, %v", tmpl, err)
+	}
+}
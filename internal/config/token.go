@@ -0,0 +1,83 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"jet/internal/secrets"
+)
+
+// resolveToken fills config.Token from token_command or token_store when no
+// literal token is configured. storeKey is the default key for token_store.
+func resolveToken(config *Config, storeKey string) error {
+	if config.Token != "" {
+		return nil
+	}
+	switch {
+	case config.TokenCommand != "":
+		token, err := RunTokenCommand(config.TokenCommand)
+		if err != nil {
+			return err
+		}
+		config.Token = token
+	case config.TokenStore != "":
+		backend, key := ParseTokenStore(config.TokenStore, storeKey)
+		store, err := secrets.Open(backend)
+		if err != nil {
+			return err
+		}
+		token, err := store.Get(key)
+		if err != nil {
+			return fmt.Errorf("failed to read token %q from %s secret store: %w", key, backend, err)
+		}
+		config.Token = token
+	}
+	return nil
+}
+
+// RunTokenCommand runs a token helper such as "pass show jira" through the
+// shell and returns the first line of its stdout, trimmed. Helpers like pass
+// print further lines (notes, usernames) that are not part of the token.
+func RunTokenCommand(command string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg != "" {
+			return "", fmt.Errorf("token_command %q failed: %w: %s", command, err, msg)
+		}
+		return "", fmt.Errorf("token_command %q failed: %w", command, err)
+	}
+	first, _, _ := strings.Cut(stdout.String(), "\n")
+	token := strings.TrimSpace(first)
+	if token == "" {
+		return "", fmt.Errorf("token_command %q printed nothing", command)
+	}
+	return token, nil
+}
+
+// ParseTokenStore splits a token_store value of the form "backend" or
+// "backend:key", using defaultKey when no key is given.
+func ParseTokenStore(value, defaultKey string) (backend, key string) {
+	backend, key, found := strings.Cut(strings.TrimSpace(value), ":")
+	if !found || key == "" {
+		key = defaultKey
+	}
+	return backend, key
+}
+
+// StoreKey returns the default secret-store key for a config section, e.g.
+// "jira" or "profile work".
+func StoreKey(section string) string {
+	return normalizeSection(section)
+}
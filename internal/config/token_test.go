@@ -0,0 +1,95 @@
+package config
+
+import (
+	"testing"
+
+	"jet/internal/secrets"
+)
+
+func TestTokenCommand(t *testing.T) {
+	writeConfig(t, "[jira]\nurl = https://x\nemail = me@x\ntoken_command = echo '  from-helper  '\n")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Token != "from-helper" {
+		t.Errorf("Token = %q", cfg.Token)
+	}
+}
+
+func TestTokenCommandFirstLine(t *testing.T) {
+	writeConfig(t, "[jira]\nurl = https://x\nemail = me@x\ntoken_command = printf 'secret\\nlogin: me@x\\n'\n")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Token != "secret" {
+		t.Errorf("Token = %q", cfg.Token)
+	}
+}
+
+func TestTokenCommandFailure(t *testing.T) {
+	writeConfig(t, "[jira]\nurl = https://x\nemail = me@x\ntoken_command = exit 3\n")
+	if _, err := Load(); err == nil {
+		t.Error("expected failing token_command to be reported")
+	}
+}
+
+func TestLiteralTokenWinsOverHelper(t *testing.T) {
+	writeConfig(t, "[jira]\nurl = https://x\nemail = me@x\ntoken = literal\ntoken_command = exit 1\n")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Token != "literal" {
+		t.Errorf("Token = %q", cfg.Token)
+	}
+}
+
+type memStore map[string]string
+
+func (m memStore) Name() string { return "mem" }
+func (m memStore) Get(key string) (string, error) {
+	if v, ok := m[key]; ok {
+		return v, nil
+	}
+	return "", secrets.ErrNotFound
+}
+func (m memStore) Set(key, value string) error { m[key] = value; return nil }
+func (m memStore) Delete(key string) error     { delete(m, key); return nil }
+
+func TestTokenStoreKeys(t *testing.T) {
+	store := memStore{"jira": "legacy", "profile work": "work", "profile work/confluence": "wiki", "custom": "c"}
+	secrets.Register("mem", func() (secrets.Store, error) { return store, nil })
+
+	writeConfig(t, `[jira]
+url = https://x
+email = me@x
+token_store = mem
+
+[profile work]
+url = https://w
+email = me@w
+token_store = mem
+confluence_token_store = mem
+
+[profile other]
+url = https://o
+email = me@o
+token_store = mem:custom
+`)
+	if cfg, err := Load(); err != nil || cfg.Token != "legacy" {
+		t.Errorf("legacy: %+v, %v", cfg, err)
+	}
+	SelectProfile("work")
+	if cfg, err := Load(); err != nil || cfg.Token != "work" {
+		t.Errorf("profile: %+v, %v", cfg, err)
+	}
+	if cfg, err := LoadConfluence(); err != nil || cfg.Token != "wiki" {
+		t.Errorf("profile confluence: %+v, %v", cfg, err)
+	}
+	SelectProfile("other")
+	if cfg, err := Load(); err != nil || cfg.Token != "c" {
+		t.Errorf("explicit key: %+v, %v", cfg, err)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"strings"
 
+	"jet/internal/auth"
 	"jet/internal/httpclient"
 )
 
@@ -19,6 +20,10 @@ type Client struct {
 	Username   string
 	Token      string
 	HTTPClient *http.Client
+
+	// Auth applies credentials to each request. When nil, HTTP Basic with
+	// Email (or Username) and Token is used.
+	Auth auth.Authenticator
 }
 
 type Page struct {
@@ -109,6 +114,25 @@ func NewClient(baseURL, email, username, token string) *Client {
 	}
 }
 
+// NewClientWithAuth returns a client that authenticates with a, e.g. a
+// Bearer personal access token or OAuth 2.0.
+func NewClientWithAuth(baseURL string, a auth.Authenticator) *Client {
+	return &Client{
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		HTTPClient: httpclient.New(httpclient.DefaultTimeout),
+		Auth:       a,
+	}
+}
+
+// Authorize adds the client's credentials to req. Use it for requests made
+// outside the client, such as downloading attachment content.
+func (c *Client) Authorize(req *http.Request) error {
+	if c.Auth != nil {
+		return c.Auth.Apply(req)
+	}
+	return auth.NewBasic(c.Email, c.Username, c.Token).Apply(req)
+}
+
 func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body interface{}) (*http.Response, error) {
 	var reqBody io.Reader
 	if body != nil {
@@ -125,13 +149,9 @@ func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Set authentication (same as JIRA)
-	if c.Email != "" && c.Token != "" {
-		req.SetBasicAuth(c.Email, c.Token)
-	} else if c.Username != "" && c.Token != "" {
-		req.SetBasicAuth(c.Username, c.Token)
-	} else {
-		return nil, fmt.Errorf("authentication credentials not provided")
+	// Set authentication
+	if err := c.Authorize(req); err != nil {
+		return nil, err
 	}
 
 	req.Header.Set("Accept", "application/json")
@@ -160,6 +180,11 @@ func checkResponse(resp *http.Response, successCode int, resource string) error
 		return fmt.Errorf("access denied to %s", resource)
 	case 404:
 		return fmt.Errorf("%s not found", resource)
+	case 429:
+		if rl := httpclient.ParseRateLimit(resp.Header).String(); rl != "" {
+			return fmt.Errorf("rate limited while requesting %s (%s)", resource, rl)
+		}
+		return fmt.Errorf("rate limited while requesting %s", resource)
 	default:
 		return fmt.Errorf("HTTP %d: request failed for %s", resp.StatusCode, resource)
 	}
@@ -167,12 +192,17 @@ func checkResponse(resp *http.Response, successCode int, resource string) error
 
 // GetPage retrieves a Confluence page by ID
 func (c *Client) GetPage(pageID string) (*Page, error) {
+	return c.GetPageContext(context.Background(), pageID)
+}
+
+// GetPageContext is like GetPage but carries ctx for cancellation.
+func (c *Client) GetPageContext(ctx context.Context, pageID string) (*Page, error) {
 	params := url.Values{}
 	params.Add("body-format", "storage")
 
 	endpoint := fmt.Sprintf("/wiki/api/v2/pages/%s?%s", pageID, params.Encode())
 
-	resp, err := c.makeRequest(context.Background(), "GET", endpoint, nil)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -192,13 +222,18 @@ func (c *Client) GetPage(pageID string) (*Page, error) {
 
 // SearchPages searches for Confluence pages using CQL
 func (c *Client) SearchPages(cql string, limit int) (*SearchResponse, error) {
+	return c.SearchPagesContext(context.Background(), cql, limit)
+}
+
+// SearchPagesContext is like SearchPages but carries ctx for cancellation.
+func (c *Client) SearchPagesContext(ctx context.Context, cql string, limit int) (*SearchResponse, error) {
 	params := url.Values{}
 	params.Add("cql", cql)
 	params.Add("limit", fmt.Sprintf("%d", limit))
 
 	endpoint := fmt.Sprintf("/wiki/rest/api/search?%s", params.Encode())
 
-	resp, err := c.makeRequest(context.Background(), "GET", endpoint, nil)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -218,21 +253,36 @@ func (c *Client) SearchPages(cql string, limit int) (*SearchResponse, error) {
 
 // SearchByText is a convenience method for simple text searches
 func (c *Client) SearchByText(searchText string, limit int) (*SearchResponse, error) {
+	return c.SearchByTextContext(context.Background(), searchText, limit)
+}
+
+// SearchByTextContext is like SearchByText but carries ctx for cancellation.
+func (c *Client) SearchByTextContext(ctx context.Context, searchText string, limit int) (*SearchResponse, error) {
 	cql := fmt.Sprintf("type=page AND text~\"%s\"", EscapeString(searchText))
-	return c.SearchPages(cql, limit)
+	return c.SearchPagesContext(ctx, cql, limit)
 }
 
 // SearchBySpace searches for pages within a specific space
 func (c *Client) SearchBySpace(spaceKey string, limit int) (*SearchResponse, error) {
+	return c.SearchBySpaceContext(context.Background(), spaceKey, limit)
+}
+
+// SearchBySpaceContext is like SearchBySpace but carries ctx for cancellation.
+func (c *Client) SearchBySpaceContext(ctx context.Context, spaceKey string, limit int) (*SearchResponse, error) {
 	cql := fmt.Sprintf("type=page AND space=\"%s\"", EscapeString(spaceKey))
-	return c.SearchPages(cql, limit)
+	return c.SearchPagesContext(ctx, cql, limit)
 }
 
 // GetSpace retrieves space information by space key
 func (c *Client) GetSpace(spaceKey string) (*Space, error) {
+	return c.GetSpaceContext(context.Background(), spaceKey)
+}
+
+// GetSpaceContext is like GetSpace but carries ctx for cancellation.
+func (c *Client) GetSpaceContext(ctx context.Context, spaceKey string) (*Space, error) {
 	endpoint := fmt.Sprintf("/wiki/api/v2/spaces?keys=%s", spaceKey)
 
-	resp, err := c.makeRequest(context.Background(), "GET", endpoint, nil)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -288,6 +338,11 @@ type UpdateVersion struct {
 
 // CreatePage creates a new Confluence page
 func (c *Client) CreatePage(spaceID, title, content string, parentID string) (*Page, error) {
+	return c.CreatePageContext(context.Background(), spaceID, title, content, parentID)
+}
+
+// CreatePageContext is like CreatePage but carries ctx for cancellation.
+func (c *Client) CreatePageContext(ctx context.Context, spaceID, title, content string, parentID string) (*Page, error) {
 	// Build the create request
 	createReq := CreatePageRequest{
 		SpaceID: spaceID,
@@ -305,7 +360,7 @@ func (c *Client) CreatePage(spaceID, title, content string, parentID string) (*P
 
 	endpoint := "/wiki/api/v2/pages"
 
-	resp, err := c.makeRequest(context.Background(), "POST", endpoint, createReq)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, createReq)
 	if err != nil {
 		return nil, err
 	}
@@ -334,6 +389,11 @@ func (c *Client) CreatePage(spaceID, title, content string, parentID string) (*P
 
 // UpdatePage updates an existing Confluence page
 func (c *Client) UpdatePage(pageID, title, content, spaceID string, version int, parentID, versionMessage string) (*Page, error) {
+	return c.UpdatePageContext(context.Background(), pageID, title, content, spaceID, version, parentID, versionMessage)
+}
+
+// UpdatePageContext is like UpdatePage but carries ctx for cancellation.
+func (c *Client) UpdatePageContext(ctx context.Context, pageID, title, content, spaceID string, version int, parentID, versionMessage string) (*Page, error) {
 	// Build the update request
 	updateReq := UpdatePageRequest{
 		ID:      pageID,
@@ -356,7 +416,7 @@ func (c *Client) UpdatePage(pageID, title, content, spaceID string, version int,
 
 	endpoint := fmt.Sprintf("/wiki/api/v2/pages/%s", pageID)
 
-	resp, err := c.makeRequest(context.Background(), "PUT", endpoint, updateReq)
+	resp, err := c.makeRequest(ctx, "PUT", endpoint, updateReq)
 	if err != nil {
 		return nil, err
 	}
@@ -405,12 +465,17 @@ type ChildPage struct {
 
 // GetChildPages retrieves direct children of a page
 func (c *Client) GetChildPages(pageID string, limit int) (*ChildPagesResponse, error) {
+	return c.GetChildPagesContext(context.Background(), pageID, limit)
+}
+
+// GetChildPagesContext is like GetChildPages but carries ctx for cancellation.
+func (c *Client) GetChildPagesContext(ctx context.Context, pageID string, limit int) (*ChildPagesResponse, error) {
 	params := url.Values{}
 	params.Add("limit", fmt.Sprintf("%d", limit))
 
 	endpoint := fmt.Sprintf("/wiki/api/v2/pages/%s/children?%s", pageID, params.Encode())
 
-	resp, err := c.makeRequest(context.Background(), "GET", endpoint, nil)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
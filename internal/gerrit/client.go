@@ -4,6 +4,7 @@ package gerrit
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -137,10 +138,15 @@ func NewClient(cfg *gerry.Config) *Client {
 
 // ListChanges runs a Gerrit query and returns matching changes.
 func (c *Client) ListChanges(query string, limit int) ([]Change, error) {
+	return c.ListChangesContext(context.Background(), query, limit)
+}
+
+// ListChangesContext is like ListChanges but carries ctx for cancellation.
+func (c *Client) ListChangesContext(ctx context.Context, query string, limit int) ([]Change, error) {
 	// query is passed raw; Gerrit accepts spaces/operators here. url.QueryEscape
 	// over-encodes some operators, so build the query string manually.
 	path := fmt.Sprintf("changes/?q=%s&n=%d&o=DETAILED_LABELS&o=DETAILED_ACCOUNTS", urlQuery(query), limit)
-	body, err := c.get(path)
+	body, err := c.get(ctx, path)
 	if err != nil {
 		return nil, err
 	}
@@ -151,8 +157,8 @@ func (c *Client) ListChanges(query string, limit int) ([]Change, error) {
 	return changes, nil
 }
 
-func (c *Client) get(path string) ([]byte, error) {
-	req, err := http.NewRequest("GET", c.cfg.RESTURL(path), nil)
+func (c *Client) get(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.cfg.RESTURL(path), nil)
 	if err != nil {
 		return nil, err
 	}
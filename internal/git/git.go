@@ -0,0 +1,185 @@
+// Package git runs the git command line for the repository jet is used in:
+// reading the current branch and recent commits, and creating branches named
+// after tickets.
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// ErrNotRepository is returned when the directory is not inside a git work
+// tree.
+var ErrNotRepository = errors.New("not a git repository")
+
+// Repo is the git repository containing Dir, the current directory when
+// empty.
+type Repo struct {
+	Dir string
+}
+
+func (r Repo) run(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = r.Dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if strings.Contains(msg, "not a git repository") {
+			return "", ErrNotRepository
+		}
+		if msg == "" {
+			return "", fmt.Errorf("git %s: %w", args[0], err)
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], msg)
+	}
+	return strings.TrimRight(stdout.String(), "\n"), nil
+}
+
+// CurrentBranch returns the checked-out branch, or "" when HEAD is detached.
+func (r Repo) CurrentBranch(ctx context.Context) (string, error) {
+	if _, err := r.run(ctx, "rev-parse", "--git-dir"); err != nil {
+		return "", err
+	}
+	branch, err := r.run(ctx, "symbolic-ref", "--quiet", "--short", "HEAD")
+	if err != nil {
+		return "", nil
+	}
+	return branch, nil
+}
+
+// RecentSubjects returns the subject lines of the last n commits on HEAD,
+// newest first. A repository without commits has none.
+func (r Repo) RecentSubjects(ctx context.Context, n int) ([]string, error) {
+	if _, err := r.run(ctx, "rev-parse", "--verify", "--quiet", "HEAD"); err != nil {
+		if errors.Is(err, ErrNotRepository) {
+			return nil, err
+		}
+		return nil, nil
+	}
+	out, err := r.run(ctx, "log", fmt.Sprintf("-n%d", n), "--format=%s")
+	if err != nil || out == "" {
+		return nil, err
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// Branches returns the names of the local branches.
+func (r Repo) Branches(ctx context.Context) ([]string, error) {
+	out, err := r.run(ctx, "for-each-ref", "--format=%(refname:short)", "refs/heads")
+	if err != nil || out == "" {
+		return nil, err
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// Switch checks out an existing branch.
+func (r Repo) Switch(ctx context.Context, branch string) error {
+	_, err := r.run(ctx, "switch", branch)
+	return err
+}
+
+// CreateBranch creates branch at base (HEAD when empty) and checks it out.
+func (r Repo) CreateBranch(ctx context.Context, branch, base string) error {
+	args := []string{"switch", "-c", branch}
+	if base != "" {
+		args = append(args, base)
+	}
+	_, err := r.run(ctx, args...)
+	return err
+}
+
+// ValidBranchName reports whether git accepts name as a branch name.
+func (r Repo) ValidBranchName(ctx context.Context, name string) bool {
+	_, err := r.run(ctx, "check-ref-format", "--branch", name)
+	return err == nil
+}
+
+// DefaultBranchTemplate names branches after the ticket key and summary,
+// e.g. PROJ-123-fix-login-timeout.
+const DefaultBranchTemplate = "{{key}}-{{slug summary}}"
+
+// BranchInfo is what a branch template can use: key, summary, type and
+// project, as template functions.
+type BranchInfo struct {
+	Key     string
+	Summary string
+	Type    string
+	Project string
+}
+
+// BranchName renders tmpl for a ticket. Besides the ticket's values the
+// template has slug (lower-case words joined by dashes, at most 50
+// characters), lower and upper, e.g. "feature/{{lower key}}-{{slug summary}}".
+func BranchName(tmpl string, info BranchInfo) (string, error) {
+	value := func(s string) func() string { return func() string { return s } }
+	t, err := template.New("branch").Funcs(template.FuncMap{
+		"key":     value(info.Key),
+		"summary": value(info.Summary),
+		"type":    value(info.Type),
+		"project": value(info.Project),
+		"slug":    func(s string) string { return Slug(s, 50) },
+		"lower":   strings.ToLower,
+		"upper":   strings.ToUpper,
+	}).Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid branch template: %w", err)
+	}
+	var sb strings.Builder
+	if err := t.Execute(&sb, nil); err != nil {
+		return "", fmt.Errorf("invalid branch template: %w", err)
+	}
+	name := strings.Trim(sb.String(), "-/")
+	if name == "" {
+		return "", fmt.Errorf("branch template %q gives an empty name", tmpl)
+	}
+	return name, nil
+}
+
+var slugSeparators = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Slug turns s into lower-case ASCII words joined by dashes, cut at a word
+// boundary to at most limit characters.
+func Slug(s string, limit int) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(s) {
+		// Drop accents rather than whole letters: é becomes e.
+		if r > unicode.MaxASCII {
+			r = foldAccent(r)
+		}
+		sb.WriteRune(r)
+	}
+	slug := strings.Trim(slugSeparators.ReplaceAllString(sb.String(), "-"), "-")
+	if len(slug) > limit {
+		slug = slug[:limit+1]
+		if cut := strings.LastIndex(slug, "-"); cut > 0 {
+			slug = slug[:cut]
+		} else {
+			slug = slug[:limit]
+		}
+	}
+	return slug
+}
+
+var accents = map[rune]rune{
+	'à': 'a', 'á': 'a', 'â': 'a', 'ã': 'a', 'ä': 'a', 'å': 'a',
+	'ç': 'c', 'è': 'e', 'é': 'e', 'ê': 'e', 'ë': 'e',
+	'ì': 'i', 'í': 'i', 'î': 'i', 'ï': 'i', 'ñ': 'n',
+	'ò': 'o', 'ó': 'o', 'ô': 'o', 'õ': 'o', 'ö': 'o', 'ø': 'o',
+	'ù': 'u', 'ú': 'u', 'û': 'u', 'ü': 'u', 'ý': 'y', 'ÿ': 'y',
+}
+
+func foldAccent(r rune) rune {
+	if a, ok := accents[r]; ok {
+		return a
+	}
+	return r
+}
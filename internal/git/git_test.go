@@ -0,0 +1,124 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+func TestSlug(t *testing.T) {
+	for _, tc := range []struct {
+		in    string
+		limit int
+		want  string
+	}{
+		{"Fix login timeout", 50, "fix-login-timeout"},
+		{"  [API] Don't crash on /users?id=Ü ", 50, "api-don-t-crash-on-users-id-u"},
+		{"Café crème", 50, "cafe-creme"},
+		{"Support exporting issues to spreadsheets", 20, "support-exporting"},
+		{"Supercalifragilistic", 10, "supercalif"},
+		{"日本語", 50, ""},
+	} {
+		if got := Slug(tc.in, tc.limit); got != tc.want {
+			t.Errorf("Slug(%q, %d) = %q, want %q", tc.in, tc.limit, got, tc.want)
+		}
+	}
+}
+
+func TestBranchName(t *testing.T) {
+	info := BranchInfo{Key: "PROJ-12", Summary: "Fix login timeout", Type: "Bug", Project: "PROJ"}
+	for tmpl, want := range map[string]string{
+		DefaultBranchTemplate:                           "PROJ-12-fix-login-timeout",
+		"{{lower type}}/{{lower key}}-{{slug summary}}": "bug/proj-12-fix-login-timeout",
+		"{{key}}-{{slug \"\"}}":                         "PROJ-12",
+	} {
+		got, err := BranchName(tmpl, info)
+		if err != nil || got != want {
+			t.Errorf("BranchName(%q) = %q, %v; want %q", tmpl, got, err, want)
+		}
+	}
+	for _, tmpl := range []string{"{{key", "{{nope}}", "{{slug summary}}"} {
+		if _, err := BranchName(tmpl, BranchInfo{Key: "PROJ-12"}); err == nil {
+			t.Errorf("BranchName(%q): expected an error", tmpl)
+		}
+	}
+}
+
+// newRepo creates a repository with one commit per subject.
+func newRepo(t *testing.T, subjects ...string) Repo {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	r := Repo{Dir: t.TempDir()}
+	ctx := context.Background()
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"config", "user.email", "dev@example.com"},
+		{"config", "user.name", "Dev"},
+	} {
+		if _, err := r.run(ctx, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, s := range subjects {
+		if _, err := r.run(ctx, "commit", "-q", "--allow-empty", "-m", s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return r
+}
+
+func TestRepo(t *testing.T) {
+	ctx := context.Background()
+	r := newRepo(t)
+	if subjects, err := r.RecentSubjects(ctx, 5); err != nil || subjects != nil {
+		t.Errorf("RecentSubjects without commits = %v, %v", subjects, err)
+	}
+
+	r = newRepo(t, "PROJ-1: first", "second\n\nbody", "PROJ-2 third")
+	subjects, err := r.RecentSubjects(ctx, 2)
+	if want := []string{"PROJ-2 third", "second"}; err != nil || !reflect.DeepEqual(subjects, want) {
+		t.Errorf("RecentSubjects = %v, %v; want %v", subjects, err, want)
+	}
+
+	if err := r.CreateBranch(ctx, "PROJ-3-fix", ""); err != nil {
+		t.Fatal(err)
+	}
+	if branch, err := r.CurrentBranch(ctx); err != nil || branch != "PROJ-3-fix" {
+		t.Errorf("CurrentBranch = %q, %v", branch, err)
+	}
+	if err := r.Switch(ctx, "main"); err != nil {
+		t.Fatal(err)
+	}
+	branches, err := r.Branches(ctx)
+	if want := []string{"PROJ-3-fix", "main"}; err != nil || !reflect.DeepEqual(branches, want) {
+		t.Errorf("Branches = %v, %v; want %v", branches, err, want)
+	}
+	if err := r.CreateBranch(ctx, "PROJ-3-fix", ""); err == nil {
+		t.Error("creating an existing branch should fail")
+	}
+	if !r.ValidBranchName(ctx, "PROJ-4-ok") || r.ValidBranchName(ctx, "bad..name") {
+		t.Error("ValidBranchName gave the wrong answer")
+	}
+
+	if _, err := r.run(ctx, "checkout", "-q", "--detach"); err != nil {
+		t.Fatal(err)
+	}
+	if branch, err := r.CurrentBranch(ctx); err != nil || branch != "" {
+		t.Errorf("CurrentBranch when detached = %q, %v", branch, err)
+	}
+}
+
+func TestNotRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_CEILING_DIRECTORIES", t.TempDir())
+	r := Repo{Dir: t.TempDir()}
+	if _, err := r.CurrentBranch(context.Background()); !errors.Is(err, ErrNotRepository) {
+		t.Errorf("CurrentBranch outside a repository: %v", err)
+	}
+}
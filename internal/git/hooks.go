@@ -0,0 +1,154 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// hookMarker identifies hook scripts written by InstallHook.
+const hookMarker = "# Installed by jet"
+
+// ErrForeignHook is returned when a hook that jet did not write is in the
+// way.
+var ErrForeignHook = errors.New("hook not installed by jet")
+
+// HooksDir returns the directory git runs hooks from, honouring
+// core.hooksPath and worktrees.
+func (r Repo) HooksDir(ctx context.Context) (string, error) {
+	dir, err := r.run(ctx, "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(r.Dir, dir)
+	}
+	return filepath.Abs(dir)
+}
+
+// CommentChar returns core.commentChar, "#" unless configured.
+func (r Repo) CommentChar(ctx context.Context) string {
+	c, err := r.run(ctx, "config", "core.commentChar")
+	if err != nil || c == "" || c == "auto" {
+		return "#"
+	}
+	return c
+}
+
+// HookScript is a hook running jet with args and the hook's arguments. It
+// falls back to jet on the PATH when the jet binary has moved.
+func HookScript(jet, args string) string {
+	return "#!/bin/sh\n" + hookMarker + "; reinstall with 'jet hooks install'.\n" +
+		"JET=" + shellQuote(jet) + "\n" +
+		`[ -x "$JET" ] || JET=jet` + "\n" +
+		`exec "$JET" ` + args + ` "$@"` + "\n"
+}
+
+// shellQuote quotes s for sh.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// InstallHook writes script as the hook name in dir. A hook jet did not
+// write is only replaced with force, after being renamed to name.orig.
+func InstallHook(dir, name, script string, force bool) error {
+	path := filepath.Join(dir, name)
+	if old, err := os.ReadFile(path); err == nil && !strings.Contains(string(old), hookMarker) {
+		if !force {
+			return fmt.Errorf("%s: %w", path, ErrForeignHook)
+		}
+		if err := os.Rename(path, path+".orig"); err != nil {
+			return fmt.Errorf("failed to keep the old hook: %w", err)
+		}
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		return fmt.Errorf("failed to write hook: %w", err)
+	}
+	// WriteFile keeps the mode of an existing file.
+	return os.Chmod(path, 0755)
+}
+
+// UninstallHook removes the hook name from dir if jet wrote it, and
+// reports whether it did.
+func UninstallHook(dir, name string) (bool, error) {
+	path := filepath.Join(dir, name)
+	old, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !strings.Contains(string(old), hookMarker) {
+		return false, fmt.Errorf("%s: %w", path, ErrForeignHook)
+	}
+	if err := os.Remove(path); err != nil {
+		return false, err
+	}
+	// Put back a hook that --force moved aside.
+	if _, err := os.Stat(path + ".orig"); err == nil {
+		if err := os.Rename(path+".orig", path); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+// scissors is the line below which git ignores a commit message (with
+// commit.verbose or --cleanup=scissors), after the comment character.
+const scissors = " ------------------------ >8 ------------------------"
+
+// CleanMessage returns a commit message as git will store it: without
+// comment lines, the part below the scissors line, and surrounding blank
+// lines.
+func CleanMessage(msg, commentChar string) string {
+	var lines []string
+	for _, line := range strings.Split(msg, "\n") {
+		if line == commentChar+scissors {
+			break
+		}
+		if strings.HasPrefix(line, commentChar) {
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, " \t\r"))
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// PrefixMessage puts prefix at the start of the message's subject, its
+// first non-blank line that is not a comment. A message that is only the
+// comment template git opens the editor with gets prefix as its first line.
+func PrefixMessage(msg, prefix, commentChar string) string {
+	lines := strings.Split(msg, "\n")
+	blank := -1
+	for i, line := range lines {
+		if i == len(lines)-1 && line == "" {
+			break // after the final newline
+		}
+		if line == commentChar+scissors {
+			break
+		}
+		if strings.HasPrefix(line, commentChar) {
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			if blank < 0 {
+				blank = i
+			}
+			continue
+		}
+		lines[i] = prefix + strings.TrimLeft(line, " \t")
+		return strings.Join(lines, "\n")
+	}
+	if blank >= 0 {
+		lines[blank] = prefix
+		return strings.Join(lines, "\n")
+	}
+	return prefix + "\n" + msg
+}
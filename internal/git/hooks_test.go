@@ -0,0 +1,110 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const editorTemplate = "\n# Please enter the commit message for your changes.\n#\n"
+
+func TestPrefixMessage(t *testing.T) {
+	for _, tc := range []struct {
+		in, want string
+	}{
+		{"Fix login\n", "PROJ-1: Fix login\n"},
+		{"\n\n  Fix login\n\nBody\n", "\n\nPROJ-1: Fix login\n\nBody\n"},
+		{editorTemplate, "PROJ-1: " + editorTemplate},
+		{"# only comments\n", "PROJ-1: \n# only comments\n"},
+		{"\n# ------------------------ >8 ------------------------\ndiff --git a/x b/x\n", "PROJ-1: \n# ------------------------ >8 ------------------------\ndiff --git a/x b/x\n"},
+	} {
+		if got := PrefixMessage(tc.in, "PROJ-1: ", "#"); got != tc.want {
+			t.Errorf("PrefixMessage(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestCleanMessage(t *testing.T) {
+	msg := "\nPROJ-1: Fix login  \n\nBody\n# comment\n\n; ------------------------ >8 ------------------------\ndiff\n"
+	if got, want := CleanMessage(msg, ";"), "PROJ-1: Fix login\n\nBody\n# comment"; got != want {
+		t.Errorf("CleanMessage = %q, want %q", got, want)
+	}
+	if got := CleanMessage(editorTemplate, "#"); got != "" {
+		t.Errorf("CleanMessage(editorTemplate) = %q", got)
+	}
+}
+
+func TestInstallHook(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "hooks")
+	script := HookScript("/opt/it's/jet", "hooks run commit-msg")
+	want := "#!/bin/sh\n# Installed by jet; reinstall with 'jet hooks install'.\n" +
+		"JET='/opt/it'\\''s/jet'\n" +
+		"[ -x \"$JET\" ] || JET=jet\n" +
+		"exec \"$JET\" hooks run commit-msg \"$@\"\n"
+	if script != want {
+		t.Errorf("script = %q, want %q", script, want)
+	}
+	if err := InstallHook(dir, "commit-msg", script, false); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(filepath.Join(dir, "commit-msg"))
+	if err != nil || info.Mode().Perm()&0100 == 0 {
+		t.Fatalf("hook not executable: %v %v", info, err)
+	}
+	// Reinstalling over our own hook needs no force.
+	if err := InstallHook(dir, "commit-msg", script, false); err != nil {
+		t.Fatal(err)
+	}
+
+	foreign := filepath.Join(dir, "prepare-commit-msg")
+	os.WriteFile(foreign, []byte("#!/bin/sh\necho mine\n"), 0755)
+	if err := InstallHook(dir, "prepare-commit-msg", script, false); !errors.Is(err, ErrForeignHook) {
+		t.Fatalf("foreign hook replaced without force: %v", err)
+	}
+	if err := InstallHook(dir, "prepare-commit-msg", script, true); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(foreign + ".orig"); string(data) != "#!/bin/sh\necho mine\n" {
+		t.Errorf("old hook not kept: %q", data)
+	}
+
+	if removed, err := UninstallHook(dir, "prepare-commit-msg"); err != nil || !removed {
+		t.Fatalf("UninstallHook = %v, %v", removed, err)
+	}
+	if data, _ := os.ReadFile(foreign); string(data) != "#!/bin/sh\necho mine\n" {
+		t.Errorf("old hook not restored: %q", data)
+	}
+	if _, err := UninstallHook(dir, "prepare-commit-msg"); !errors.Is(err, ErrForeignHook) {
+		t.Errorf("foreign hook removed: %v", err)
+	}
+	if removed, err := UninstallHook(dir, "pre-push"); err != nil || removed {
+		t.Errorf("missing hook: %v, %v", removed, err)
+	}
+}
+
+func TestHooksDir(t *testing.T) {
+	r := newRepo(t)
+	ctx := context.Background()
+	dir, err := r.HooksDir(ctx)
+	if want, _ := filepath.EvalSymlinks(filepath.Join(r.Dir, ".git", "hooks")); err != nil || mustEval(dir) != want {
+		t.Errorf("HooksDir = %q, %v; want %q", dir, err, want)
+	}
+	if _, err := r.run(ctx, "config", "core.hooksPath", "githooks"); err != nil {
+		t.Fatal(err)
+	}
+	if dir, err := r.HooksDir(ctx); err != nil || filepath.Base(dir) != "githooks" {
+		t.Errorf("HooksDir with core.hooksPath = %q, %v", dir, err)
+	}
+	if c := r.CommentChar(ctx); c != "#" {
+		t.Errorf("CommentChar = %q", c)
+	}
+}
+
+func mustEval(path string) string {
+	if p, err := filepath.EvalSymlinks(path); err == nil {
+		return p
+	}
+	return path
+}
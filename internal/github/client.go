@@ -2,6 +2,7 @@
 package github
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os/exec"
@@ -39,7 +40,7 @@ func RepoExists(repo string) bool {
 }
 
 // listRepo runs `gh pr list` for one repo with an optional extra --search filter.
-func listRepo(repo, search string, limit int) ([]PR, error) {
+func listRepo(ctx context.Context, repo, search string, limit int) ([]PR, error) {
 	args := []string{
 		"pr", "list",
 		"--repo", repo,
@@ -50,7 +51,7 @@ func listRepo(repo, search string, limit int) ([]PR, error) {
 	if search != "" {
 		args = append(args, "--search", search)
 	}
-	out, err := exec.Command("gh", args...).Output()
+	out, err := exec.CommandContext(ctx, "gh", args...).Output()
 	if err != nil {
 		if ee, ok := err.(*exec.ExitError); ok {
 			return nil, fmt.Errorf("gh pr list failed for %s: %s", repo, strings.TrimSpace(string(ee.Stderr)))
@@ -69,23 +70,36 @@ func listRepo(repo, search string, limit int) ([]PR, error) {
 
 // Authored returns open PRs you authored across the given repos.
 func Authored(repos []string, limit int) ([]PR, error) {
-	return listAcross(repos, "author:@me", limit)
+	return AuthoredContext(context.Background(), repos, limit)
+}
+
+// AuthoredContext is like Authored but stops when ctx is cancelled.
+func AuthoredContext(ctx context.Context, repos []string, limit int) ([]PR, error) {
+	return listAcross(ctx, repos, "author:@me", limit)
 }
 
 // ReviewRequested returns open PRs where your review is requested across the repos.
 func ReviewRequested(repos []string, limit int) ([]PR, error) {
-	return listAcross(repos, "review-requested:@me", limit)
+	return ReviewRequestedContext(context.Background(), repos, limit)
 }
 
-func listAcross(repos []string, search string, limit int) ([]PR, error) {
+// ReviewRequestedContext is like ReviewRequested but stops when ctx is cancelled.
+func ReviewRequestedContext(ctx context.Context, repos []string, limit int) ([]PR, error) {
+	return listAcross(ctx, repos, "review-requested:@me", limit)
+}
+
+func listAcross(ctx context.Context, repos []string, search string, limit int) ([]PR, error) {
 	var all []PR
 	var errs []string
 	for _, repo := range repos {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		repo = strings.TrimSpace(repo)
 		if repo == "" {
 			continue
 		}
-		prs, err := listRepo(repo, search, limit)
+		prs, err := listRepo(ctx, repo, search, limit)
 		if err != nil {
 			errs = append(errs, err.Error())
 			continue
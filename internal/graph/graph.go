@@ -0,0 +1,332 @@
+// Package graph crawls the links and parent/child relationships around a
+// Jira issue and renders them as a dependency graph.
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"jet/internal/jira"
+)
+
+// Status categories, as Jira names them.
+const (
+	ToDo       = "new"
+	InProgress = "indeterminate"
+	Done       = "done"
+)
+
+// DefaultConcurrency is how many issues Crawl fetches at once by default.
+const DefaultConcurrency = 4
+
+// Fetcher loads issues and their children; *jira.Client implements it.
+type Fetcher interface {
+	GetIssueLinksContext(ctx context.Context, issueKey string) (*jira.Issue, error)
+	GetIssueChildrenContext(ctx context.Context, parentKey string) ([]jira.Issue, error)
+	GetEpicChildrenContext(ctx context.Context, epicKey string) ([]jira.Issue, error)
+}
+
+// Node is an issue in the graph.
+type Node struct {
+	Key      string `json:"key"`
+	Summary  string `json:"summary"`
+	Type     string `json:"type"`
+	Status   string `json:"status"`
+	Category string `json:"category"` // ToDo, InProgress or Done
+	Depth    int    `json:"depth"`    // hops from the root
+	Critical bool   `json:"critical,omitempty"`
+}
+
+// Edge is a link or a parent/child relationship, pointing the way its
+// label reads: "PROJ-1 blocks PROJ-2", "PROJ-1 parent of PROJ-3".
+type Edge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Kind     string `json:"kind"` // link type name such as "Blocks", or "parent"
+	Label    string `json:"label"`
+	Critical bool   `json:"critical,omitempty"`
+}
+
+// ParentKind is the Kind of parent/child edges.
+const ParentKind = "parent"
+
+// Graph is the neighbourhood of Root.
+type Graph struct {
+	Root  string `json:"root"`
+	Nodes []Node `json:"nodes"` // in crawl order, Root first
+	Edges []Edge `json:"edges"`
+
+	// CriticalPath is the longest chain of unresolved issues blocking one
+	// another, first blocker first; empty when nothing unresolved blocks.
+	CriticalPath []string `json:"criticalPath"`
+	// Cycles are chains of unresolved issues that block each other in a
+	// circle, which left alone will never be resolved.
+	Cycles [][]string `json:"cycles,omitempty"`
+	// Skipped are issues that are linked but could not be fetched, usually
+	// for lack of permission; they are kept with what their links told.
+	Skipped []string `json:"skipped,omitempty"`
+}
+
+// Options tune Crawl.
+type Options struct {
+	Depth       int // hops from the root to follow; 0 is the root alone
+	Concurrency int // issues fetched at once; DefaultConcurrency when 0
+}
+
+// fetched is what was loaded for one issue.
+type fetched struct {
+	issue    *jira.Issue
+	children []jira.Issue
+	err      error
+}
+
+// Crawl walks links and parent/child relationships breadth first from
+// root, up to opts.Depth hops. Each issue is fetched once, so cycles end
+// the walk rather than loop it. Issues at the last hop are fetched too, so
+// their status is known and edges among them are kept, but what lies
+// beyond them is not.
+func Crawl(ctx context.Context, f Fetcher, root string, opts Options) (*Graph, error) {
+	root = strings.ToUpper(root)
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	g := &Graph{Root: root}
+	nodes := map[string]*Node{root: {Key: root}}
+	order := []string{root}
+	edges := make(map[string]bool)
+	addEdge := func(e Edge) {
+		id := e.From + "\x00" + e.To + "\x00" + e.Kind
+		if !edges[id] {
+			edges[id] = true
+			g.Edges = append(g.Edges, e)
+		}
+	}
+
+	level := []string{root}
+	for depth := 0; len(level) > 0; depth++ {
+		expand := depth < opts.Depth
+		results := make([]fetched, len(level))
+		sem := make(chan struct{}, concurrency)
+		var wg sync.WaitGroup
+		for i, key := range level {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				results[i] = fetch(ctx, f, key, expand)
+			}()
+		}
+		wg.Wait()
+
+		var next []string
+		// Seen neighbours join the graph one hop further out.
+		visit := func(n Node) {
+			if _, ok := nodes[n.Key]; ok || !expand {
+				return
+			}
+			n.Depth = depth + 1
+			nodes[n.Key] = &n
+			order = append(order, n.Key)
+			next = append(next, n.Key)
+		}
+		for i, r := range results {
+			key := level[i]
+			if r.err != nil {
+				if depth > 0 && (errors.Is(r.err, jira.ErrNotFound) || errors.Is(r.err, jira.ErrForbidden)) {
+					g.Skipped = append(g.Skipped, key)
+					continue
+				}
+				return nil, fmt.Errorf("failed to fetch %s: %w", key, r.err)
+			}
+			issue := r.issue
+			n := nodes[key]
+			// Keep the key it was reached by, even if the issue has moved.
+			*n = issueNode(key, issue.Fields.Summary, issue.Fields.IssueType.Name, issue.Fields.Status)
+			n.Depth = depth
+
+			if p := parentKey(issue); p != "" {
+				addEdge(Edge{From: p, To: n.Key, Kind: ParentKind, Label: "parent of"})
+				visit(Node{Key: p})
+			}
+			for _, link := range issue.Fields.IssueLinks {
+				switch {
+				case link.OutwardIssue != nil:
+					other := link.OutwardIssue
+					addEdge(Edge{From: n.Key, To: other.Key, Kind: link.Type.Name, Label: link.Type.Outward})
+					visit(issueNode(other.Key, other.Fields.Summary, other.Fields.IssueType.Name, other.Fields.Status))
+				case link.InwardIssue != nil:
+					other := link.InwardIssue
+					addEdge(Edge{From: other.Key, To: n.Key, Kind: link.Type.Name, Label: link.Type.Outward})
+					visit(issueNode(other.Key, other.Fields.Summary, other.Fields.IssueType.Name, other.Fields.Status))
+				}
+			}
+			for _, child := range r.children {
+				addEdge(Edge{From: n.Key, To: child.Key, Kind: ParentKind, Label: "parent of"})
+				visit(issueNode(child.Key, child.Fields.Summary, child.Fields.IssueType.Name, child.Fields.Status))
+			}
+		}
+		level = next
+	}
+
+	for _, key := range order {
+		g.Nodes = append(g.Nodes, *nodes[key])
+	}
+	// Drop edges leading past the last hop.
+	kept := g.Edges[:0]
+	for _, e := range g.Edges {
+		if nodes[e.From] != nil && nodes[e.To] != nil {
+			kept = append(kept, e)
+		}
+	}
+	g.Edges = kept
+	g.markCriticalPath()
+	return g, nil
+}
+
+// fetch loads an issue and, when the crawl goes on past it, its children.
+func fetch(ctx context.Context, f Fetcher, key string, expand bool) fetched {
+	issue, err := f.GetIssueLinksContext(ctx, key)
+	if err != nil || !expand {
+		return fetched{issue: issue, err: err}
+	}
+	r := fetched{issue: issue}
+	r.children, r.err = children(ctx, f, issue)
+	return r
+}
+
+// children fetches an epic's issues, or the sub-tasks and child issues of
+// anything else. Sub-tasks have none.
+func children(ctx context.Context, f Fetcher, issue *jira.Issue) ([]jira.Issue, error) {
+	switch {
+	case issue.Fields.IssueType.Subtask:
+		return nil, nil
+	case strings.EqualFold(issue.Fields.IssueType.Name, "Epic"):
+		return f.GetEpicChildrenContext(ctx, issue.Key)
+	}
+	return f.GetIssueChildrenContext(ctx, issue.Key)
+}
+
+// parentKey returns the issue's epic or parent.
+func parentKey(issue *jira.Issue) string {
+	if issue.Fields.EpicLink != nil && issue.Fields.EpicLink.Key != "" {
+		return issue.Fields.EpicLink.Key
+	}
+	if issue.Fields.Parent != nil {
+		return issue.Fields.Parent.Key
+	}
+	return ""
+}
+
+func issueNode(key, summary, typ string, status jira.Status) Node {
+	return Node{Key: key, Summary: summary, Type: typ, Status: status.Name, Category: category(status)}
+}
+
+// category returns the status category of s, guessing from its name when
+// Jira did not say.
+func category(s jira.Status) string {
+	if s.Category != nil && s.Category.Key != "" {
+		return s.Category.Key
+	}
+	if s.Name == "" {
+		return ""
+	}
+	if s.IsDone() {
+		return Done
+	}
+	switch strings.ToLower(s.Name) {
+	case "to do", "open", "backlog", "new", "selected for development":
+		return ToDo
+	}
+	return InProgress
+}
+
+// isBlocks reports whether e is a "blocks" link.
+func isBlocks(e Edge) bool {
+	return strings.EqualFold(e.Kind, "Blocks") || strings.EqualFold(e.Label, "blocks")
+}
+
+// markCriticalPath finds the longest chain of "blocks" links between
+// unresolved issues, and the cycles among them. A depth-first search sets
+// aside the links that close a cycle, which leaves the longest path in what
+// remains well defined.
+func (g *Graph) markCriticalPath() {
+	open := make(map[string]bool)
+	for _, n := range g.Nodes {
+		open[n.Key] = n.Category != Done
+	}
+	blocks := make(map[string][]string)
+	for _, e := range g.Edges {
+		if isBlocks(e) && open[e.From] && open[e.To] && e.From != e.To {
+			blocks[e.From] = append(blocks[e.From], e.To)
+		}
+	}
+
+	const (
+		unvisited = iota
+		onStack
+		finished
+	)
+	state := make(map[string]int)
+	length := make(map[string]int) // issues on the longest chain from a key
+	next := make(map[string]string)
+	var stack []string
+	var visit func(key string)
+	visit = func(key string) {
+		state[key] = onStack
+		stack = append(stack, key)
+		length[key] = 1
+		for _, to := range blocks[key] {
+			switch state[to] {
+			case onStack:
+				for i := len(stack) - 1; i >= 0; i-- {
+					if stack[i] == to {
+						g.Cycles = append(g.Cycles, append([]string(nil), stack[i:]...))
+						break
+					}
+				}
+				continue
+			case unvisited:
+				visit(to)
+			}
+			if length[to]+1 > length[key] {
+				length[key] = length[to] + 1
+				next[key] = to
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[key] = finished
+	}
+
+	start := ""
+	for _, n := range g.Nodes {
+		if state[n.Key] == unvisited && len(blocks[n.Key]) > 0 {
+			visit(n.Key)
+		}
+	}
+	for _, n := range g.Nodes {
+		if length[n.Key] > 1 && length[n.Key] > length[start] {
+			start = n.Key
+		}
+	}
+	if start == "" {
+		return
+	}
+
+	critical := make(map[string]bool)
+	for key := start; key != ""; key = next[key] {
+		g.CriticalPath = append(g.CriticalPath, key)
+		critical[key] = true
+	}
+	for i := range g.Nodes {
+		g.Nodes[i].Critical = critical[g.Nodes[i].Key]
+	}
+	for i, e := range g.Edges {
+		g.Edges[i].Critical = isBlocks(e) && critical[e.From] && next[e.From] == e.To
+	}
+}
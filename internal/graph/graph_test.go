@@ -0,0 +1,191 @@
+package graph
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"jet/internal/jira"
+)
+
+// fakeJira serves issues from a map and counts lookups.
+type fakeJira struct {
+	issues   map[string]*jira.Issue
+	children map[string][]string
+
+	mu      sync.Mutex
+	fetches map[string]int
+}
+
+func (f *fakeJira) GetIssueLinksContext(ctx context.Context, key string) (*jira.Issue, error) {
+	f.mu.Lock()
+	f.fetches[key]++
+	f.mu.Unlock()
+	issue, ok := f.issues[key]
+	if !ok {
+		return nil, &jira.APIError{StatusCode: 404, Resource: "issue " + key}
+	}
+	return issue, nil
+}
+
+func (f *fakeJira) GetIssueChildrenContext(ctx context.Context, key string) ([]jira.Issue, error) {
+	var out []jira.Issue
+	for _, k := range f.children[key] {
+		out = append(out, *f.issues[k])
+	}
+	return out, nil
+}
+
+func (f *fakeJira) GetEpicChildrenContext(ctx context.Context, key string) ([]jira.Issue, error) {
+	return f.GetIssueChildrenContext(ctx, key)
+}
+
+func issue(key, typ, category string) *jira.Issue {
+	status := map[string]string{ToDo: "To Do", InProgress: "In Progress", Done: "Done"}[category]
+	return &jira.Issue{Key: key, Fields: jira.Fields{
+		Summary:   "Summary of " + key,
+		IssueType: jira.IssueType{Name: typ},
+		Status:    jira.Status{Name: status, Category: &jira.StatusCategory{Key: category}},
+	}}
+}
+
+// blocks records "from blocks to" on both issues, as Jira returns it.
+func blocks(from, to *jira.Issue) {
+	typ := jira.IssueLinkTypeDetail{Name: "Blocks", Inward: "is blocked by", Outward: "blocks"}
+	linked := func(i *jira.Issue) *jira.LinkedIssue {
+		return &jira.LinkedIssue{Key: i.Key, Fields: jira.LinkedFields{Summary: i.Fields.Summary, Status: i.Fields.Status, IssueType: i.Fields.IssueType}}
+	}
+	from.Fields.IssueLinks = append(from.Fields.IssueLinks, jira.IssueLinkItem{Type: typ, OutwardIssue: linked(to)})
+	to.Fields.IssueLinks = append(to.Fields.IssueLinks, jira.IssueLinkItem{Type: typ, InwardIssue: linked(from)})
+}
+
+func newFake(issues ...*jira.Issue) *fakeJira {
+	f := &fakeJira{issues: map[string]*jira.Issue{}, children: map[string][]string{}, fetches: map[string]int{}}
+	for _, i := range issues {
+		f.issues[i.Key] = i
+		if i.Fields.Parent != nil {
+			f.children[i.Fields.Parent.Key] = append(f.children[i.Fields.Parent.Key], i.Key)
+		}
+	}
+	return f
+}
+
+func keys(nodes []Node) []string {
+	var out []string
+	for _, n := range nodes {
+		out = append(out, n.Key)
+	}
+	return out
+}
+
+func TestCrawl(t *testing.T) {
+	epic := issue("P-1", "Epic", InProgress)
+	a, b, c, d := issue("P-2", "Story", InProgress), issue("P-3", "Story", ToDo), issue("P-4", "Story", ToDo), issue("P-5", "Bug", Done)
+	far := issue("Q-1", "Task", ToDo)
+	for _, child := range []*jira.Issue{a, b, c, d} {
+		child.Fields.Parent = &jira.IssueLink{Key: epic.Key}
+	}
+	blocks(a, b)
+	blocks(b, c)
+	blocks(d, c)   // resolved, so not on the critical path
+	blocks(c, far) // two hops from the epic
+	f := newFake(epic, a, b, c, d, far)
+
+	g, err := Crawl(context.Background(), f, "p-1", Options{Depth: 1, Concurrency: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := keys(g.Nodes), []string{"P-1", "P-2", "P-3", "P-4", "P-5"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("nodes = %v, want %v", got, want)
+	}
+	for _, e := range g.Edges {
+		if e.To == "Q-1" {
+			t.Errorf("edge past the last hop kept: %+v", e)
+		}
+	}
+	if got, want := len(g.Edges), 4+3; got != want {
+		t.Errorf("%d edges, want %d: %+v", got, want, g.Edges)
+	}
+	if want := []string{"P-2", "P-3", "P-4"}; !reflect.DeepEqual(g.CriticalPath, want) {
+		t.Errorf("critical path = %v, want %v", g.CriticalPath, want)
+	}
+	for key, n := range f.fetches {
+		if n != 1 {
+			t.Errorf("%s fetched %d times", key, n)
+		}
+	}
+
+	g, err = Crawl(context.Background(), f, "P-2", Options{Depth: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := keys(g.Nodes), []string{"P-2", "P-1", "P-3", "P-4", "P-5"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("from P-2: nodes = %v, want %v", got, want)
+	}
+	if g.Nodes[0].Depth != 0 || g.Nodes[1].Depth != 1 || g.Nodes[3].Depth != 2 {
+		t.Errorf("depths: %+v", g.Nodes)
+	}
+}
+
+func TestCrawlCycles(t *testing.T) {
+	a, b, c := issue("P-1", "Task", ToDo), issue("P-2", "Task", ToDo), issue("P-3", "Task", ToDo)
+	blocks(a, b)
+	blocks(b, c)
+	blocks(c, a)
+	a.Fields.IssueLinks = append(a.Fields.IssueLinks, jira.IssueLinkItem{
+		Type:         jira.IssueLinkTypeDetail{Name: "Relates", Outward: "relates to", Inward: "relates to"},
+		OutwardIssue: &jira.LinkedIssue{Key: "P-9"},
+	})
+	f := newFake(a, b, c)
+
+	g, err := Crawl(context.Background(), f, "P-1", Options{Depth: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Nodes) != 4 {
+		t.Errorf("nodes = %v", keys(g.Nodes))
+	}
+	if !reflect.DeepEqual(g.Skipped, []string{"P-9"}) {
+		t.Errorf("skipped = %v", g.Skipped)
+	}
+	if want := [][]string{{"P-1", "P-2", "P-3"}}; !reflect.DeepEqual(g.Cycles, want) {
+		t.Errorf("cycles = %v, want %v", g.Cycles, want)
+	}
+	if len(g.CriticalPath) != 3 {
+		t.Errorf("critical path = %v", g.CriticalPath)
+	}
+
+	if _, err := Crawl(context.Background(), f, "NOPE-1", Options{}); err == nil {
+		t.Error("missing root not reported")
+	}
+}
+
+func TestCrawlDepthZero(t *testing.T) {
+	a, b := issue("P-1", "Task", ToDo), issue("P-2", "Task", ToDo)
+	blocks(a, b)
+	g, err := Crawl(context.Background(), newFake(a, b), "P-1", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Nodes) != 1 || len(g.Edges) != 0 || g.CriticalPath != nil {
+		t.Errorf("graph = %+v", g)
+	}
+}
+
+func TestCategory(t *testing.T) {
+	for _, tc := range []struct {
+		status jira.Status
+		want   string
+	}{
+		{jira.Status{Name: "Whatever", Category: &jira.StatusCategory{Key: Done}}, Done},
+		{jira.Status{Name: "Closed"}, Done},
+		{jira.Status{Name: "Backlog"}, ToDo},
+		{jira.Status{Name: "In Review"}, InProgress},
+		{jira.Status{}, ""},
+	} {
+		if got := category(tc.status); got != tc.want {
+			t.Errorf("category(%v) = %q, want %q", tc.status, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Formats Write supports.
+const (
+	DOT     = "dot"
+	Mermaid = "mermaid"
+	JSON    = "json"
+)
+
+// Formats lists the formats Write supports.
+var Formats = []string{DOT, Mermaid, JSON}
+
+// maxLabelSummary caps the summary shown in a node.
+const maxLabelSummary = 40
+
+// palette holds the fill and border of a status category, after Jira's
+// lozenge colours.
+type palette struct{ fill, stroke string }
+
+var categoryColours = map[string]palette{
+	ToDo:       {"#dfe1e6", "#42526e"},
+	InProgress: {"#deebff", "#0052cc"},
+	Done:       {"#e3fcef", "#006644"},
+	"":         {"#ffffff", "#6b778c"}, // not fetched
+}
+
+// criticalColour marks the critical path.
+const criticalColour = "#de350b"
+
+// Write renders g in format.
+func Write(w io.Writer, g *Graph, format string) error {
+	switch format {
+	case DOT:
+		return writeDOT(w, g)
+	case Mermaid:
+		return writeMermaid(w, g)
+	case JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(g)
+	}
+	return fmt.Errorf("unknown graph format %q (use %s)", format, strings.Join(Formats, ", "))
+}
+
+func colours(n Node) palette {
+	if p, ok := categoryColours[n.Category]; ok {
+		return p
+	}
+	return categoryColours[""]
+}
+
+// label is the text of a node: key and summary on the first line, status
+// below.
+func label(n Node) []string {
+	lines := []string{n.Key}
+	if n.Summary != "" {
+		lines[0] += ": " + truncate(n.Summary, maxLabelSummary)
+	}
+	if n.Status != "" {
+		lines = append(lines, n.Status)
+	}
+	return lines
+}
+
+func truncate(s string, limit int) string {
+	r := []rune(s)
+	if len(r) <= limit {
+		return s
+	}
+	return strings.TrimSpace(string(r[:limit-1])) + "…"
+}
+
+func writeDOT(w io.Writer, g *Graph) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", dotQuote(g.Root))
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\", fontsize=11];\n")
+	b.WriteString("  edge [fontname=\"Helvetica\", fontsize=9];\n")
+	for _, n := range g.Nodes {
+		p := colours(n)
+		lines := label(n)
+		for i := range lines {
+			lines[i] = dotEscape(lines[i])
+		}
+		if n.Critical {
+			p.stroke = criticalColour
+		}
+		attrs := fmt.Sprintf("label=\"%s\", fillcolor=%q, color=%q", strings.Join(lines, `\n`), p.fill, p.stroke)
+		if n.Critical {
+			attrs += ", penwidth=2"
+		}
+		if n.Key == g.Root {
+			attrs += ", peripheries=2"
+		}
+		fmt.Fprintf(&b, "  %s [%s];\n", dotQuote(n.Key), attrs)
+	}
+	for _, e := range g.Edges {
+		var attrs []string
+		if e.Kind == ParentKind {
+			attrs = append(attrs, "style=dashed", "arrowhead=none")
+		} else {
+			attrs = append(attrs, "label="+dotQuote(e.Label))
+		}
+		if e.Critical {
+			attrs = append(attrs, fmt.Sprintf("color=%q", criticalColour), fmt.Sprintf("fontcolor=%q", criticalColour), "penwidth=2")
+		}
+		fmt.Fprintf(&b, "  %s -> %s [%s];\n", dotQuote(e.From), dotQuote(e.To), strings.Join(attrs, ", "))
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", " ").Replace(s)
+}
+
+func dotQuote(s string) string {
+	return `"` + dotEscape(s) + `"`
+}
+
+func writeMermaid(w io.Writer, g *Graph) error {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	byCategory := make(map[string][]string)
+	var critical []string
+	for _, n := range g.Nodes {
+		lines := label(n)
+		for i := range lines {
+			lines[i] = mermaidEscape(lines[i])
+		}
+		// The root gets a double border.
+		left, right := "[\"", "\"]"
+		if n.Key == g.Root {
+			left, right = "[[\"", "\"]]"
+		}
+		fmt.Fprintf(&b, "  %s%s%s%s\n", mermaidID(n.Key), left, strings.Join(lines, "<br/>"), right)
+		cat := n.Category
+		if _, ok := categoryColours[cat]; !ok {
+			cat = ""
+		}
+		byCategory[cat] = append(byCategory[cat], mermaidID(n.Key))
+		if n.Critical {
+			critical = append(critical, mermaidID(n.Key))
+		}
+	}
+	var criticalEdges []string
+	for i, e := range g.Edges {
+		if e.Kind == ParentKind {
+			fmt.Fprintf(&b, "  %s -.- %s\n", mermaidID(e.From), mermaidID(e.To))
+		} else {
+			fmt.Fprintf(&b, "  %s -->|\"%s\"| %s\n", mermaidID(e.From), mermaidEscape(e.Label), mermaidID(e.To))
+		}
+		if e.Critical {
+			criticalEdges = append(criticalEdges, fmt.Sprint(i))
+		}
+	}
+	for _, cat := range []string{ToDo, InProgress, Done, ""} {
+		ids := byCategory[cat]
+		if len(ids) == 0 {
+			continue
+		}
+		name := mermaidClass(cat)
+		p := categoryColours[cat]
+		fmt.Fprintf(&b, "  classDef %s fill:%s,stroke:%s,color:#172b4d\n", name, p.fill, p.stroke)
+		fmt.Fprintf(&b, "  class %s %s\n", strings.Join(ids, ","), name)
+	}
+	if len(critical) > 0 {
+		fmt.Fprintf(&b, "  classDef critical stroke:%s,stroke-width:3px\n", criticalColour)
+		fmt.Fprintf(&b, "  class %s critical\n", strings.Join(critical, ","))
+	}
+	if len(criticalEdges) > 0 {
+		fmt.Fprintf(&b, "  linkStyle %s stroke:%s,stroke-width:3px\n", strings.Join(criticalEdges, ","), criticalColour)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// mermaidID turns a key into a node ID Mermaid will not mistake for an
+// arrow: PROJ-12 becomes PROJ_12.
+func mermaidID(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, key)
+}
+
+func mermaidClass(category string) string {
+	switch category {
+	case ToDo:
+		return "todo"
+	case InProgress:
+		return "inprogress"
+	case Done:
+		return "resolved"
+	}
+	return "unknown"
+}
+
+// mermaidEscape makes s safe inside a quoted Mermaid label.
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;", "\n", " ").Replace(s)
+}
@@ -0,0 +1,42 @@
+package graph
+
+import (
+	"fmt"
+	"os"
+)
+
+func ExampleWrite() {
+	g := &Graph{
+		Root: "P-1",
+		Nodes: []Node{
+			{Key: "P-1", Summary: `Say "hi"`, Status: "In Progress", Category: InProgress, Critical: true},
+			{Key: "P-2", Summary: "Follow up", Status: "To Do", Category: ToDo, Critical: true},
+		},
+		Edges: []Edge{{From: "P-1", To: "P-2", Kind: "Blocks", Label: "blocks", Critical: true}},
+	}
+	for _, format := range []string{DOT, Mermaid} {
+		if err := Write(os.Stdout, g, format); err != nil {
+			fmt.Println(err)
+		}
+	}
+	// Output:
+	// digraph "P-1" {
+	//   rankdir=LR;
+	//   node [shape=box, style="rounded,filled", fontname="Helvetica", fontsize=11];
+	//   edge [fontname="Helvetica", fontsize=9];
+	//   "P-1" [label="P-1: Say \"hi\"\nIn Progress", fillcolor="#deebff", color="#de350b", penwidth=2, peripheries=2];
+	//   "P-2" [label="P-2: Follow up\nTo Do", fillcolor="#dfe1e6", color="#de350b", penwidth=2];
+	//   "P-1" -> "P-2" [label="blocks", color="#de350b", fontcolor="#de350b", penwidth=2];
+	// }
+	// flowchart LR
+	//   P_1[["P-1: Say #quot;hi#quot;<br/>In Progress"]]
+	//   P_2["P-2: Follow up<br/>To Do"]
+	//   P_1 -->|"blocks"| P_2
+	//   classDef todo fill:#dfe1e6,stroke:#42526e,color:#172b4d
+	//   class P_2 todo
+	//   classDef inprogress fill:#deebff,stroke:#0052cc,color:#172b4d
+	//   class P_1 inprogress
+	//   classDef critical stroke:#de350b,stroke-width:3px
+	//   class P_1,P_2 critical
+	//   linkStyle 0 stroke:#de350b,stroke-width:3px
+}
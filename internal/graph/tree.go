@@ -0,0 +1,162 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"jet/internal/jira"
+)
+
+// TreeNode is an issue in a parent/child hierarchy, such as initiative,
+// epic, story and sub-task.
+type TreeNode struct {
+	Key      string `json:"key"`
+	Summary  string `json:"summary"`
+	Type     string `json:"type"`
+	Status   string `json:"status"`
+	Category string `json:"category"` // ToDo, InProgress or Done
+	Assignee string `json:"assignee,omitempty"`
+
+	// Done and Total count the issues anywhere below this one, and how
+	// many of them are resolved.
+	Done  int `json:"done"`
+	Total int `json:"total"`
+
+	Children []*TreeNode `json:"children,omitempty"`
+}
+
+// TreeOptions tune Tree.
+type TreeOptions struct {
+	Depth       int // levels below the root to load; 0 for all
+	Concurrency int // issues whose children are fetched at once; DefaultConcurrency when 0
+}
+
+// Tree loads the hierarchy below root, a level at a time. An issue already
+// in the tree is not added again, so a parent loop cannot make it endless.
+func Tree(ctx context.Context, f Fetcher, root string, opts TreeOptions) (*TreeNode, error) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	issue, err := f.GetIssueLinksContext(ctx, strings.ToUpper(root))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", root, err)
+	}
+	top := treeNode(issue)
+	seen := map[string]bool{top.Key: true}
+
+	type pending struct {
+		node  *TreeNode
+		issue *jira.Issue
+	}
+	level := []pending{{top, issue}}
+	for depth := 0; len(level) > 0 && (opts.Depth <= 0 || depth < opts.Depth); depth++ {
+		results := make([][]jira.Issue, len(level))
+		errs := make([]error, len(level))
+		sem := make(chan struct{}, concurrency)
+		var wg sync.WaitGroup
+		for i, p := range level {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				results[i], errs[i] = children(ctx, f, p.issue)
+			}()
+		}
+		wg.Wait()
+
+		var next []pending
+		for i, p := range level {
+			if errs[i] != nil {
+				return nil, fmt.Errorf("failed to fetch the children of %s: %w", p.node.Key, errs[i])
+			}
+			for j := range results[i] {
+				child := &results[i][j]
+				if seen[child.Key] {
+					continue
+				}
+				seen[child.Key] = true
+				n := treeNode(child)
+				p.node.Children = append(p.node.Children, n)
+				next = append(next, pending{n, child})
+			}
+		}
+		level = next
+	}
+	top.rollUp()
+	return top, nil
+}
+
+// TopOf returns the top of key's hierarchy, following parents and epics up
+// as far as they can be seen.
+func TopOf(ctx context.Context, f Fetcher, key string) (string, error) {
+	key = strings.ToUpper(key)
+	top := ""
+	seen := map[string]bool{key: true}
+	for {
+		issue, err := f.GetIssueLinksContext(ctx, key)
+		if err != nil {
+			if top != "" && (errors.Is(err, jira.ErrNotFound) || errors.Is(err, jira.ErrForbidden)) {
+				return top, nil
+			}
+			return "", fmt.Errorf("failed to fetch %s: %w", key, err)
+		}
+		top = key
+		parent := parentKey(issue)
+		if parent == "" || seen[parent] {
+			return top, nil
+		}
+		seen[parent] = true
+		key = parent
+	}
+}
+
+func treeNode(issue *jira.Issue) *TreeNode {
+	n := &TreeNode{
+		Key:      issue.Key,
+		Summary:  issue.Fields.Summary,
+		Type:     issue.Fields.IssueType.Name,
+		Status:   issue.Fields.Status.Name,
+		Category: category(issue.Fields.Status),
+	}
+	if a := issue.Fields.Assignee; a != nil {
+		n.Assignee = a.DisplayName
+		if n.Assignee == "" {
+			n.Assignee = a.Name
+		}
+	}
+	return n
+}
+
+// rollUp fills Done and Total from the bottom up.
+func (n *TreeNode) rollUp() {
+	n.Done, n.Total = 0, 0
+	for _, c := range n.Children {
+		c.rollUp()
+		n.Total += 1 + c.Total
+		n.Done += c.Done
+		if c.Category == Done {
+			n.Done++
+		}
+	}
+}
+
+// Walk calls fn for n and the nodes below it, depth first, with their depth
+// below n. Returning false from fn skips the children of that node.
+func (n *TreeNode) Walk(fn func(node *TreeNode, depth int) bool) {
+	var walk func(node *TreeNode, depth int)
+	walk = func(node *TreeNode, depth int) {
+		if !fn(node, depth) {
+			return
+		}
+		for _, c := range node.Children {
+			walk(c, depth+1)
+		}
+	}
+	walk(n, 0)
+}
@@ -0,0 +1,60 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"jet/internal/jira"
+)
+
+func TestTree(t *testing.T) {
+	initiative := issue("P-1", "Initiative", InProgress)
+	epic, other := issue("P-2", "Epic", InProgress), issue("P-3", "Epic", Done)
+	story := issue("P-4", "Story", Done)
+	sub := issue("P-5", "Sub-task", ToDo)
+	sub.Fields.IssueType.Subtask = true
+	story.Fields.Assignee = &jira.User{DisplayName: "Ada"}
+	epic.Fields.Parent = &jira.IssueLink{Key: "P-1"}
+	other.Fields.Parent = &jira.IssueLink{Key: "P-1"}
+	story.Fields.Parent = &jira.IssueLink{Key: "P-2"}
+	sub.Fields.Parent = &jira.IssueLink{Key: "P-4"}
+	f := newFake(initiative, epic, other, story, sub)
+
+	root, err := Tree(context.Background(), f, "p-1", TreeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	root.Walk(func(n *TreeNode, depth int) bool {
+		got = append(got, fmt.Sprint(depth)+n.Key)
+		return true
+	})
+	if want := "0P-1 1P-2 2P-4 3P-5 1P-3"; strings.Join(got, " ") != want {
+		t.Errorf("tree = %s, want %s", strings.Join(got, " "), want)
+	}
+	if root.Done != 2 || root.Total != 4 {
+		t.Errorf("root roll-up = %d/%d, want 2/4", root.Done, root.Total)
+	}
+	if e := root.Children[0]; e.Done != 1 || e.Total != 2 || e.Children[0].Assignee != "Ada" {
+		t.Errorf("epic = %+v", e)
+	}
+
+	root, err = Tree(context.Background(), f, "P-1", TreeOptions{Depth: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(root.Children) != 2 || len(root.Children[0].Children) != 0 {
+		t.Errorf("depth 1: %+v", root.Children)
+	}
+
+	if top, err := TopOf(context.Background(), f, "p-5"); err != nil || top != "P-1" {
+		t.Errorf("TopOf = %q, %v", top, err)
+	}
+	// A parent that cannot be fetched ends the climb.
+	initiative.Fields.Parent = &jira.IssueLink{Key: "SECRET-1"}
+	if top, err := TopOf(context.Background(), f, "P-5"); err != nil || top != "P-1" {
+		t.Errorf("TopOf past a hidden parent = %q, %v", top, err)
+	}
+}
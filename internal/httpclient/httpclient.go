@@ -8,8 +8,15 @@ import (
 
 const DefaultTimeout = 30 * time.Second
 
-// New returns an *http.Client with secure TLS defaults and the given timeout.
+// New returns an *http.Client with secure TLS defaults and a retrying
+// transport using DefaultRetryPolicy. timeout bounds each attempt; the
+// client-wide timeout also allows for the retries and their waits.
 func New(timeout time.Duration) *http.Client {
+	return NewWithPolicy(timeout, DefaultRetryPolicy)
+}
+
+// NewWithPolicy is like New with an explicit retry policy.
+func NewWithPolicy(timeout time.Duration, policy RetryPolicy) *http.Client {
 	tlsConfig := &tls.Config{
 		MinVersion: tls.VersionTLS12,
 		CipherSuites: []uint16{
@@ -21,10 +28,16 @@ func New(timeout time.Duration) *http.Client {
 		PreferServerCipherSuites: true,
 	}
 
+	overall := timeout
+	if policy.MaxRetries > 0 && timeout > 0 {
+		overall += time.Duration(policy.MaxRetries) * (timeout + policy.MaxWait)
+	}
+
 	return &http.Client{
-		Timeout: timeout,
-		Transport: &http.Transport{
-			TLSClientConfig: tlsConfig,
-		},
+		Timeout: overall,
+		Transport: NewRetryTransport(&http.Transport{
+			TLSClientConfig:       tlsConfig,
+			ResponseHeaderTimeout: timeout,
+		}, policy),
 	}
 }
@@ -0,0 +1,261 @@
+package httpclient
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RetryPolicy controls how RetryTransport retries failed requests.
+type RetryPolicy struct {
+	MaxRetries int           // retries after the first attempt; 0 disables retrying
+	BaseDelay  time.Duration // first backoff delay, doubled on each retry
+	MaxWait    time.Duration // longest single wait; a longer Retry-After is not honoured
+
+	// Notify, when set, is called before each retry.
+	Notify func(RetryEvent)
+}
+
+// RetryEvent describes a retry about to happen.
+type RetryEvent struct {
+	Method    string
+	URL       string
+	Attempt   int // 1-based retry number
+	Max       int
+	Wait      time.Duration
+	Status    int   // 0 when the attempt failed with a transport error
+	Err       error // transport error, if any
+	RateLimit RateLimit
+}
+
+// DefaultRetryPolicy is used by New. Commands may adjust it from config
+// before creating clients.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	BaseDelay:  500 * time.Millisecond,
+	MaxWait:    30 * time.Second,
+}
+
+// RateLimit holds the rate-limit headers of a response. Atlassian Cloud sends
+// X-RateLimit-Limit/-Remaining/-Reset and X-RateLimit-NearLimit; fields are
+// zero when a header is absent.
+type RateLimit struct {
+	Limit      int
+	Remaining  int
+	Reset      time.Time
+	NearLimit  bool
+	RetryAfter time.Duration
+	present    bool
+}
+
+// Present reports whether any rate-limit header was found.
+func (r RateLimit) Present() bool { return r.present }
+
+func (r RateLimit) String() string {
+	if !r.present {
+		return ""
+	}
+	s := ""
+	if r.Limit > 0 {
+		s = fmt.Sprintf("%d/%d requests remaining", r.Remaining, r.Limit)
+	}
+	if !r.Reset.IsZero() {
+		if s != "" {
+			s += ", "
+		}
+		s += "resets " + r.Reset.Local().Format("15:04:05")
+	}
+	if r.RetryAfter > 0 {
+		if s != "" {
+			s += ", "
+		}
+		s += "retry after " + r.RetryAfter.String()
+	}
+	return s
+}
+
+// ParseRateLimit extracts rate-limit information from response headers.
+func ParseRateLimit(h http.Header) RateLimit {
+	var r RateLimit
+	if v, err := strconv.Atoi(h.Get("X-RateLimit-Limit")); err == nil {
+		r.Limit, r.present = v, true
+	}
+	if v, err := strconv.Atoi(h.Get("X-RateLimit-Remaining")); err == nil {
+		r.Remaining, r.present = v, true
+	}
+	if v := h.Get("X-RateLimit-Reset"); v != "" {
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			r.Reset, r.present = t, true
+		}
+	}
+	if h.Get("X-RateLimit-NearLimit") == "true" {
+		r.NearLimit, r.present = true, true
+	}
+	if d, ok := parseRetryAfter(h.Get("Retry-After"), time.Now()); ok {
+		r.RetryAfter, r.present = d, true
+	}
+	return r
+}
+
+// parseRetryAfter parses a Retry-After value in seconds or as an HTTP date.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := t.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// RetryTransport retries requests that fail with 429, 502, 503 or 504, or
+// with a transport error. 429 responses are retried for any method since the
+// server did not process the request; other failures only for idempotent
+// methods. Retry-After is honoured up to MaxWait; otherwise the wait is a
+// jittered exponential backoff.
+type RetryTransport struct {
+	Base   http.RoundTripper
+	Policy RetryPolicy
+
+	mu   sync.Mutex
+	last RateLimit
+}
+
+// NewRetryTransport wraps base (http.DefaultTransport when nil).
+func NewRetryTransport(base http.RoundTripper, policy RetryPolicy) *RetryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &RetryTransport{Base: base, Policy: policy}
+}
+
+// LastRateLimit returns the rate-limit headers of the most recent response
+// that carried any.
+func (t *RetryTransport) LastRateLimit() RateLimit {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.last
+}
+
+// sleep is replaced in tests.
+var sleep = func(req *http.Request, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		resp, err := t.Base.RoundTrip(req)
+		var rl RateLimit
+		if resp != nil {
+			rl = ParseRateLimit(resp.Header)
+			if rl.Present() {
+				t.mu.Lock()
+				t.last = rl
+				t.mu.Unlock()
+			}
+		}
+
+		if attempt >= t.Policy.MaxRetries || !t.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt)
+		if rl.RetryAfter > 0 {
+			if t.Policy.MaxWait > 0 && rl.RetryAfter > t.Policy.MaxWait {
+				// The server asked for longer than we are willing to wait.
+				return resp, err
+			}
+			wait = rl.RetryAfter
+		}
+		if deadline, ok := req.Context().Deadline(); ok && time.Until(deadline) < wait {
+			return resp, err
+		}
+
+		if t.Policy.Notify != nil {
+			ev := RetryEvent{Method: req.Method, URL: req.URL.String(), Attempt: attempt + 1, Max: t.Policy.MaxRetries, Wait: wait, Err: err, RateLimit: rl}
+			if resp != nil {
+				ev.Status = resp.StatusCode
+			}
+			t.Policy.Notify(ev)
+		}
+		if resp != nil {
+			// Drain so the connection can be reused.
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+		if err := sleep(req, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (t *RetryTransport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false // body cannot be replayed
+	}
+	if err != nil {
+		if errors.Is(err, req.Context().Err()) && req.Context().Err() != nil {
+			return false
+		}
+		return isIdempotent(req.Method)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method)
+	}
+	return false
+}
+
+// backoff returns a jittered exponential delay for the given attempt: a
+// random duration in [d/2, d] where d = BaseDelay * 2^attempt, capped at
+// MaxWait.
+func (t *RetryTransport) backoff(attempt int) time.Duration {
+	base := t.Policy.BaseDelay
+	if base <= 0 {
+		base = 500 * time.Millisecond
+	}
+	d := base << attempt
+	if t.Policy.MaxWait > 0 && (d > t.Policy.MaxWait || d <= 0) {
+		d = t.Policy.MaxWait
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,169 @@
+package httpclient
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// recordSleeps replaces sleep for the duration of a test.
+func recordSleeps(t *testing.T) *[]time.Duration {
+	t.Helper()
+	var waits []time.Duration
+	orig := sleep
+	sleep = func(_ *http.Request, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	t.Cleanup(func() { sleep = orig })
+	return &waits
+}
+
+func testClient(policy RetryPolicy) *http.Client {
+	return &http.Client{Transport: NewRetryTransport(nil, policy)}
+}
+
+func TestRetryHonoursRetryAfter(t *testing.T) {
+	waits := recordSleeps(t)
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.Header().Set("X-RateLimit-Limit", "100")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	var events []RetryEvent
+	policy := RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxWait: 10 * time.Second, Notify: func(e RetryEvent) { events = append(events, e) }}
+	transport := NewRetryTransport(nil, policy)
+	resp, err := (&http.Client{Transport: transport}).Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != 200 || calls != 2 {
+		t.Fatalf("status %d after %d calls", resp.StatusCode, calls)
+	}
+	if len(*waits) != 1 || (*waits)[0] != 2*time.Second {
+		t.Errorf("waits = %v, want [2s]", *waits)
+	}
+	if len(events) != 1 || events[0].Status != 429 || events[0].RateLimit.Limit != 100 {
+		t.Errorf("events = %+v", events)
+	}
+	if rl := transport.LastRateLimit(); rl.Limit != 100 || rl.Remaining != 0 {
+		t.Errorf("LastRateLimit = %+v", rl)
+	}
+}
+
+func TestRetryAfterBeyondMaxWaitIsNotRetried(t *testing.T) {
+	waits := recordSleeps(t)
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	resp, err := testClient(RetryPolicy{MaxRetries: 3, MaxWait: 5 * time.Second}).Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 429 || calls != 1 || len(*waits) != 0 {
+		t.Errorf("status %d, calls %d, waits %v", resp.StatusCode, calls, *waits)
+	}
+}
+
+func TestRetryBackoffOnGatewayErrors(t *testing.T) {
+	waits := recordSleeps(t)
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	resp, err := testClient(RetryPolicy{MaxRetries: 3, BaseDelay: 100 * time.Millisecond, MaxWait: 250 * time.Millisecond}).Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 502 || calls != 4 {
+		t.Fatalf("status %d after %d calls, want 502 after 4", resp.StatusCode, calls)
+	}
+	// Backoff doubles from 100ms and is capped at MaxWait, with jitter in [d/2, d].
+	caps := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 250 * time.Millisecond}
+	if len(*waits) != len(caps) {
+		t.Fatalf("waits = %v", *waits)
+	}
+	for i, w := range *waits {
+		if w < caps[i]/2 || w > caps[i] {
+			t.Errorf("wait %d = %v, want within [%v, %v]", i, w, caps[i]/2, caps[i])
+		}
+	}
+}
+
+func TestNonIdempotentRequestsNotRetriedOnServerError(t *testing.T) {
+	recordSleeps(t)
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	resp, err := testClient(RetryPolicy{MaxRetries: 3}).Post(srv.URL, "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if calls != 1 {
+		t.Errorf("POST retried on 503: %d calls", calls)
+	}
+}
+
+func TestRetryReplaysBody(t *testing.T) {
+	recordSleeps(t)
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	resp, err := testClient(RetryPolicy{MaxRetries: 2}).Post(srv.URL, "application/json", strings.NewReader(`{"a":1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 201 || len(bodies) != 2 || bodies[1] != `{"a":1}` {
+		t.Errorf("status %d, bodies %q", resp.StatusCode, bodies)
+	}
+}
+
+func TestParseRetryAfterDate(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	d, ok := parseRetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now)
+	if !ok || d != 90*time.Second {
+		t.Errorf("got (%v, %v)", d, ok)
+	}
+	if _, ok := parseRetryAfter("soon", now); ok {
+		t.Error("invalid Retry-After accepted")
+	}
+}
@@ -0,0 +1,103 @@
+// Package issueset creates a parent issue and its children as one unit: if
+// any of them cannot be created, those already created are deleted again so
+// no half-made set is left behind.
+package issueset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"jet/internal/jira"
+)
+
+// Client is the part of *jira.Client a set needs.
+type Client interface {
+	CreateIssueWithFieldsContext(ctx context.Context, fields jira.CreateIssueFields) (*jira.Issue, error)
+	DeleteIssueContext(ctx context.Context, issueKey string) error
+}
+
+// Set is a parent issue and the children to create under it. The children's
+// Parent is set to the parent once it exists; an empty child Project is
+// taken from the parent.
+type Set struct {
+	Parent   jira.CreateIssueFields
+	Children []jira.CreateIssueFields
+}
+
+// Created holds the issues of a set that were created, in order.
+type Created struct {
+	Parent   *jira.Issue
+	Children []*jira.Issue
+}
+
+// Keys returns the keys of the created issues, parent first.
+func (c *Created) Keys() []string {
+	var keys []string
+	if c.Parent != nil {
+		keys = append(keys, c.Parent.Key)
+	}
+	for _, child := range c.Children {
+		keys = append(keys, child.Key)
+	}
+	return keys
+}
+
+// Create creates the parent of set and then each child. When one fails, the
+// issues created so far are rolled back and the error names the issue that
+// failed; if the rollback fails too, the error says which issues are left.
+func Create(ctx context.Context, c Client, set Set) (*Created, error) {
+	created := &Created{}
+	parent, err := c.CreateIssueWithFieldsContext(ctx, set.Parent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %q: %w", set.Parent.Summary, err)
+	}
+	created.Parent = parent
+
+	for _, fields := range set.Children {
+		fields.Parent = &jira.IssueRef{Key: parent.Key}
+		if fields.Project.Key == "" {
+			fields.Project = set.Parent.Project
+		}
+		child, err := c.CreateIssueWithFieldsContext(ctx, fields)
+		if err != nil {
+			err = fmt.Errorf("failed to create %q under %s: %w", fields.Summary, parent.Key, err)
+			return nil, Rollback(ctx, c, created, err)
+		}
+		created.Children = append(created.Children, child)
+	}
+	return created, nil
+}
+
+// Rollback deletes the issues in created, children first, after cause
+// stopped the set from being finished. It returns cause, extended with the
+// issues that could not be deleted.
+func Rollback(ctx context.Context, c Client, created *Created, cause error) error {
+	// Deleting may outlive a cancelled ctx; the issues should not.
+	ctx = context.WithoutCancel(ctx)
+
+	var left []string
+	var errs []error
+	for i := len(created.Children) - 1; i >= 0; i-- {
+		key := created.Children[i].Key
+		if err := c.DeleteIssueContext(ctx, key); err != nil {
+			left = append(left, key)
+			errs = append(errs, err)
+		}
+	}
+	if created.Parent != nil {
+		if err := c.DeleteIssueContext(ctx, created.Parent.Key); err != nil {
+			left = append(left, created.Parent.Key)
+			errs = append(errs, err)
+		}
+	}
+
+	if len(left) > 0 {
+		return fmt.Errorf("%w; rolling back failed, delete %s by hand: %w", cause, strings.Join(left, ", "), errors.Join(errs...))
+	}
+	if keys := created.Keys(); len(keys) > 0 {
+		return fmt.Errorf("%w (rolled back %s)", cause, strings.Join(keys, ", "))
+	}
+	return cause
+}
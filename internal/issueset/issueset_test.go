@@ -0,0 +1,82 @@
+package issueset
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"jet/internal/jira"
+)
+
+// fakeJira numbers created issues P-1, P-2, ... and fails on request.
+type fakeJira struct {
+	failCreate string          // summary whose creation fails
+	failDelete map[string]bool // keys that cannot be deleted
+
+	created []jira.CreateIssueFields
+	deleted []string
+}
+
+func (f *fakeJira) CreateIssueWithFieldsContext(ctx context.Context, fields jira.CreateIssueFields) (*jira.Issue, error) {
+	if fields.Summary == f.failCreate {
+		return nil, fmt.Errorf("summary is too long")
+	}
+	f.created = append(f.created, fields)
+	return &jira.Issue{Key: fmt.Sprintf("P-%d", len(f.created))}, nil
+}
+
+func (f *fakeJira) DeleteIssueContext(ctx context.Context, issueKey string) error {
+	if f.failDelete[issueKey] {
+		return fmt.Errorf("%s is protected", issueKey)
+	}
+	f.deleted = append(f.deleted, issueKey)
+	return nil
+}
+
+func newSet(children ...string) Set {
+	set := Set{Parent: jira.CreateIssueFields{Project: jira.ProjectRef{Key: "P"}, Summary: "Parent"}}
+	for _, s := range children {
+		set.Children = append(set.Children, jira.CreateIssueFields{Summary: s})
+	}
+	return set
+}
+
+func TestCreate(t *testing.T) {
+	f := &fakeJira{}
+	created, err := Create(context.Background(), f, newSet("One", "Two"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := created.Keys(); !reflect.DeepEqual(got, []string{"P-1", "P-2", "P-3"}) {
+		t.Errorf("keys = %v", got)
+	}
+	child := f.created[2]
+	if child.Parent == nil || child.Parent.Key != "P-1" || child.Project.Key != "P" {
+		t.Errorf("child created with parent %v, project %q", child.Parent, child.Project.Key)
+	}
+}
+
+func TestCreateRollsBack(t *testing.T) {
+	f := &fakeJira{failCreate: "Three"}
+	_, err := Create(context.Background(), f, newSet("One", "Two", "Three"))
+	want := `failed to create "Three" under P-1: summary is too long (rolled back P-1, P-2, P-3)`
+	if err == nil || err.Error() != want {
+		t.Errorf("err = %v, want %s", err, want)
+	}
+	if !reflect.DeepEqual(f.deleted, []string{"P-3", "P-2", "P-1"}) {
+		t.Errorf("deleted %v, want children first", f.deleted)
+	}
+
+	f = &fakeJira{failCreate: "Two", failDelete: map[string]bool{"P-1": true}}
+	_, err = Create(context.Background(), f, newSet("One", "Two"))
+	want = `failed to create "Two" under P-1: summary is too long; rolling back failed, delete P-1 by hand: P-1 is protected`
+	if err == nil || err.Error() != want {
+		t.Errorf("err = %v, want %s", err, want)
+	}
+
+	f = &fakeJira{failCreate: "Parent"}
+	if _, err := Create(context.Background(), f, newSet("One")); err == nil || len(f.deleted) != 0 {
+		t.Errorf("parent failure: err %v, deleted %v", err, f.deleted)
+	}
+}
@@ -0,0 +1,687 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ADFNode is a node in an Atlassian Document Format tree. The same type is
+// used for block nodes (paragraph, table, ...) and inline nodes (text,
+// mention, ...); only the fields relevant to a node's type are populated.
+type ADFNode struct {
+	Type    string                 `json:"type"`
+	Version int                    `json:"version,omitempty"`
+	Text    string                 `json:"text,omitempty"`
+	Attrs   map[string]interface{} `json:"attrs,omitempty"`
+	Marks   []ADFMark              `json:"marks,omitempty"`
+	Content []ADFNode              `json:"content,omitempty"`
+}
+
+// ADFMark is a formatting mark applied to a text node (strong, link, ...).
+type ADFMark struct {
+	Type  string                 `json:"type"`
+	Attrs map[string]interface{} `json:"attrs,omitempty"`
+}
+
+// IsADF reports whether raw holds an ADF document rather than a plain string.
+func IsADF(raw json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(raw)
+	return len(trimmed) > 0 && trimmed[0] == '{'
+}
+
+// ParseADF decodes an ADF document.
+func ParseADF(raw json.RawMessage) (*ADFNode, error) {
+	var doc ADFNode
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode ADF: %w", err)
+	}
+	return &doc, nil
+}
+
+// ADFToMarkdown renders raw ADF as Markdown. Returns "" if raw is not ADF.
+func ADFToMarkdown(raw json.RawMessage) string {
+	doc, err := ParseADF(raw)
+	if err != nil {
+		return ""
+	}
+	return RenderADFMarkdown(doc)
+}
+
+// ADFToText renders raw ADF as plain text suitable for terminals.
+// Returns "" if raw is not ADF.
+func ADFToText(raw json.RawMessage) string {
+	doc, err := ParseADF(raw)
+	if err != nil {
+		return ""
+	}
+	return RenderADFText(doc)
+}
+
+// RenderADFMarkdown renders an ADF tree as Markdown.
+func RenderADFMarkdown(doc *ADFNode) string {
+	r := adfRenderer{plain: false}
+	return trimBlock(r.block(*doc))
+}
+
+// RenderADFText renders an ADF tree as plain text without markup.
+func RenderADFText(doc *ADFNode) string {
+	r := adfRenderer{plain: true}
+	return trimBlock(r.block(*doc))
+}
+
+// trimBlock strips surrounding blank lines but keeps the leading indentation
+// of the first line (an indented code block may start the document).
+func trimBlock(s string) string {
+	return strings.TrimRight(strings.TrimLeft(s, "\n"), " \t\n")
+}
+
+// adfRenderer walks an ADF tree. Block nodes render to a string that may span
+// several lines; the caller is responsible for separating sibling blocks.
+type adfRenderer struct {
+	plain bool
+}
+
+// blocks renders a sequence of block nodes separated by blank lines.
+func (r adfRenderer) blocks(nodes []ADFNode) string {
+	return r.joinBlocks(nodes, "\n\n")
+}
+
+func (r adfRenderer) joinBlocks(nodes []ADFNode, sep string) string {
+	var parts []string
+	for _, n := range nodes {
+		if s := r.block(n); strings.TrimSpace(s) != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+func (r adfRenderer) block(n ADFNode) string {
+	switch n.Type {
+	case "doc", "layoutSection", "layoutColumn", "multiBodiedExtension", "extensionFrame":
+		return r.blocks(n.Content)
+
+	case "paragraph":
+		return r.inlines(n.Content)
+
+	case "heading":
+		text := r.inlines(n.Content)
+		if r.plain {
+			return text
+		}
+		level := attrInt(n.Attrs, "level", 1)
+		if level < 1 || level > 6 {
+			level = 1
+		}
+		return strings.Repeat("#", level) + " " + text
+
+	case "bulletList":
+		return r.list(n.Content, func(int) string {
+			if r.plain {
+				return "• "
+			}
+			return "- "
+		})
+
+	case "orderedList":
+		start := attrInt(n.Attrs, "order", 1)
+		return r.list(n.Content, func(i int) string {
+			return strconv.Itoa(start+i) + ". "
+		})
+
+	case "taskList":
+		return r.taskList(n)
+
+	case "decisionList":
+		return r.list(n.Content, func(int) string {
+			if r.plain {
+				return "◆ "
+			}
+			return "- "
+		})
+
+	case "listItem", "taskItem", "decisionItem":
+		return r.listItemBody(n)
+
+	case "codeBlock":
+		code := strings.TrimRight(plainText(n.Content), "\n")
+		if r.plain {
+			return indentLines(code, "    ")
+		}
+		lang := attrString(n.Attrs, "language")
+		fence := "```"
+		for strings.Contains(code, fence) {
+			fence += "`"
+		}
+		return fence + lang + "\n" + code + "\n" + fence
+
+	case "blockquote":
+		return prefixLines(r.blocks(n.Content), "> ")
+
+	case "panel":
+		label := panelLabel(attrString(n.Attrs, "panelType"))
+		body := r.blocks(n.Content)
+		if r.plain {
+			return prefixLines(label+": "+body, "│ ")
+		}
+		return prefixLines("**"+label+":** "+body, "> ")
+
+	case "rule":
+		if r.plain {
+			return strings.Repeat("─", 40)
+		}
+		return "---"
+
+	case "expand", "nestedExpand":
+		title := attrString(n.Attrs, "title")
+		body := r.blocks(n.Content)
+		if r.plain {
+			return "▸ " + title + "\n" + indentLines(body, "  ")
+		}
+		return "**▸ " + escapeMarkdown(title) + "**\n\n" + body
+
+	case "table":
+		return r.table(n)
+
+	case "mediaSingle", "mediaGroup":
+		var parts []string
+		for _, c := range n.Content {
+			parts = append(parts, r.media(c))
+		}
+		return strings.Join(parts, "\n")
+
+	case "media":
+		return r.media(n)
+
+	case "blockCard", "embedCard":
+		return r.card(n)
+
+	case "extension", "bodiedExtension":
+		key := attrString(n.Attrs, "extensionKey")
+		if len(n.Content) > 0 {
+			return r.blocks(n.Content)
+		}
+		return "[extension: " + key + "]"
+
+	case "placeholder":
+		return ""
+	}
+
+	// Unknown node: render its text or its children so no content is lost.
+	if n.Text != "" {
+		return r.text(n)
+	}
+	if len(n.Content) > 0 {
+		if isInlineContent(n.Content) {
+			return r.inlines(n.Content)
+		}
+		return r.blocks(n.Content)
+	}
+	return ""
+}
+
+// list renders listItem/decisionItem children with the marker returned by
+// marker(i). Continuation lines are indented to align with the item text.
+func (r adfRenderer) list(items []ADFNode, marker func(int) string) string {
+	var lines []string
+	for i, item := range items {
+		m := marker(i)
+		body := r.block(item)
+		lines = append(lines, m+indentContinuation(body, strings.Repeat(" ", utf8.RuneCountInString(m))))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (r adfRenderer) taskList(n ADFNode) string {
+	var lines []string
+	for _, item := range n.Content {
+		if item.Type == "taskList" {
+			// Nested task lists appear as direct children of their parent list.
+			lines = append(lines, indentLines(r.taskList(item), "  "))
+			continue
+		}
+		done := attrString(item.Attrs, "state") == "DONE"
+		var m string
+		switch {
+		case r.plain && done:
+			m = "☑ "
+		case r.plain:
+			m = "☐ "
+		case done:
+			m = "- [x] "
+		default:
+			m = "- [ ] "
+		}
+		body := r.block(item)
+		lines = append(lines, m+indentContinuation(body, strings.Repeat(" ", utf8.RuneCountInString(m))))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// listItemBody renders the content of a list item. Items may hold inline
+// nodes directly (taskItem, decisionItem) or block nodes (listItem).
+func (r adfRenderer) listItemBody(n ADFNode) string {
+	if isInlineContent(n.Content) {
+		return r.inlines(n.Content)
+	}
+	return r.joinBlocks(n.Content, "\n")
+}
+
+func (r adfRenderer) table(n ADFNode) string {
+	var rows [][]string
+	headerRow := false
+	for i, row := range n.Content {
+		var cells []string
+		allHeaders := len(row.Content) > 0
+		for _, cell := range row.Content {
+			if cell.Type != "tableHeader" {
+				allHeaders = false
+			}
+			sep := " "
+			if !r.plain {
+				sep = "<br>"
+			}
+			text := strings.ReplaceAll(r.joinBlocks(cell.Content, "\n"), "\n", sep)
+			if !r.plain {
+				text = strings.ReplaceAll(text, "|", `\|`)
+			}
+			cells = append(cells, text)
+			// Repeat merged cells so columns stay aligned.
+			for span := attrInt(cell.Attrs, "colspan", 1); span > 1; span-- {
+				cells = append(cells, "")
+			}
+		}
+		if i == 0 {
+			headerRow = allHeaders
+		}
+		rows = append(rows, cells)
+	}
+	if len(rows) == 0 {
+		return ""
+	}
+
+	cols := 0
+	for _, row := range rows {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+	for i := range rows {
+		for len(rows[i]) < cols {
+			rows[i] = append(rows[i], "")
+		}
+	}
+
+	if r.plain {
+		return plainTable(rows, headerRow)
+	}
+
+	// Markdown tables always need a header row; synthesize an empty one when
+	// the ADF table has none so the first data row isn't promoted.
+	var b strings.Builder
+	body := rows
+	if headerRow {
+		b.WriteString(tableLine(rows[0]))
+		body = rows[1:]
+	} else {
+		b.WriteString(tableLine(make([]string, cols)))
+	}
+	b.WriteString("\n")
+	seps := make([]string, cols)
+	for i := range seps {
+		seps[i] = "---"
+	}
+	b.WriteString(tableLine(seps))
+	for _, row := range body {
+		b.WriteString("\n")
+		b.WriteString(tableLine(row))
+	}
+	return b.String()
+}
+
+func tableLine(cells []string) string {
+	return "| " + strings.Join(cells, " | ") + " |"
+}
+
+func plainTable(rows [][]string, headerRow bool) string {
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, c := range row {
+			if w := utf8.RuneCountInString(c); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	var lines []string
+	for ri, row := range rows {
+		padded := make([]string, len(row))
+		for i, c := range row {
+			padded[i] = c + strings.Repeat(" ", widths[i]-utf8.RuneCountInString(c))
+		}
+		lines = append(lines, strings.TrimRight(strings.Join(padded, " │ "), " "))
+		if ri == 0 && headerRow {
+			seps := make([]string, len(widths))
+			for i, w := range widths {
+				seps[i] = strings.Repeat("─", w)
+			}
+			lines = append(lines, strings.Join(seps, "─┼─"))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (r adfRenderer) media(n ADFNode) string {
+	name := attrString(n.Attrs, "alt")
+	if name == "" {
+		name = attrString(n.Attrs, "id")
+	}
+	url := attrString(n.Attrs, "url")
+	if r.plain {
+		if url != "" {
+			return "[image: " + url + "]"
+		}
+		return "[attachment: " + name + "]"
+	}
+	if url != "" {
+		return "![" + escapeMarkdown(name) + "](" + url + ")"
+	}
+	return "[attachment: " + escapeMarkdown(name) + "]"
+}
+
+func (r adfRenderer) card(n ADFNode) string {
+	url := attrString(n.Attrs, "url")
+	if url == "" {
+		return ""
+	}
+	if r.plain {
+		return url
+	}
+	return "<" + url + ">"
+}
+
+// inlines renders a run of inline nodes.
+func (r adfRenderer) inlines(nodes []ADFNode) string {
+	var b strings.Builder
+	for _, n := range nodes {
+		b.WriteString(r.inline(n))
+	}
+	return b.String()
+}
+
+func (r adfRenderer) inline(n ADFNode) string {
+	switch n.Type {
+	case "text":
+		return r.text(n)
+
+	case "hardBreak":
+		if r.plain {
+			return "\n"
+		}
+		return "\\\n"
+
+	case "mention":
+		text := attrString(n.Attrs, "text")
+		if text == "" {
+			text = attrString(n.Attrs, "id")
+		}
+		if !strings.HasPrefix(text, "@") {
+			text = "@" + text
+		}
+		return text
+
+	case "emoji":
+		if text := attrString(n.Attrs, "text"); text != "" {
+			return text
+		}
+		return attrString(n.Attrs, "shortName")
+
+	case "date":
+		return formatADFDate(attrString(n.Attrs, "timestamp"))
+
+	case "status":
+		text := strings.ToUpper(attrString(n.Attrs, "text"))
+		if r.plain {
+			return "[" + text + "]"
+		}
+		return "`" + text + "`"
+
+	case "inlineCard":
+		return r.card(n)
+
+	case "mediaInline":
+		return r.media(n)
+
+	case "inlineExtension":
+		return "[extension: " + attrString(n.Attrs, "extensionKey") + "]"
+
+	case "placeholder":
+		return ""
+	}
+
+	if n.Text != "" {
+		return r.text(n)
+	}
+	return r.inlines(n.Content)
+}
+
+// text renders a text node with its marks applied.
+func (r adfRenderer) text(n ADFNode) string {
+	if r.plain {
+		text := n.Text
+		for _, m := range n.Marks {
+			switch m.Type {
+			case "link":
+				href := attrString(m.Attrs, "href")
+				if href != "" && href != text {
+					text += " (" + href + ")"
+				}
+			case "subsup":
+				if attrString(m.Attrs, "type") == "sup" {
+					text = "^" + text
+				} else {
+					text = "_" + text
+				}
+			}
+		}
+		return text
+	}
+
+	// Markdown emphasis can't start or end with whitespace, so keep leading
+	// and trailing spaces outside the markers.
+	core := strings.TrimSpace(n.Text)
+	if core == "" {
+		return n.Text
+	}
+	lead := n.Text[:strings.Index(n.Text, core)]
+	trail := n.Text[len(lead)+len(core):]
+
+	isCode := false
+	for _, m := range n.Marks {
+		if m.Type == "code" {
+			isCode = true
+		}
+	}
+	if isCode {
+		fence := "`"
+		for strings.Contains(core, fence) {
+			fence += "`"
+		}
+		if strings.HasPrefix(core, "`") || strings.HasSuffix(core, "`") {
+			core = " " + core + " "
+		}
+		core = fence + core + fence
+	} else {
+		core = escapeMarkdown(core)
+	}
+
+	var href string
+	for _, m := range n.Marks {
+		switch m.Type {
+		case "strong":
+			core = "**" + core + "**"
+		case "em":
+			core = "*" + core + "*"
+		case "strike":
+			core = "~~" + core + "~~"
+		case "underline":
+			core = "<u>" + core + "</u>"
+		case "subsup":
+			if attrString(m.Attrs, "type") == "sup" {
+				core = "<sup>" + core + "</sup>"
+			} else {
+				core = "<sub>" + core + "</sub>"
+			}
+		case "link":
+			href = attrString(m.Attrs, "href")
+		}
+		// textColor, backgroundColor, alignment, indentation, breakout,
+		// annotation, border, dataConsumer and fragment are presentational
+		// or editor metadata with no Markdown equivalent.
+	}
+	if href != "" {
+		core = "[" + core + "](" + href + ")"
+	}
+	return lead + core + trail
+}
+
+// plainText concatenates the raw text of inline nodes (used for code blocks).
+func plainText(nodes []ADFNode) string {
+	var b strings.Builder
+	for _, n := range nodes {
+		if n.Type == "hardBreak" {
+			b.WriteString("\n")
+			continue
+		}
+		b.WriteString(n.Text)
+		b.WriteString(plainText(n.Content))
+	}
+	return b.String()
+}
+
+func isInlineContent(nodes []ADFNode) bool {
+	for _, n := range nodes {
+		switch n.Type {
+		case "text", "hardBreak", "mention", "emoji", "date", "status", "inlineCard", "mediaInline", "inlineExtension", "placeholder":
+		default:
+			return false
+		}
+	}
+	return len(nodes) > 0
+}
+
+func panelLabel(panelType string) string {
+	switch panelType {
+	case "note":
+		return "Note"
+	case "warning":
+		return "Warning"
+	case "error":
+		return "Error"
+	case "success":
+		return "Success"
+	case "tip":
+		return "Tip"
+	case "custom":
+		return "Panel"
+	default:
+		return "Info"
+	}
+}
+
+// formatADFDate converts an ADF date timestamp (milliseconds since epoch, as
+// a string) into YYYY-MM-DD.
+func formatADFDate(ts string) string {
+	ms, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return ts
+	}
+	return time.UnixMilli(ms).UTC().Format("2006-01-02")
+}
+
+// escapeMarkdown escapes characters that would otherwise be read as Markdown
+// syntax. Underscores are left alone inside words (snake_case identifiers
+// are common in tickets and don't trigger emphasis there).
+func escapeMarkdown(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, c := range runes {
+		switch c {
+		case '\\', '`', '*', '[', ']':
+			b.WriteRune('\\')
+		case '_':
+			prevWord := i > 0 && isWordRune(runes[i-1])
+			nextWord := i+1 < len(runes) && isWordRune(runes[i+1])
+			if !prevWord || !nextWord {
+				b.WriteRune('\\')
+			}
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r > 127
+}
+
+// indentContinuation indents every line after the first.
+func indentContinuation(s, indent string) string {
+	lines := strings.Split(s, "\n")
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" {
+			lines[i] = indent + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func indentLines(s, indent string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		if l != "" {
+			lines[i] = indent + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// prefixLines prefixes every line (including blank ones) with prefix.
+func prefixLines(s, prefix string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(prefix+l, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func attrString(attrs map[string]interface{}, key string) string {
+	switch v := attrs[key].(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func attrInt(attrs map[string]interface{}, key string, def int) int {
+	switch v := attrs[key].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	case string:
+		if n, err := strconv.Atoi(v); err == nil {
+			return n
+		}
+	}
+	return def
+}
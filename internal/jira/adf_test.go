@@ -0,0 +1,87 @@
+package jira
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata/")
+
+// TestADFGolden renders every testdata/adf/*.json document to Markdown and
+// plain text and compares against the .md / .txt golden files next to it.
+// Run `go test ./internal/jira -update` to regenerate them.
+func TestADFGolden(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "adf", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inputs) == 0 {
+		t.Fatal("no ADF fixtures found")
+	}
+	for _, in := range inputs {
+		raw, err := os.ReadFile(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		base := strings.TrimSuffix(in, ".json")
+		checkGolden(t, base+".md", ADFToMarkdown(raw))
+		checkGolden(t, base+".txt", ADFToText(raw))
+	}
+}
+
+func checkGolden(t *testing.T, path, got string) {
+	t.Helper()
+	got += "\n"
+	if *updateGolden {
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("missing golden file %s (run with -update): %v", path, err)
+	}
+	if got != string(want) {
+		t.Errorf("%s mismatch\n--- got ---\n%s\n--- want ---\n%s", path, got, want)
+	}
+}
+
+func TestParseDescriptionAcceptsStringOrADF(t *testing.T) {
+	if got := parseDescription(json.RawMessage(`"h1. Title"`)); got != "h1. Title" {
+		t.Errorf("string description: got %q", got)
+	}
+	adf := json.RawMessage(`{"type":"doc","version":1,"content":[{"type":"heading","attrs":{"level":1},"content":[{"type":"text","text":"Title"}]}]}`)
+	if got := parseDescription(adf); got != "# Title" {
+		t.Errorf("ADF description: got %q", got)
+	}
+	if got := parseDescription(nil); got != "" {
+		t.Errorf("empty description: got %q", got)
+	}
+}
+
+func TestCommentUnmarshalADFBody(t *testing.T) {
+	var c Comment
+	data := `{"id":"1","body":{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"done","marks":[{"type":"strong"}]}]}]}}`
+	if err := json.Unmarshal([]byte(data), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Body != "**done**" {
+		t.Errorf("Body = %q, want markdown", c.Body)
+	}
+	if c.PlainBody() != "done" {
+		t.Errorf("PlainBody = %q, want plain text", c.PlainBody())
+	}
+
+	var v2 Comment
+	if err := json.Unmarshal([]byte(`{"id":"2","body":"plain *wiki*"}`), &v2); err != nil {
+		t.Fatal(err)
+	}
+	if v2.Body != "plain *wiki*" || len(v2.BodyADF) != 0 {
+		t.Errorf("v2 body mangled: %+v", v2)
+	}
+}
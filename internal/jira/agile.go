@@ -0,0 +1,477 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Board is a Scrum or Kanban board of the Agile API.
+type Board struct {
+	ID       int           `json:"id"`
+	Name     string        `json:"name"`
+	Type     string        `json:"type"` // "scrum", "kanban" or "simple"
+	Location BoardLocation `json:"location"`
+}
+
+// BoardLocation is the project a board belongs to.
+type BoardLocation struct {
+	ProjectKey  string `json:"projectKey,omitempty"`
+	ProjectName string `json:"projectName,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// BoardFilter narrows GetBoards. Empty fields match every board.
+type BoardFilter struct {
+	ProjectKey string
+	Name       string // substring match, done by Jira
+	Type       string
+}
+
+// Sprint states.
+const (
+	SprintActive = "active"
+	SprintFuture = "future"
+	SprintClosed = "closed"
+)
+
+// Sprint is a sprint of a Scrum board.
+type Sprint struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	State         string `json:"state"`
+	Goal          string `json:"goal,omitempty"`
+	StartDate     string `json:"startDate,omitempty"`
+	EndDate       string `json:"endDate,omitempty"`
+	CompleteDate  string `json:"completeDate,omitempty"`
+	OriginBoardID int    `json:"originBoardId,omitempty"`
+}
+
+// Start returns the sprint's start, or the zero time before it is planned.
+func (s Sprint) Start() time.Time { return parseAgileTime(s.StartDate) }
+
+// End returns the sprint's planned end, or the zero time.
+func (s Sprint) End() time.Time { return parseAgileTime(s.EndDate) }
+
+// The Agile API returns ISO 8601 with a Z or numeric offset, unlike the
+// platform API's jiraTimeLayout.
+func parseAgileTime(s string) time.Time {
+	if s == "" {
+		return time.Time{}
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t
+	}
+	t, _ := ParseTime(s)
+	return t
+}
+
+// agilePage is the envelope of the Agile API's paged lists.
+type agilePage struct {
+	StartAt    int             `json:"startAt"`
+	MaxResults int             `json:"maxResults"`
+	IsLast     bool            `json:"isLast"`
+	Values     json.RawMessage `json:"values"`
+}
+
+// getAgileValues walks a paged Agile list endpoint, decoding each page's
+// values with add. add returns how many values the page held.
+func (c *Client) getAgileValues(ctx context.Context, endpoint string, params url.Values, resource string, add func(json.RawMessage) (int, error)) error {
+	startAt := 0
+	for {
+		params.Set("startAt", strconv.Itoa(startAt))
+		params.Set("maxResults", "50")
+		resp, err := c.makeRequest(ctx, "GET", endpoint+"?"+params.Encode(), nil)
+		if err != nil {
+			return err
+		}
+		var page agilePage
+		err = checkResponse(resp, 200, resource)
+		if err == nil {
+			if derr := json.NewDecoder(resp.Body).Decode(&page); derr != nil {
+				err = fmt.Errorf("failed to decode response: %w", derr)
+			}
+		}
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		n, err := add(page.Values)
+		if err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+		startAt += n
+		if page.IsLast || n == 0 {
+			return nil
+		}
+	}
+}
+
+// GetBoards lists the boards visible to the user.
+func (c *Client) GetBoards(filter BoardFilter) ([]Board, error) {
+	return c.GetBoardsContext(context.Background(), filter)
+}
+
+// GetBoardsContext is like GetBoards but carries ctx for cancellation.
+func (c *Client) GetBoardsContext(ctx context.Context, filter BoardFilter) ([]Board, error) {
+	params := url.Values{}
+	if filter.ProjectKey != "" {
+		params.Set("projectKeyOrId", filter.ProjectKey)
+	}
+	if filter.Name != "" {
+		params.Set("name", filter.Name)
+	}
+	if filter.Type != "" {
+		params.Set("type", filter.Type)
+	}
+	var boards []Board
+	err := c.getAgileValues(ctx, "/rest/agile/1.0/board", params, "boards", func(raw json.RawMessage) (int, error) {
+		var page []Board
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return 0, err
+		}
+		boards = append(boards, page...)
+		return len(page), nil
+	})
+	return boards, err
+}
+
+// GetBoard returns one board.
+func (c *Client) GetBoard(boardID int) (*Board, error) {
+	return c.GetBoardContext(context.Background(), boardID)
+}
+
+// GetBoardContext is like GetBoard but carries ctx for cancellation.
+func (c *Client) GetBoardContext(ctx context.Context, boardID int) (*Board, error) {
+	var board Board
+	if err := c.getAgile(ctx, fmt.Sprintf("/rest/agile/1.0/board/%d", boardID), fmt.Sprintf("board %d", boardID), &board); err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
+
+// ResolveBoard finds a board by ID or by name. Names match exactly, ignoring
+// case, or else as the only board whose name contains them.
+func (c *Client) ResolveBoard(ref string) (*Board, error) {
+	return c.ResolveBoardContext(context.Background(), ref)
+}
+
+// ResolveBoardContext is like ResolveBoard but carries ctx for cancellation.
+func (c *Client) ResolveBoardContext(ctx context.Context, ref string) (*Board, error) {
+	if id, err := strconv.Atoi(ref); err == nil {
+		board, err := c.GetBoardContext(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch board: %w", err)
+		}
+		return board, nil
+	}
+
+	boards, err := c.GetBoardsContext(ctx, BoardFilter{Name: ref})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch boards: %w", err)
+	}
+	for i := range boards {
+		if strings.EqualFold(boards[i].Name, ref) {
+			return &boards[i], nil
+		}
+	}
+	switch len(boards) {
+	case 0:
+		return nil, fmt.Errorf("no board matches %q", ref)
+	case 1:
+		return &boards[0], nil
+	}
+	names := make([]string, len(boards))
+	for i, b := range boards {
+		names[i] = fmt.Sprintf("%s (%d)", b.Name, b.ID)
+	}
+	return nil, fmt.Errorf("board %q is ambiguous: %s", ref, strings.Join(names, ", "))
+}
+
+// BoardColumn is a column of a board's configuration. Statuses holds the
+// IDs of the statuses mapped to it; Max is its WIP limit, 0 when unset.
+type BoardColumn struct {
+	Name     string   `json:"name"`
+	Statuses []string `json:"statuses"`
+	Min      int      `json:"min,omitempty"`
+	Max      int      `json:"max,omitempty"`
+}
+
+// GetBoardColumns returns a board's columns in display order.
+func (c *Client) GetBoardColumns(boardID int) ([]BoardColumn, error) {
+	return c.GetBoardColumnsContext(context.Background(), boardID)
+}
+
+// GetBoardColumnsContext is like GetBoardColumns but carries ctx for cancellation.
+func (c *Client) GetBoardColumnsContext(ctx context.Context, boardID int) ([]BoardColumn, error) {
+	var config struct {
+		ColumnConfig struct {
+			Columns []struct {
+				Name     string `json:"name"`
+				Statuses []struct {
+					ID string `json:"id"`
+				} `json:"statuses"`
+				Min int `json:"min"`
+				Max int `json:"max"`
+			} `json:"columns"`
+		} `json:"columnConfig"`
+	}
+	if err := c.getAgile(ctx, fmt.Sprintf("/rest/agile/1.0/board/%d/configuration", boardID), fmt.Sprintf("configuration of board %d", boardID), &config); err != nil {
+		return nil, err
+	}
+	columns := make([]BoardColumn, 0, len(config.ColumnConfig.Columns))
+	for _, col := range config.ColumnConfig.Columns {
+		bc := BoardColumn{Name: col.Name, Min: col.Min, Max: col.Max}
+		for _, st := range col.Statuses {
+			bc.Statuses = append(bc.Statuses, st.ID)
+		}
+		columns = append(columns, bc)
+	}
+	return columns, nil
+}
+
+// GetBoardIssues returns the issues on a board, optionally narrowed by jql,
+// in board rank order.
+func (c *Client) GetBoardIssues(boardID int, jql string) ([]Issue, error) {
+	return c.GetBoardIssuesContext(context.Background(), boardID, jql)
+}
+
+// GetBoardIssuesContext is like GetBoardIssues but carries ctx for cancellation.
+func (c *Client) GetBoardIssuesContext(ctx context.Context, boardID int, jql string) ([]Issue, error) {
+	params := url.Values{}
+	if jql != "" {
+		params.Set("jql", jql)
+	}
+	return c.getAgileIssues(ctx, fmt.Sprintf("/rest/agile/1.0/board/%d/issue", boardID), params, fmt.Sprintf("issues of board %d", boardID))
+}
+
+// GetSprints lists a board's sprints in the given states (all when none),
+// in the board's order: closed, then active, then future.
+func (c *Client) GetSprints(boardID int, states ...string) ([]Sprint, error) {
+	return c.GetSprintsContext(context.Background(), boardID, states...)
+}
+
+// GetSprintsContext is like GetSprints but carries ctx for cancellation.
+func (c *Client) GetSprintsContext(ctx context.Context, boardID int, states ...string) ([]Sprint, error) {
+	params := url.Values{}
+	if len(states) > 0 {
+		params.Set("state", strings.Join(states, ","))
+	}
+	var sprints []Sprint
+	err := c.getAgileValues(ctx, fmt.Sprintf("/rest/agile/1.0/board/%d/sprint", boardID), params, fmt.Sprintf("sprints of board %d", boardID), func(raw json.RawMessage) (int, error) {
+		var page []Sprint
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return 0, err
+		}
+		sprints = append(sprints, page...)
+		return len(page), nil
+	})
+	return sprints, err
+}
+
+// GetSprint returns one sprint.
+func (c *Client) GetSprint(sprintID int) (*Sprint, error) {
+	return c.GetSprintContext(context.Background(), sprintID)
+}
+
+// GetSprintContext is like GetSprint but carries ctx for cancellation.
+func (c *Client) GetSprintContext(ctx context.Context, sprintID int) (*Sprint, error) {
+	var sprint Sprint
+	if err := c.getAgile(ctx, fmt.Sprintf("/rest/agile/1.0/sprint/%d", sprintID), fmt.Sprintf("sprint %d", sprintID), &sprint); err != nil {
+		return nil, err
+	}
+	return &sprint, nil
+}
+
+// GetSprintIssues returns every issue in a sprint, with story points when
+// the site has a story points field.
+func (c *Client) GetSprintIssues(sprintID int) ([]Issue, error) {
+	return c.GetSprintIssuesContext(context.Background(), sprintID)
+}
+
+// GetSprintIssuesContext is like GetSprintIssues but carries ctx for cancellation.
+func (c *Client) GetSprintIssuesContext(ctx context.Context, sprintID int) ([]Issue, error) {
+	return c.getAgileIssues(ctx, fmt.Sprintf("/rest/agile/1.0/sprint/%d/issue", sprintID), url.Values{}, fmt.Sprintf("sprint %d", sprintID))
+}
+
+// GetBoardBacklog returns the issues in a board's backlog, i.e. not in any
+// active or future sprint, in backlog order.
+func (c *Client) GetBoardBacklog(boardID int) ([]Issue, error) {
+	return c.GetBoardBacklogContext(context.Background(), boardID)
+}
+
+// GetBoardBacklogContext is like GetBoardBacklog but carries ctx for cancellation.
+func (c *Client) GetBoardBacklogContext(ctx context.Context, boardID int) ([]Issue, error) {
+	return c.getAgileIssues(ctx, fmt.Sprintf("/rest/agile/1.0/board/%d/backlog", boardID), url.Values{}, fmt.Sprintf("backlog of board %d", boardID))
+}
+
+// getAgileIssues pages through an Agile issue list, which unlike the other
+// Agile lists reports a total instead of isLast.
+func (c *Client) getAgileIssues(ctx context.Context, endpoint string, params url.Values, resource string) ([]Issue, error) {
+	fields := c.withEpicLink(ctx, searchFields)
+	pointsID := c.storyPointsField(ctx)
+	if pointsID != "" {
+		fields += "," + pointsID
+	}
+
+	var issues []Issue
+	for startAt := 0; ; {
+		params.Set("startAt", strconv.Itoa(startAt))
+		params.Set("maxResults", "100")
+		params.Set("fields", fields)
+
+		resp, err := c.makeRequest(ctx, "GET", endpoint+"?"+params.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		var page SearchResponse
+		err = checkResponse(resp, 200, resource)
+		if err == nil {
+			if derr := json.NewDecoder(resp.Body).Decode(&page); derr != nil {
+				err = fmt.Errorf("failed to decode response: %w", derr)
+			}
+		}
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		issues = append(issues, page.Issues...)
+		startAt += len(page.Issues)
+		if len(page.Issues) == 0 || startAt >= page.Total {
+			break
+		}
+	}
+	c.resolveEpicLinks(ctx, issues)
+	resolveStoryPoints(issues, pointsID)
+	return issues, nil
+}
+
+// maxAgileMove is how many issues the Agile API moves per request.
+const maxAgileMove = 50
+
+// MoveIssuesToSprint moves issues into a sprint, taking them out of any
+// other active or future sprint.
+func (c *Client) MoveIssuesToSprint(sprintID int, issueKeys []string) error {
+	return c.MoveIssuesToSprintContext(context.Background(), sprintID, issueKeys)
+}
+
+// MoveIssuesToSprintContext is like MoveIssuesToSprint but carries ctx for cancellation.
+func (c *Client) MoveIssuesToSprintContext(ctx context.Context, sprintID int, issueKeys []string) error {
+	return c.moveIssues(ctx, fmt.Sprintf("/rest/agile/1.0/sprint/%d/issue", sprintID), fmt.Sprintf("sprint %d", sprintID), issueKeys)
+}
+
+// MoveIssuesToBacklog takes issues out of their sprints.
+func (c *Client) MoveIssuesToBacklog(issueKeys []string) error {
+	return c.MoveIssuesToBacklogContext(context.Background(), issueKeys)
+}
+
+// MoveIssuesToBacklogContext is like MoveIssuesToBacklog but carries ctx for cancellation.
+func (c *Client) MoveIssuesToBacklogContext(ctx context.Context, issueKeys []string) error {
+	return c.moveIssues(ctx, "/rest/agile/1.0/backlog/issue", "backlog", issueKeys)
+}
+
+func (c *Client) moveIssues(ctx context.Context, endpoint, resource string, issueKeys []string) error {
+	for len(issueKeys) > 0 {
+		n := min(len(issueKeys), maxAgileMove)
+		body := map[string][]string{"issues": issueKeys[:n]}
+		resp, err := c.makeRequest(ctx, "POST", endpoint, body)
+		if err != nil {
+			return err
+		}
+		err = checkResponse(resp, 204, resource)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+		issueKeys = issueKeys[n:]
+	}
+	return nil
+}
+
+// StartSprint activates a future sprint for the given dates.
+func (c *Client) StartSprint(sprintID int, start, end time.Time) (*Sprint, error) {
+	return c.StartSprintContext(context.Background(), sprintID, start, end)
+}
+
+// StartSprintContext is like StartSprint but carries ctx for cancellation.
+func (c *Client) StartSprintContext(ctx context.Context, sprintID int, start, end time.Time) (*Sprint, error) {
+	return c.updateSprint(ctx, sprintID, map[string]string{
+		"state":     SprintActive,
+		"startDate": start.Format(time.RFC3339),
+		"endDate":   end.Format(time.RFC3339),
+	})
+}
+
+// CompleteSprint closes an active sprint. Jira moves its unfinished issues
+// to the backlog; move them first to carry them elsewhere.
+func (c *Client) CompleteSprint(sprintID int) (*Sprint, error) {
+	return c.CompleteSprintContext(context.Background(), sprintID)
+}
+
+// CompleteSprintContext is like CompleteSprint but carries ctx for cancellation.
+func (c *Client) CompleteSprintContext(ctx context.Context, sprintID int) (*Sprint, error) {
+	return c.updateSprint(ctx, sprintID, map[string]string{"state": SprintClosed})
+}
+
+// updateSprint partially updates a sprint; only the given keys change.
+func (c *Client) updateSprint(ctx context.Context, sprintID int, changes map[string]string) (*Sprint, error) {
+	resp, err := c.makeRequest(ctx, "POST", fmt.Sprintf("/rest/agile/1.0/sprint/%d", sprintID), changes)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, 200, fmt.Sprintf("sprint %d", sprintID)); err != nil {
+		return nil, err
+	}
+	var sprint Sprint
+	if err := json.NewDecoder(resp.Body).Decode(&sprint); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &sprint, nil
+}
+
+func (c *Client) getAgile(ctx context.Context, endpoint, resource string, v interface{}) error {
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, 200, resource); err != nil {
+		return err
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// storyPointsField returns the story points field ID, or "" when it cannot
+// be discovered.
+func (c *Client) storyPointsField(ctx context.Context) string {
+	reg, err := c.FieldRegistry(ctx)
+	if err != nil {
+		return ""
+	}
+	return reg.StoryPointsID()
+}
+
+// resolveStoryPoints fills Fields.StoryPoints from the story points field.
+func resolveStoryPoints(issues []Issue, id string) {
+	if id == "" {
+		return
+	}
+	for i := range issues {
+		var points float64
+		if raw, ok := issues[i].Fields.Custom[id]; ok && json.Unmarshal(raw, &points) == nil {
+			issues[i].Fields.StoryPoints = &points
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetBoardsPaginates(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/agile/1.0/board" {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.URL.Query().Get("projectKeyOrId"); got != "PROJ" {
+			t.Errorf("projectKeyOrId = %q", got)
+		}
+		switch r.URL.Query().Get("startAt") {
+		case "0":
+			w.Write([]byte(`{"isLast":false,"values":[{"id":1,"name":"One","type":"scrum","location":{"projectKey":"PROJ"}}]}`))
+		case "1":
+			w.Write([]byte(`{"isLast":true,"values":[{"id":2,"name":"Two","type":"kanban"}]}`))
+		default:
+			t.Errorf("unexpected startAt %s", r.URL.Query().Get("startAt"))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "me@example.com", "", "token")
+	boards, err := c.GetBoards(BoardFilter{ProjectKey: "PROJ"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(boards) != 2 || boards[0].Location.ProjectKey != "PROJ" || boards[1].Type != "kanban" {
+		t.Errorf("boards = %+v", boards)
+	}
+}
+
+func TestResolveBoard(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("name") {
+		case "rocket":
+			w.Write([]byte(`{"isLast":true,"values":[{"id":1,"name":"Rocket Ops"},{"id":2,"name":"Rocket"}]}`))
+		case "team":
+			w.Write([]byte(`{"isLast":true,"values":[{"id":3,"name":"Team A"},{"id":4,"name":"Team B"}]}`))
+		case "ops":
+			w.Write([]byte(`{"isLast":true,"values":[{"id":1,"name":"Rocket Ops"}]}`))
+		default:
+			w.Write([]byte(`{"isLast":true,"values":[]}`))
+		}
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL, "me@example.com", "", "token")
+
+	for ref, id := range map[string]int{"rocket": 2, "ops": 1} {
+		if board, err := c.ResolveBoard(ref); err != nil || board.ID != id {
+			t.Errorf("ResolveBoard(%s) = %+v, %v; want board %d", ref, board, err, id)
+		}
+	}
+	if _, err := c.ResolveBoard("team"); err == nil || err.Error() != `board "team" is ambiguous: Team A (3), Team B (4)` {
+		t.Errorf("ambiguous: err = %v", err)
+	}
+	if _, err := c.ResolveBoard("nothing"); err == nil {
+		t.Error("no match not reported")
+	}
+}
+
+func TestGetSprintIssuesStoryPoints(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/field":
+			w.Write([]byte(`[{"id":"customfield_10016","name":"Story Points","custom":true,"schema":{"type":"number"}}]`))
+		case "/rest/agile/1.0/sprint/7/issue":
+			if !strings.Contains(r.URL.Query().Get("fields"), "customfield_10016") {
+				t.Errorf("fields = %s", r.URL.Query().Get("fields"))
+			}
+			w.Write([]byte(`{"total":2,"issues":[
+				{"key":"PROJ-1","fields":{"status":{"name":"Done","statusCategory":{"key":"done","name":"Done"}},"customfield_10016":3}},
+				{"key":"PROJ-2","fields":{"status":{"name":"In Progress","statusCategory":{"key":"indeterminate"}}}}
+			]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "me@example.com", "", "token")
+	issues, err := c.GetSprintIssues(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("got %d issues", len(issues))
+	}
+	if p := issues[0].Fields.StoryPoints; p == nil || *p != 3 {
+		t.Errorf("PROJ-1 points = %v", p)
+	}
+	if issues[1].Fields.StoryPoints != nil {
+		t.Errorf("PROJ-2 points = %v", *issues[1].Fields.StoryPoints)
+	}
+	if !issues[0].Fields.Status.IsDone() || issues[1].Fields.Status.IsDone() {
+		t.Error("IsDone does not follow the status category")
+	}
+}
+
+func TestMoveIssuesToSprintBatches(t *testing.T) {
+	var batches []int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/rest/agile/1.0/sprint/7/issue" {
+			http.NotFound(w, r)
+			return
+		}
+		var body struct{ Issues []string }
+		json.NewDecoder(r.Body).Decode(&body)
+		batches = append(batches, len(body.Issues))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	keys := make([]string, 120)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("PROJ-%d", i)
+	}
+	c := NewClient(srv.URL, "me@example.com", "", "token")
+	if err := c.MoveIssuesToSprint(7, keys); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(batches) != "[50 50 20]" {
+		t.Errorf("batches = %v", batches)
+	}
+}
+
+func TestStartAndCompleteSprint(t *testing.T) {
+	var bodies []map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/rest/agile/1.0/sprint/7" {
+			http.NotFound(w, r)
+			return
+		}
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		fmt.Fprintf(w, `{"id":7,"name":"Sprint 7","state":%q}`, body["state"])
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "me@example.com", "", "token")
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	s, err := c.StartSprint(7, start, start.AddDate(0, 0, 14))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.State != SprintActive {
+		t.Errorf("state = %q", s.State)
+	}
+	if bodies[0]["startDate"] != "2024-03-04T09:00:00Z" || bodies[0]["endDate"] != "2024-03-18T09:00:00Z" {
+		t.Errorf("start body = %v", bodies[0])
+	}
+
+	if _, err := c.CompleteSprint(7); err != nil {
+		t.Fatal(err)
+	}
+	if len(bodies[1]) != 1 || bodies[1]["state"] != SprintClosed {
+		t.Errorf("complete body = %v", bodies[1])
+	}
+}
+
+func TestSprintDates(t *testing.T) {
+	s := Sprint{StartDate: "2024-03-04T09:00:00.000Z", EndDate: "2024-03-18T09:00:00.000+01:00"}
+	if got := s.Start(); !got.Equal(time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Start() = %v", got)
+	}
+	if got := s.End(); !got.Equal(time.Date(2024, 3, 18, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("End() = %v", got)
+	}
+	if !(Sprint{}).Start().IsZero() {
+		t.Error("unplanned sprint has a start")
+	}
+}
+
+func TestGetBoardColumns(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/agile/1.0/board/5/configuration" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"columnConfig":{"columns":[
+			{"name":"To Do","statuses":[{"id":"1"}]},
+			{"name":"Doing","statuses":[{"id":"3"},{"id":"4"}],"max":3},
+			{"name":"Done","statuses":[{"id":"5"}]}
+		]}}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "me@example.com", "", "token")
+	cols, err := c.GetBoardColumns(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cols) != 3 || cols[1].Name != "Doing" || fmt.Sprint(cols[1].Statuses) != "[3 4]" || cols[1].Max != 3 {
+		t.Errorf("columns = %+v", cols)
+	}
+}
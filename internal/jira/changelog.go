@@ -0,0 +1,216 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// jiraTimeLayout is the timestamp format of Jira's REST API.
+const jiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// Changelog is the change history of an issue, as embedded by
+// expand=changelog. Jira only embeds the most recent entries; Total tells
+// whether more exist.
+type Changelog struct {
+	StartAt    int       `json:"startAt"`
+	MaxResults int       `json:"maxResults"`
+	Total      int       `json:"total"`
+	Histories  []History `json:"histories"`
+}
+
+// History is one edit of an issue, which may change several fields at once.
+type History struct {
+	ID      string       `json:"id"`
+	Author  User         `json:"author"`
+	Created string       `json:"created"`
+	Items   []ChangeItem `json:"items"`
+}
+
+// ChangeItem is a single field change within a History. From and To hold
+// IDs where the field has them; FromString and ToString the display values.
+type ChangeItem struct {
+	Field      string `json:"field"`
+	FieldType  string `json:"fieldtype"`
+	FieldID    string `json:"fieldId,omitempty"`
+	From       string `json:"from"`
+	FromString string `json:"fromString"`
+	To         string `json:"to"`
+	ToString   string `json:"toString"`
+}
+
+// HistoryEntry is one field change, flattened out of the changelog: who
+// changed which field from what to what, and when.
+type HistoryEntry struct {
+	Time    time.Time `json:"time"`
+	Author  string    `json:"author"`
+	Field   string    `json:"field"`
+	FieldID string    `json:"fieldId,omitempty"`
+	From    string    `json:"from"`
+	To      string    `json:"to"`
+}
+
+// Entries flattens the changelog into one entry per field change, oldest
+// first.
+func (c *Changelog) Entries() []HistoryEntry {
+	if c == nil {
+		return nil
+	}
+	var entries []HistoryEntry
+	for _, h := range c.Histories {
+		t, _ := ParseTime(h.Created)
+		author := h.Author.DisplayName
+		if author == "" {
+			author = h.Author.Name
+		}
+		for _, item := range h.Items {
+			entries = append(entries, HistoryEntry{
+				Time:    t,
+				Author:  author,
+				Field:   item.Field,
+				FieldID: item.FieldID,
+				From:    changeValue(item.FromString, item.From),
+				To:      changeValue(item.ToString, item.To),
+			})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Time.Before(entries[j].Time) })
+	return entries
+}
+
+// changeValue prefers the display string, falling back to the raw ID.
+func changeValue(display, id string) string {
+	if display != "" {
+		return display
+	}
+	return id
+}
+
+// HistoryFilter selects history entries. Fields and Authors match
+// case-insensitively (authors by substring); empty lists match everything.
+type HistoryFilter struct {
+	Fields  []string
+	Authors []string
+	Since   time.Time
+}
+
+// Match reports whether e passes the filter.
+func (f HistoryFilter) Match(e HistoryEntry) bool {
+	if !f.Since.IsZero() && e.Time.Before(f.Since) {
+		return false
+	}
+	if len(f.Fields) > 0 {
+		ok := false
+		for _, field := range f.Fields {
+			if strings.EqualFold(field, e.Field) || (e.FieldID != "" && strings.EqualFold(field, e.FieldID)) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	if len(f.Authors) > 0 {
+		ok := false
+		for _, author := range f.Authors {
+			if strings.Contains(strings.ToLower(e.Author), strings.ToLower(author)) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the entries that pass the filter.
+func (f HistoryFilter) Filter(entries []HistoryEntry) []HistoryEntry {
+	var out []HistoryEntry
+	for _, e := range entries {
+		if f.Match(e) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// ParseTime parses a Jira timestamp such as "2024-03-01T09:30:00.000+0000".
+func ParseTime(s string) (time.Time, error) {
+	t, err := time.Parse(jiraTimeLayout, s)
+	if err != nil {
+		// Some servers omit the milliseconds.
+		return time.Parse("2006-01-02T15:04:05-0700", s)
+	}
+	return t, nil
+}
+
+// GetChangelog returns the complete change history of an issue. It pages
+// through /rest/api/2/issue/{key}/changelog, falling back to the changelog
+// embedded in the issue on servers without that endpoint.
+func (c *Client) GetChangelog(issueKey string) (*Changelog, error) {
+	return c.GetChangelogContext(context.Background(), issueKey)
+}
+
+// GetChangelogContext is like GetChangelog but carries ctx for cancellation.
+func (c *Client) GetChangelogContext(ctx context.Context, issueKey string) (*Changelog, error) {
+	changelog := &Changelog{}
+	startAt := 0
+	for {
+		params := url.Values{}
+		params.Add("startAt", fmt.Sprintf("%d", startAt))
+		params.Add("maxResults", "100")
+
+		resp, err := c.makeRequest(ctx, "GET", fmt.Sprintf("/rest/api/2/issue/%s/changelog?%s", url.PathEscape(issueKey), params.Encode()), nil)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == 404 && startAt == 0 {
+			resp.Body.Close()
+			return c.embeddedChangelog(ctx, issueKey)
+		}
+
+		var page struct {
+			Total  int       `json:"total"`
+			IsLast bool      `json:"isLast"`
+			Values []History `json:"values"`
+		}
+		err = checkResponse(resp, 200, "changelog of "+issueKey)
+		if err == nil {
+			if derr := json.NewDecoder(resp.Body).Decode(&page); derr != nil {
+				err = fmt.Errorf("failed to decode response: %w", derr)
+			}
+		}
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		changelog.Histories = append(changelog.Histories, page.Values...)
+		changelog.Total = page.Total
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 || startAt >= page.Total {
+			changelog.MaxResults = len(changelog.Histories)
+			return changelog, nil
+		}
+	}
+}
+
+// embeddedChangelog reads the changelog from the issue itself; Data Center
+// servers have no paginated changelog endpoint but embed all entries.
+func (c *Client) embeddedChangelog(ctx context.Context, issueKey string) (*Changelog, error) {
+	issue, err := c.GetIssueContext(ctx, issueKey)
+	if err != nil {
+		return nil, err
+	}
+	if issue.Changelog == nil {
+		return &Changelog{}, nil
+	}
+	return issue.Changelog, nil
+}
@@ -0,0 +1,102 @@
+package jira
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const changelogJSON = `{"startAt":0,"maxResults":2,"total":2,"histories":[
+	{"id":"2","author":{"displayName":"Bob"},"created":"2024-03-02T10:00:00.000+0000","items":[
+		{"field":"status","fieldtype":"jira","fieldId":"status","from":"3","fromString":"In Progress","to":"10001","toString":"Done"}
+	]},
+	{"id":"1","author":{"displayName":"Alice Smith"},"created":"2024-03-01T09:30:00.000+0000","items":[
+		{"field":"status","fieldtype":"jira","fieldId":"status","from":"1","fromString":"To Do","to":"3","toString":"In Progress"},
+		{"field":"assignee","fieldtype":"jira","fieldId":"assignee","from":null,"fromString":null,"to":"abc","toString":"Alice Smith"}
+	]}
+]}`
+
+func TestChangelogEntries(t *testing.T) {
+	var issue Issue
+	if err := json.Unmarshal([]byte(`{"key":"PROJ-1","fields":{},"changelog":`+changelogJSON+`}`), &issue); err != nil {
+		t.Fatal(err)
+	}
+	entries := issue.Changelog.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries", len(entries))
+	}
+	first := entries[0]
+	if first.Author != "Alice Smith" || first.Field != "status" || first.From != "To Do" || first.To != "In Progress" {
+		t.Errorf("first = %+v", first)
+	}
+	if want := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC); !first.Time.Equal(want) {
+		t.Errorf("time = %v", first.Time)
+	}
+	if entries[1].From != "" || entries[1].To != "Alice Smith" {
+		t.Errorf("assignee entry = %+v", entries[1])
+	}
+	if entries[2].To != "Done" {
+		t.Errorf("last = %+v", entries[2])
+	}
+
+	byField := HistoryFilter{Fields: []string{"Status"}}.Filter(entries)
+	if len(byField) != 2 {
+		t.Errorf("field filter kept %d", len(byField))
+	}
+	byAuthor := HistoryFilter{Authors: []string{"alice"}}.Filter(entries)
+	if len(byAuthor) != 2 {
+		t.Errorf("author filter kept %d", len(byAuthor))
+	}
+	since := HistoryFilter{Since: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)}.Filter(entries)
+	if len(since) != 1 || since[0].To != "Done" {
+		t.Errorf("since filter = %+v", since)
+	}
+}
+
+func TestGetChangelogPaginated(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/issue/PROJ-1/changelog" {
+			http.NotFound(w, r)
+			return
+		}
+		switch r.URL.Query().Get("startAt") {
+		case "0":
+			w.Write([]byte(`{"startAt":0,"total":2,"isLast":false,"values":[{"id":"1","created":"2024-03-01T09:30:00.000+0000","items":[{"field":"status","toString":"In Progress"}]}]}`))
+		default:
+			w.Write([]byte(`{"startAt":1,"total":2,"isLast":true,"values":[{"id":"2","created":"2024-03-02T09:30:00.000+0000","items":[{"field":"status","toString":"Done"}]}]}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "me@example.com", "", "token")
+	changelog, err := c.GetChangelog("PROJ-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changelog.Histories) != 2 || changelog.Total != 2 {
+		t.Errorf("changelog = %+v", changelog)
+	}
+}
+
+func TestGetChangelogEmbeddedFallback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/issue/PROJ-1":
+			w.Write([]byte(`{"key":"PROJ-1","fields":{},"changelog":` + changelogJSON + `}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "me@example.com", "", "token")
+	changelog, err := c.GetChangelog("PROJ-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changelog.Entries()) != 3 {
+		t.Errorf("entries = %+v", changelog.Entries())
+	}
+}
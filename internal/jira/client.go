@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -12,7 +13,10 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
+	"jet/internal/auth"
 	"jet/internal/httpclient"
 )
 
@@ -22,11 +26,31 @@ type Client struct {
 	Username   string
 	Token      string
 	HTTPClient *http.Client
+
+	// Auth applies credentials to each request. When nil, HTTP Basic with
+	// Email (or Username) and Token is used.
+	Auth auth.Authenticator
+
+	// CacheDir, when set, holds per-site caches such as the field registry.
+	CacheDir string
+
+	fieldsMu    sync.Mutex
+	fields      *FieldRegistry
+	fieldsErr   error
+	fieldsErrAt time.Time
 }
 
+// issueFields and searchFields are the fields requested for a single issue
+// and for search results; the site's Epic Link field is appended when known.
+const (
+	issueFields  = "summary,description,status,assignee,reporter,priority,labels,components,fixVersions,created,updated,resolutiondate,issuetype,project,comment,attachment,parent,issuelinks,timetracking"
+	searchFields = "summary,description,status,assignee,reporter,priority,labels,components,fixVersions,created,updated,resolutiondate,issuetype,project,parent"
+)
+
 type Issue struct {
-	Key    string `json:"key"`
-	Fields Fields `json:"fields"`
+	Key       string     `json:"key"`
+	Fields    Fields     `json:"fields"`
+	Changelog *Changelog `json:"changelog,omitempty"` // present when fetched with expand=changelog
 }
 
 type Fields struct {
@@ -48,16 +72,76 @@ type Fields struct {
 	Comment            CommentList     `json:"comment"`
 	Attachment         []Attachment    `json:"attachment"`
 	Parent             *IssueLink      `json:"parent"`
-	EpicLink           *EpicLink       `json:"customfield_10014"`
+	EpicLink           *EpicLink       `json:"-"` // from the site's Epic Link field; see MarshalJSON
 	IssueLinks         []IssueLinkItem `json:"issuelinks"`
+	TimeTracking       *TimeTracking   `json:"timetracking,omitempty"`
+	StoryPoints        *float64        `json:"storyPoints,omitempty"` // from the site's story points field, on Agile lists
+
+	// Custom holds the raw customfield_* values, keyed by field ID.
+	Custom map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and keeps non-null custom fields.
+func (f *Fields) UnmarshalJSON(data []byte) error {
+	type plain Fields
+	if err := json.Unmarshal(data, (*plain)(f)); err != nil {
+		return err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	for id, raw := range all {
+		if !strings.HasPrefix(id, "customfield_") || string(raw) == "null" {
+			continue
+		}
+		if f.Custom == nil {
+			f.Custom = make(map[string]json.RawMessage)
+		}
+		f.Custom[id] = raw
+	}
+	return nil
+}
+
+// MarshalJSON writes EpicLink under customfield_10014, the key jet's JSON
+// output has always used for it, whatever the site's Epic Link field is.
+func (f Fields) MarshalJSON() ([]byte, error) {
+	type plain Fields
+	return json.Marshal(struct {
+		plain
+		EpicLink *EpicLink `json:"customfield_10014"`
+	}{plain(f), f.EpicLink})
 }
 
 type Status struct {
+	ID       string          `json:"id,omitempty"`
+	Name     string          `json:"name"`
+	Category *StatusCategory `json:"statusCategory,omitempty"`
+}
+
+// StatusCategory groups statuses across workflows. Key is "new",
+// "indeterminate" or "done".
+type StatusCategory struct {
+	Key  string `json:"key"`
 	Name string `json:"name"`
 }
 
+// IsDone reports whether the status is in the done category. Without a
+// category it falls back to common done status names.
+func (s Status) IsDone() bool {
+	if s.Category != nil {
+		return s.Category.Key == "done"
+	}
+	switch strings.ToLower(s.Name) {
+	case "done", "closed", "resolved", "complete", "completed":
+		return true
+	}
+	return false
+}
+
 type IssueType struct {
-	Name string `json:"name"`
+	Name    string `json:"name"`
+	Subtask bool   `json:"subtask,omitempty"`
 }
 
 type Priority struct {
@@ -89,11 +173,12 @@ type CommentList struct {
 }
 
 type Comment struct {
-	ID      string `json:"id"`
-	Body    string `json:"body"`
-	Author  User   `json:"author"`
-	Created string `json:"created"`
-	Updated string `json:"updated"`
+	ID      string          `json:"id"`
+	Body    string          `json:"body"`
+	BodyADF json.RawMessage `json:"-"` // original ADF body, when the API returned one
+	Author  User            `json:"author"`
+	Created string          `json:"created"`
+	Updated string          `json:"updated"`
 }
 
 type Attachment struct {
@@ -147,9 +232,35 @@ type CreateIssueRequest struct {
 type CreateIssueFields struct {
 	Project     ProjectRef `json:"project"`
 	Summary     string     `json:"summary"`
-	Description string     `json:"description"`
+	Description interface{} `json:"description,omitempty"` // string for v2, *ADFNode for v3
 	IssueType   IssueTypeRef `json:"issuetype"`
 	Parent      *IssueRef  `json:"parent,omitempty"`
+	Priority    *NameRef   `json:"priority,omitempty"`
+	Labels      []string   `json:"labels,omitempty"`
+	Components  []NameRef  `json:"components,omitempty"`
+	Assignee    *UserRef   `json:"assignee,omitempty"`
+	FixVersions []NameRef  `json:"fixVersions,omitempty"`
+
+	// Custom holds additional fields keyed by field ID, such as the values
+	// from FieldRegistry.ParseFieldValues. They are sent alongside the rest.
+	Custom map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON merges Custom into the standard create fields.
+func (f CreateIssueFields) MarshalJSON() ([]byte, error) {
+	type plain CreateIssueFields
+	data, err := json.Marshal(plain(f))
+	if err != nil || len(f.Custom) == 0 {
+		return data, err
+	}
+	merged := make(map[string]interface{})
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, err
+	}
+	for id, v := range f.Custom {
+		merged[id] = v
+	}
+	return json.Marshal(merged)
 }
 
 type ProjectRef struct {
@@ -164,14 +275,35 @@ type IssueRef struct {
 	Key string `json:"key"`
 }
 
+// NameRef refers to a priority, component or version by name.
+type NameRef struct {
+	Name string `json:"name"`
+}
+
+// UserRef refers to a user by account ID (Cloud) or username (Server).
+type UserRef struct {
+	AccountID string `json:"accountId,omitempty"`
+	Name      string `json:"name,omitempty"`
+}
+
 type UpdateIssueRequest struct {
-	Fields map[string]interface{} `json:"fields"`
+	Fields map[string]interface{}      `json:"fields,omitempty"`
+	Update map[string][]FieldOperation `json:"update,omitempty"`
 }
 
+// FieldOperation is one edit of a field's current value, such as
+// {"add": "backend"} or {"remove": "backend"} for labels.
+type FieldOperation map[string]interface{}
+
 type AddCommentRequest struct {
 	Body string `json:"body"`
 }
 
+// AddCommentADFRequest is the v3 comment payload, whose body must be ADF.
+type AddCommentADFRequest struct {
+	Body *ADFNode `json:"body"`
+}
+
 type SearchRequest struct {
 	JQL        string   `json:"jql"`
 	StartAt    int      `json:"startAt"`
@@ -184,14 +316,17 @@ type SearchResponse struct {
 	StartAt    int     `json:"startAt"`
 	Total      int     `json:"total"`
 	MaxResults int     `json:"maxResults"`
+
+	// NextPageToken asks SearchIssuesPage for the page after this one;
+	// IsLast is set on the last page.
+	NextPageToken string `json:"nextPageToken,omitempty"`
+	IsLast        bool   `json:"isLast,omitempty"`
 }
 
 type Transition struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
-	To   struct {
-		Name string `json:"name"`
-	} `json:"to"`
+	To   Status `json:"to"`
 }
 
 type TransitionsResponse struct {
@@ -235,6 +370,25 @@ func NewClient(baseURL, email, username, token string) *Client {
 	}
 }
 
+// NewClientWithAuth returns a client that authenticates with a, e.g. a
+// Bearer personal access token or OAuth 2.0.
+func NewClientWithAuth(baseURL string, a auth.Authenticator) *Client {
+	return &Client{
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		HTTPClient: httpclient.New(httpclient.DefaultTimeout),
+		Auth:       a,
+	}
+}
+
+// Authorize adds the client's credentials to req. Use it for requests made
+// outside the client, such as downloading attachment content.
+func (c *Client) Authorize(req *http.Request) error {
+	if c.Auth != nil {
+		return c.Auth.Apply(req)
+	}
+	return auth.NewBasic(c.Email, c.Username, c.Token).Apply(req)
+}
+
 func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body interface{}) (*http.Response, error) {
 	var reqBody io.Reader
 	if body != nil {
@@ -252,12 +406,8 @@ func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body
 	}
 
 	// Set authentication
-	if c.Email != "" && c.Token != "" {
-		req.SetBasicAuth(c.Email, c.Token)
-	} else if c.Username != "" && c.Token != "" {
-		req.SetBasicAuth(c.Username, c.Token)
-	} else {
-		return nil, fmt.Errorf("authentication credentials not provided")
+	if err := c.Authorize(req); err != nil {
+		return nil, err
 	}
 
 	req.Header.Set("Accept", "application/json")
@@ -274,32 +424,38 @@ func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body
 	return resp, nil
 }
 
-// checkResponse maps common HTTP error codes to user-friendly errors.
-// Returns nil when resp.StatusCode == successCode.
+// checkResponse returns an *APIError describing resp unless
+// resp.StatusCode == successCode.
 func checkResponse(resp *http.Response, successCode int, resource string) error {
 	if resp.StatusCode == successCode {
 		return nil
 	}
-	switch resp.StatusCode {
-	case 401:
-		return fmt.Errorf("authentication failed - check your credentials")
-	case 403:
-		return fmt.Errorf("access denied to %s", resource)
-	case 404:
-		return fmt.Errorf("%s not found", resource)
-	default:
-		return fmt.Errorf("HTTP %d: request failed for %s", resp.StatusCode, resource)
-	}
+	return newAPIError(resp, resource)
 }
 
 func (c *Client) GetIssue(issueKey string) (*Issue, error) {
+	return c.GetIssueContext(context.Background(), issueKey)
+}
+
+// GetIssueContext is like GetIssue but carries ctx for cancellation.
+func (c *Client) GetIssueContext(ctx context.Context, issueKey string) (*Issue, error) {
+	return c.GetIssueWithFieldsContext(ctx, issueKey, nil)
+}
+
+// GetIssueWithFieldsContext is like GetIssueContext but also requests the
+// given field IDs (or "*all"); their values end up in Fields.Custom.
+func (c *Client) GetIssueWithFieldsContext(ctx context.Context, issueKey string, extraFields []string) (*Issue, error) {
 	params := url.Values{}
 	params.Add("expand", "changelog,renderedFields")
-	params.Add("fields", "summary,description,status,assignee,reporter,priority,labels,components,fixVersions,created,updated,resolutiondate,issuetype,project,comment,attachment,parent,customfield_10014,issuelinks")
+	fields := c.withEpicLink(ctx, issueFields)
+	if len(extraFields) > 0 {
+		fields += "," + strings.Join(extraFields, ",")
+	}
+	params.Add("fields", fields)
 
 	endpoint := fmt.Sprintf("/rest/api/2/issue/%s?%s", issueKey, params.Encode())
 
-	resp, err := c.makeRequest(context.Background(), "GET", endpoint, nil)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -316,11 +472,51 @@ func (c *Client) GetIssue(issueKey string) (*Issue, error) {
 
 	// Parse description
 	issue.Fields.DescriptionText = parseDescription(issue.Fields.Description)
+	issues := []Issue{issue}
+	c.resolveEpicLinks(ctx, issues)
 
-	return &issue, nil
+	return &issues[0], nil
+}
+
+// linkFields are the fields GetIssueLinks requests.
+const linkFields = "summary,status,issuetype,assignee,parent,issuelinks"
+
+// GetIssueLinks fetches an issue with only what relates it to others: its
+// links, parent and epic, plus its summary, type, status and assignee. It is
+// much lighter than GetIssue when walking many issues.
+func (c *Client) GetIssueLinks(issueKey string) (*Issue, error) {
+	return c.GetIssueLinksContext(context.Background(), issueKey)
 }
 
-// parseDescription converts the description field (which can be either a string or ADF object) to plain text
+// GetIssueLinksContext is like GetIssueLinks but carries ctx for cancellation.
+func (c *Client) GetIssueLinksContext(ctx context.Context, issueKey string) (*Issue, error) {
+	params := url.Values{}
+	params.Add("fields", c.withEpicLink(ctx, linkFields))
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s?%s", url.PathEscape(issueKey), params.Encode())
+
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, 200, "issue "+issueKey); err != nil {
+		return nil, err
+	}
+
+	var issue Issue
+	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	issues := []Issue{issue}
+	c.resolveEpicLinks(ctx, issues)
+
+	return &issues[0], nil
+}
+
+// parseDescription converts the description field (which can be either a
+// string or an ADF object) to text. ADF descriptions are rendered as Markdown
+// so headings, lists, code blocks and tables survive.
 func parseDescription(desc json.RawMessage) string {
 	if len(desc) == 0 {
 		return ""
@@ -332,45 +528,56 @@ func parseDescription(desc json.RawMessage) string {
 		return strDesc
 	}
 
-	// If that fails, it's probably an ADF object (API v3)
-	// For now, just return a simplified text extraction
-	// TODO: Implement full ADF parsing if needed
-	var adfDoc map[string]interface{}
-	if err := json.Unmarshal(desc, &adfDoc); err == nil {
-		return extractTextFromADF(adfDoc)
-	}
-
-	return ""
+	// Otherwise it's an ADF object (API v3)
+	return ADFToMarkdown(desc)
 }
 
-// extractTextFromADF recursively extracts text content from Atlassian Document Format
-func extractTextFromADF(node map[string]interface{}) string {
-	var text strings.Builder
-
-	// Extract text from this node
-	if nodeText, ok := node["text"].(string); ok {
-		text.WriteString(nodeText)
+// DescriptionPlain returns the description as plain text for terminals.
+// ADF descriptions are rendered without Markdown markup; v2 string
+// descriptions are returned unchanged.
+func (f Fields) DescriptionPlain() string {
+	if IsADF(f.Description) {
+		return ADFToText(f.Description)
 	}
+	return f.DescriptionText
+}
 
-	// Recursively process content array
-	if content, ok := node["content"].([]interface{}); ok {
-		for _, child := range content {
-			if childMap, ok := child.(map[string]interface{}); ok {
-				childText := extractTextFromADF(childMap)
-				if childText != "" {
-					text.WriteString(childText)
-					text.WriteString(" ")
-				}
-			}
-		}
+// UnmarshalJSON accepts comment bodies as either a plain string (API v2) or
+// an ADF document (API v3). ADF bodies are rendered to Markdown in Body and
+// the original document is kept in BodyADF.
+func (c *Comment) UnmarshalJSON(data []byte) error {
+	type commentAlias Comment
+	var aux struct {
+		commentAlias
+		Body json.RawMessage `json:"body"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
 	}
+	*c = Comment(aux.commentAlias)
+	c.Body = parseDescription(aux.Body)
+	if IsADF(aux.Body) {
+		c.BodyADF = aux.Body
+	}
+	return nil
+}
 
-	return text.String()
+// PlainBody returns the comment body as plain text for terminals.
+func (c Comment) PlainBody() string {
+	if len(c.BodyADF) > 0 {
+		return ADFToText(c.BodyADF)
+	}
+	return c.Body
 }
 
 // UploadAttachments uploads one or more files to the given issue.
 // Returns the attachment metadata returned by JIRA on success.
 func (c *Client) UploadAttachments(issueKey string, filePaths []string) ([]Attachment, error) {
+	return c.UploadAttachmentsContext(context.Background(), issueKey, filePaths)
+}
+
+// UploadAttachmentsContext is like UploadAttachments but carries ctx for cancellation.
+func (c *Client) UploadAttachmentsContext(ctx context.Context, issueKey string, filePaths []string) ([]Attachment, error) {
 	if len(filePaths) == 0 {
 		return nil, fmt.Errorf("no files provided")
 	}
@@ -400,17 +607,14 @@ func (c *Client) UploadAttachments(issueKey string, filePaths []string) ([]Attac
 
 	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/attachments", issueKey)
 	reqURL := fmt.Sprintf("%s%s", c.BaseURL, endpoint)
-	req, err := http.NewRequestWithContext(context.Background(), "POST", reqURL, &buf)
+	req, err := http.NewRequestWithContext(ctx, "POST", reqURL, &buf)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	if c.Email != "" && c.Token != "" {
-		req.SetBasicAuth(c.Email, c.Token)
-	} else if c.Username != "" && c.Token != "" {
-		req.SetBasicAuth(c.Username, c.Token)
-	} else {
-		return nil, fmt.Errorf("authentication credentials not provided")
+	// Set authentication
+	if err := c.Authorize(req); err != nil {
+		return nil, err
 	}
 
 	req.Header.Set("Accept", "application/json")
@@ -436,11 +640,26 @@ func (c *Client) UploadAttachments(issueKey string, filePaths []string) ([]Attac
 }
 
 func (c *Client) AddComment(issueKey, comment string) error {
-	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/comment", issueKey)
-	
-	reqBody := AddCommentRequest{Body: comment}
-	
-	resp, err := c.makeRequest(context.Background(), "POST", endpoint, reqBody)
+	return c.AddCommentContext(context.Background(), issueKey, comment)
+}
+
+// AddCommentContext is like AddComment but carries ctx for cancellation.
+func (c *Client) AddCommentContext(ctx context.Context, issueKey, comment string) error {
+	return c.addComment(ctx, fmt.Sprintf("/rest/api/2/issue/%s/comment", issueKey), issueKey, AddCommentRequest{Body: comment})
+}
+
+// AddCommentADF adds a rich-text comment through the v3 API.
+func (c *Client) AddCommentADF(issueKey string, body *ADFNode) error {
+	return c.AddCommentADFContext(context.Background(), issueKey, body)
+}
+
+// AddCommentADFContext is like AddCommentADF but carries ctx for cancellation.
+func (c *Client) AddCommentADFContext(ctx context.Context, issueKey string, body *ADFNode) error {
+	return c.addComment(ctx, fmt.Sprintf("/rest/api/3/issue/%s/comment", issueKey), issueKey, AddCommentADFRequest{Body: body})
+}
+
+func (c *Client) addComment(ctx context.Context, endpoint, issueKey string, reqBody interface{}) error {
+	resp, err := c.makeRequest(ctx, "POST", endpoint, reqBody)
 	if err != nil {
 		return err
 	}
@@ -450,11 +669,42 @@ func (c *Client) AddComment(issueKey, comment string) error {
 }
 
 func (c *Client) UpdateIssue(issueKey string, fields map[string]interface{}) error {
-	endpoint := fmt.Sprintf("/rest/api/2/issue/%s", issueKey)
-	
-	reqBody := UpdateIssueRequest{Fields: fields}
-	
-	resp, err := c.makeRequest(context.Background(), "PUT", endpoint, reqBody)
+	return c.UpdateIssueContext(context.Background(), issueKey, fields)
+}
+
+// UpdateIssueContext is like UpdateIssue but carries ctx for cancellation.
+func (c *Client) UpdateIssueContext(ctx context.Context, issueKey string, fields map[string]interface{}) error {
+	return c.updateIssue(ctx, fmt.Sprintf("/rest/api/2/issue/%s", issueKey), issueKey, fields)
+}
+
+// UpdateIssueADF updates fields through the v3 API. Rich-text fields such as
+// description must be given as *ADFNode values.
+func (c *Client) UpdateIssueADF(issueKey string, fields map[string]interface{}) error {
+	return c.UpdateIssueADFContext(context.Background(), issueKey, fields)
+}
+
+// UpdateIssueADFContext is like UpdateIssueADF but carries ctx for cancellation.
+func (c *Client) UpdateIssueADFContext(ctx context.Context, issueKey string, fields map[string]interface{}) error {
+	return c.updateIssue(ctx, fmt.Sprintf("/rest/api/3/issue/%s", issueKey), issueKey, fields)
+}
+
+// UpdateIssueOperations edits fields relative to their current values, such
+// as adding or removing single labels, leaving the other values alone.
+func (c *Client) UpdateIssueOperations(issueKey string, ops map[string][]FieldOperation) error {
+	return c.UpdateIssueOperationsContext(context.Background(), issueKey, ops)
+}
+
+// UpdateIssueOperationsContext is like UpdateIssueOperations but carries ctx for cancellation.
+func (c *Client) UpdateIssueOperationsContext(ctx context.Context, issueKey string, ops map[string][]FieldOperation) error {
+	return c.sendUpdate(ctx, fmt.Sprintf("/rest/api/2/issue/%s", issueKey), issueKey, UpdateIssueRequest{Update: ops})
+}
+
+func (c *Client) updateIssue(ctx context.Context, endpoint, issueKey string, fields map[string]interface{}) error {
+	return c.sendUpdate(ctx, endpoint, issueKey, UpdateIssueRequest{Fields: fields})
+}
+
+func (c *Client) sendUpdate(ctx context.Context, endpoint, issueKey string, reqBody UpdateIssueRequest) error {
+	resp, err := c.makeRequest(ctx, "PUT", endpoint, reqBody)
 	if err != nil {
 		return err
 	}
@@ -464,23 +714,66 @@ func (c *Client) UpdateIssue(issueKey string, fields map[string]interface{}) err
 }
 
 func (c *Client) CreateIssue(projectKey, summary, description, issueType, epicKey string) (*Issue, error) {
+	return c.CreateIssueContext(context.Background(), projectKey, summary, description, issueType, epicKey)
+}
+
+// CreateIssueContext is like CreateIssue but carries ctx for cancellation.
+func (c *Client) CreateIssueContext(ctx context.Context, projectKey, summary, description, issueType, epicKey string) (*Issue, error) {
+	return c.createIssue(ctx, "/rest/api/2/issue", newCreateFields(projectKey, summary, description, issueType, epicKey))
+}
+
+// CreateIssueADF creates an issue through the v3 API with a rich-text
+// description. A nil description leaves the field empty.
+func (c *Client) CreateIssueADF(projectKey, summary string, description *ADFNode, issueType, epicKey string) (*Issue, error) {
+	return c.CreateIssueADFContext(context.Background(), projectKey, summary, description, issueType, epicKey)
+}
+
+// CreateIssueADFContext is like CreateIssueADF but carries ctx for cancellation.
+func (c *Client) CreateIssueADFContext(ctx context.Context, projectKey, summary string, description *ADFNode, issueType, epicKey string) (*Issue, error) {
+	var desc interface{}
+	if description != nil {
+		desc = description
+	}
+	return c.createIssue(ctx, "/rest/api/3/issue", newCreateFields(projectKey, summary, desc, issueType, epicKey))
+}
+
+// CreateIssueWithFields creates an issue from a full set of create fields,
+// including Custom ones. A *ADFNode description is sent through the v3 API.
+func (c *Client) CreateIssueWithFields(fields CreateIssueFields) (*Issue, error) {
+	return c.CreateIssueWithFieldsContext(context.Background(), fields)
+}
+
+// CreateIssueWithFieldsContext is like CreateIssueWithFields but carries ctx for cancellation.
+func (c *Client) CreateIssueWithFieldsContext(ctx context.Context, fields CreateIssueFields) (*Issue, error) {
 	endpoint := "/rest/api/2/issue"
-	
-	reqBody := CreateIssueRequest{
-		Fields: CreateIssueFields{
-			Project:     ProjectRef{Key: projectKey},
-			Summary:     summary,
-			Description: description,
-			IssueType:   IssueTypeRef{Name: issueType},
-		},
+	if doc, ok := fields.Description.(*ADFNode); ok {
+		endpoint = "/rest/api/3/issue"
+		if doc == nil {
+			fields.Description = nil
+		}
+	}
+	return c.createIssue(ctx, endpoint, fields)
+}
+
+func newCreateFields(projectKey, summary string, description interface{}, issueType, epicKey string) CreateIssueFields {
+	fields := CreateIssueFields{
+		Project:     ProjectRef{Key: projectKey},
+		Summary:     summary,
+		Description: description,
+		IssueType:   IssueTypeRef{Name: issueType},
 	}
 
 	// Add epic link if provided
 	if epicKey != "" {
-		reqBody.Fields.Parent = &IssueRef{Key: epicKey}
+		fields.Parent = &IssueRef{Key: epicKey}
 	}
-	
-	resp, err := c.makeRequest(context.Background(), "POST", endpoint, reqBody)
+	return fields
+}
+
+func (c *Client) createIssue(ctx context.Context, endpoint string, fields CreateIssueFields) (*Issue, error) {
+	reqBody := CreateIssueRequest{Fields: fields}
+
+	resp, err := c.makeRequest(ctx, "POST", endpoint, reqBody)
 	if err != nil {
 		return nil, err
 	}
@@ -498,33 +791,81 @@ func (c *Client) CreateIssue(projectKey, summary, description, issueType, epicKe
 	return &issue, nil
 }
 
+// DeleteIssue deletes an issue together with its subtasks.
+func (c *Client) DeleteIssue(issueKey string) error {
+	return c.DeleteIssueContext(context.Background(), issueKey)
+}
+
+// DeleteIssueContext is like DeleteIssue but carries ctx for cancellation.
+func (c *Client) DeleteIssueContext(ctx context.Context, issueKey string) error {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s?deleteSubtasks=true", url.PathEscape(issueKey))
+
+	resp, err := c.makeRequest(ctx, "DELETE", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp, 204, "issue "+issueKey)
+}
+
 func (c *Client) SearchIssues(jql string, maxResults int) (*SearchResponse, error) {
-	return c.SearchIssuesWithPagination(jql, 0, maxResults)
+	return c.SearchIssuesContext(context.Background(), jql, maxResults)
+}
+
+// SearchIssuesContext is like SearchIssues but carries ctx for cancellation.
+func (c *Client) SearchIssuesContext(ctx context.Context, jql string, maxResults int) (*SearchResponse, error) {
+	return c.SearchIssuesWithPaginationContext(ctx, jql, 0, maxResults)
 }
 
 func (c *Client) SearchIssuesWithPagination(jql string, startAt int, maxResults int) (*SearchResponse, error) {
+	return c.SearchIssuesWithPaginationContext(context.Background(), jql, startAt, maxResults)
+}
+
+// SearchIssuesWithPaginationContext is like SearchIssuesWithPagination but carries ctx for cancellation.
+func (c *Client) SearchIssuesWithPaginationContext(ctx context.Context, jql string, startAt int, maxResults int) (*SearchResponse, error) {
 	// Use GET request with query parameters for v3 API
 	// The v3 API uses /rest/api/3/search/jql with GET method
 	params := url.Values{}
 	params.Add("jql", jql)
 	params.Add("startAt", fmt.Sprintf("%d", startAt))
 	params.Add("maxResults", fmt.Sprintf("%d", maxResults))
-	params.Add("fields", "summary,description,status,assignee,reporter,priority,labels,components,fixVersions,created,updated,resolutiondate,issuetype,project,parent,customfield_10014")
+	return c.searchJQL(ctx, params)
+}
+
+// SearchIssuesPage returns the page of issues jql matches that pageToken,
+// the NextPageToken of the previous page, asks for; an empty token asks for
+// the first page. Unlike startAt, which /rest/api/3/search/jql does not page
+// with, the token reaches every match.
+func (c *Client) SearchIssuesPage(jql, pageToken string, maxResults int) (*SearchResponse, error) {
+	return c.SearchIssuesPageContext(context.Background(), jql, pageToken, maxResults)
+}
+
+// SearchIssuesPageContext is like SearchIssuesPage but carries ctx for cancellation.
+func (c *Client) SearchIssuesPageContext(ctx context.Context, jql, pageToken string, maxResults int) (*SearchResponse, error) {
+	params := url.Values{}
+	params.Add("jql", jql)
+	params.Add("maxResults", fmt.Sprintf("%d", maxResults))
+	if pageToken != "" {
+		params.Add("nextPageToken", pageToken)
+	}
+	return c.searchJQL(ctx, params)
+}
+
+// searchJQL runs an issue search with params, adding the fields to return.
+func (c *Client) searchJQL(ctx context.Context, params url.Values) (*SearchResponse, error) {
+	params.Add("fields", c.withEpicLink(ctx, searchFields))
 
 	endpoint := fmt.Sprintf("/rest/api/3/search/jql?%s", params.Encode())
 
-	resp, err := c.makeRequest(context.Background(), "GET", endpoint, nil)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		if resp.StatusCode == 401 || resp.StatusCode == 403 || resp.StatusCode == 404 {
-			return nil, checkResponse(resp, 200, "issue search")
-		}
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("HTTP %d: search failed - %s", resp.StatusCode, string(bodyBytes))
+	if err := checkResponse(resp, 200, "issue search"); err != nil {
+		return nil, err
 	}
 
 	// Read the response body to handle it properly
@@ -554,11 +895,17 @@ func (c *Client) SearchIssuesWithPagination(jql string, startAt int, maxResults
 	for i := range searchResp.Issues {
 		searchResp.Issues[i].Fields.DescriptionText = parseDescription(searchResp.Issues[i].Fields.Description)
 	}
+	c.resolveEpicLinks(ctx, searchResp.Issues)
 
 	return &searchResp, nil
 }
 
 func (c *Client) GetEpicChildren(epicKey string) ([]Issue, error) {
+	return c.GetEpicChildrenContext(context.Background(), epicKey)
+}
+
+// GetEpicChildrenContext is like GetEpicChildren but carries ctx for cancellation.
+func (c *Client) GetEpicChildrenContext(ctx context.Context, epicKey string) ([]Issue, error) {
 	// Use the Agile API with pagination to get all epic children
 	var allIssues []Issue
 	startAt := 0
@@ -568,11 +915,11 @@ func (c *Client) GetEpicChildren(epicKey string) ([]Issue, error) {
 		params := url.Values{}
 		params.Add("startAt", fmt.Sprintf("%d", startAt))
 		params.Add("maxResults", fmt.Sprintf("%d", maxResults))
-		params.Add("fields", "summary,description,status,assignee,reporter,priority,labels,components,fixVersions,created,updated,resolutiondate,issuetype,project,parent,customfield_10014")
+		params.Add("fields", c.withEpicLink(ctx, searchFields))
 
 		endpoint := fmt.Sprintf("/rest/agile/1.0/epic/%s/issue?%s", epicKey, params.Encode())
 
-		resp, err := c.makeRequest(context.Background(), "GET", endpoint, nil)
+		resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -587,6 +934,7 @@ func (c *Client) GetEpicChildren(epicKey string) ([]Issue, error) {
 			return nil, fmt.Errorf("failed to decode response: %w", err)
 		}
 
+		c.resolveEpicLinks(ctx, searchResp.Issues)
 		allIssues = append(allIssues, searchResp.Issues...)
 
 		// Check if we've fetched all issues
@@ -600,7 +948,7 @@ func (c *Client) GetEpicChildren(epicKey string) ([]Issue, error) {
 	return allIssues, nil
 }
 
-func (c *Client) getEpicChildrenViaSearch(epicKey string) ([]Issue, error) {
+func (c *Client) getEpicChildrenViaSearch(ctx context.Context, epicKey string) ([]Issue, error) {
 	jql := fmt.Sprintf("\"Epic Link\" = %s ORDER BY key ASC", epicKey)
 
 	var allIssues []Issue
@@ -608,11 +956,12 @@ func (c *Client) getEpicChildrenViaSearch(epicKey string) ([]Issue, error) {
 	maxResults := 100
 
 	for {
-		searchResp, err := c.SearchIssuesWithPagination(jql, startAt, maxResults)
+		searchResp, err := c.SearchIssuesWithPaginationContext(ctx, jql, startAt, maxResults)
 		if err != nil {
 			return nil, fmt.Errorf("failed to search for child issues: %w", err)
 		}
 
+		c.resolveEpicLinks(ctx, searchResp.Issues)
 		allIssues = append(allIssues, searchResp.Issues...)
 
 		// Check if we've fetched all issues
@@ -627,6 +976,11 @@ func (c *Client) getEpicChildrenViaSearch(epicKey string) ([]Issue, error) {
 }
 
 func (c *Client) GetIssueChildren(parentKey string) ([]Issue, error) {
+	return c.GetIssueChildrenContext(context.Background(), parentKey)
+}
+
+// GetIssueChildrenContext is like GetIssueChildren but carries ctx for cancellation.
+func (c *Client) GetIssueChildrenContext(ctx context.Context, parentKey string) ([]Issue, error) {
 	jql := fmt.Sprintf("parent = %s ORDER BY key ASC", parentKey)
 
 	var allIssues []Issue
@@ -634,11 +988,12 @@ func (c *Client) GetIssueChildren(parentKey string) ([]Issue, error) {
 	maxResults := 100
 
 	for {
-		searchResp, err := c.SearchIssuesWithPagination(jql, startAt, maxResults)
+		searchResp, err := c.SearchIssuesWithPaginationContext(ctx, jql, startAt, maxResults)
 		if err != nil {
 			return nil, fmt.Errorf("failed to search for child issues: %w", err)
 		}
 
+		c.resolveEpicLinks(ctx, searchResp.Issues)
 		allIssues = append(allIssues, searchResp.Issues...)
 
 		if startAt+len(searchResp.Issues) >= searchResp.Total {
@@ -653,9 +1008,14 @@ func (c *Client) GetIssueChildren(parentKey string) ([]Issue, error) {
 
 
 func (c *Client) GetCurrentUser() (*User, error) {
+	return c.GetCurrentUserContext(context.Background())
+}
+
+// GetCurrentUserContext is like GetCurrentUser but carries ctx for cancellation.
+func (c *Client) GetCurrentUserContext(ctx context.Context) (*User, error) {
 	endpoint := "/rest/api/2/myself"
 	
-	resp, err := c.makeRequest(context.Background(), "GET", endpoint, nil)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -673,11 +1033,95 @@ func (c *Client) GetCurrentUser() (*User, error) {
 	return &user, nil
 }
 
+// FindUsers searches users by name, email or username.
+func (c *Client) FindUsers(query string) ([]User, error) {
+	return c.FindUsersContext(context.Background(), query)
+}
+
+// FindUsersContext is like FindUsers but carries ctx for cancellation.
+func (c *Client) FindUsersContext(ctx context.Context, query string) ([]User, error) {
+	// Cloud takes "query"; Server and Data Center only accept "username".
+	var users []User
+	for _, param := range []string{"query", "username"} {
+		params := url.Values{}
+		params.Add(param, query)
+		resp, err := c.makeRequest(ctx, "GET", "/rest/api/2/user/search?"+params.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		err = checkResponse(resp, 200, "user search")
+		if err == nil {
+			if derr := json.NewDecoder(resp.Body).Decode(&users); derr != nil {
+				err = fmt.Errorf("failed to decode response: %w", derr)
+			}
+		}
+		resp.Body.Close()
+
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest && param == "query" {
+			continue
+		}
+		return users, err
+	}
+	return users, nil
+}
+
+// ResolveUser turns "me", an account ID, username or email into a user.
+// Searches must match exactly one user.
+func (c *Client) ResolveUser(who string) (*User, error) {
+	return c.ResolveUserContext(context.Background(), who)
+}
+
+// ResolveUserContext is like ResolveUser but carries ctx for cancellation.
+func (c *Client) ResolveUserContext(ctx context.Context, who string) (*User, error) {
+	if strings.EqualFold(who, "me") {
+		user, err := c.GetCurrentUserContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current user: %w", err)
+		}
+		return user, nil
+	}
+
+	users, err := c.FindUsersContext(ctx, who)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up user %q: %w", who, err)
+	}
+	for i, u := range users {
+		if u.AccountID == who || strings.EqualFold(u.Name, who) || strings.EqualFold(u.EmailAddress, who) {
+			return &users[i], nil
+		}
+	}
+	switch len(users) {
+	case 0:
+		return nil, fmt.Errorf("no user matches %q", who)
+	case 1:
+		return &users[0], nil
+	}
+	var names []string
+	for _, u := range users {
+		names = append(names, u.DisplayName)
+	}
+	return nil, fmt.Errorf("%q matches several users: %s", who, strings.Join(names, ", "))
+}
+
+// Ref returns a reference to the user suitable for assignee-like fields.
+func (u *User) Ref() *UserRef {
+	if u.AccountID != "" {
+		return &UserRef{AccountID: u.AccountID}
+	}
+	return &UserRef{Name: u.Name}
+}
+
 // GetTransitions retrieves available transitions for an issue
 func (c *Client) GetTransitions(issueKey string) ([]Transition, error) {
+	return c.GetTransitionsContext(context.Background(), issueKey)
+}
+
+// GetTransitionsContext is like GetTransitions but carries ctx for cancellation.
+func (c *Client) GetTransitionsContext(ctx context.Context, issueKey string) ([]Transition, error) {
 	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/transitions", issueKey)
 
-	resp, err := c.makeRequest(context.Background(), "GET", endpoint, nil)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -697,27 +1141,33 @@ func (c *Client) GetTransitions(issueKey string) ([]Transition, error) {
 
 // TransitionIssue transitions an issue to a new status
 func (c *Client) TransitionIssue(issueKey, transitionID string) error {
+	return c.TransitionIssueContext(context.Background(), issueKey, transitionID)
+}
+
+// TransitionIssueContext is like TransitionIssue but carries ctx for cancellation.
+func (c *Client) TransitionIssueContext(ctx context.Context, issueKey, transitionID string) error {
 	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/transitions", issueKey)
 
 	reqBody := TransitionRequest{
 		Transition: TransitionRef{ID: transitionID},
 	}
 
-	resp, err := c.makeRequest(context.Background(), "POST", endpoint, reqBody)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, reqBody)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == 400 {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("invalid transition - %s", string(bodyBytes))
-	}
 	return checkResponse(resp, 204, "issue "+issueKey+" transition")
 }
 
 // LinkIssues creates a link between two issues
 func (c *Client) LinkIssues(inwardIssue, outwardIssue, linkType string, isInward bool) error {
+	return c.LinkIssuesContext(context.Background(), inwardIssue, outwardIssue, linkType, isInward)
+}
+
+// LinkIssuesContext is like LinkIssues but carries ctx for cancellation.
+func (c *Client) LinkIssuesContext(ctx context.Context, inwardIssue, outwardIssue, linkType string, isInward bool) error {
 	endpoint := "/rest/api/2/issueLink"
 
 	// If the relationship is inward (e.g., "is-blocked-by"), swap the issues
@@ -738,15 +1188,11 @@ func (c *Client) LinkIssues(inwardIssue, outwardIssue, linkType string, isInward
 		},
 	}
 
-	resp, err := c.makeRequest(context.Background(), "POST", endpoint, reqBody)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, reqBody)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == 400 || resp.StatusCode == 404 {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("HTTP %d: link request failed - %s", resp.StatusCode, string(bodyBytes))
-	}
 	return checkResponse(resp, 201, "issue link")
 }
\ No newline at end of file
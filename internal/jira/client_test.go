@@ -0,0 +1,163 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetEpicChildrenContextCancelled(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "me@example.com", "", "token")
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.GetEpicChildrenContext(ctx, "PROJ-1")
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("err = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("request was not cancelled")
+	}
+}
+
+func TestSearchIssuesContextPassesContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"issues":[{"key":"PROJ-1","fields":{"summary":"One"}}],"total":1}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "me@example.com", "", "token")
+	resp, err := c.SearchIssuesContext(context.Background(), "project = PROJ", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Issues) != 1 || resp.Issues[0].Key != "PROJ-1" {
+		t.Fatalf("issues = %+v", resp.Issues)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.SearchIssuesContext(ctx, "project = PROJ", 10); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestSearchIssuesPageFollowsToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/search/jql" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		switch r.URL.Query().Get("nextPageToken") {
+		case "":
+			w.Write([]byte(`{"issues":[{"key":"PROJ-1"}],"nextPageToken":"page-2"}`))
+		case "page-2":
+			w.Write([]byte(`{"issues":[{"key":"PROJ-2"}],"isLast":true}`))
+		default:
+			http.Error(w, "bad token", http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "me@example.com", "", "token")
+	first, err := c.SearchIssuesPageContext(context.Background(), "project = PROJ", "", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.NextPageToken != "page-2" || first.IsLast {
+		t.Fatalf("first page = %+v, want a token to page 2", first)
+	}
+	last, err := c.SearchIssuesPageContext(context.Background(), "project = PROJ", first.NextPageToken, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !last.IsLast || len(last.Issues) != 1 || last.Issues[0].Key != "PROJ-2" {
+		t.Fatalf("last page = %+v", last)
+	}
+}
+
+func TestUpdateIssueOperationsSendsOnlyUpdate(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "me@example.com", "", "token")
+	err := c.UpdateIssueOperations("PROJ-1", map[string][]FieldOperation{"labels": {{"add": "backend"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"update":{"labels":[{"add":"backend"}]}}`; body != want {
+		t.Errorf("body = %s, want %s", body, want)
+	}
+}
+
+func TestDeleteIssueDeletesSubtasks(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Method + " " + r.URL.String()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "me@example.com", "", "token")
+	if err := c.DeleteIssue("PROJ-1"); err != nil {
+		t.Fatal(err)
+	}
+	if want := "DELETE /rest/api/2/issue/PROJ-1?deleteSubtasks=true"; got != want {
+		t.Errorf("request = %s, want %s", got, want)
+	}
+}
+
+func TestResolveUser(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("query") {
+		case "jane":
+			w.Write([]byte(`[{"accountId":"a1","displayName":"Jane Doe","emailAddress":"jane@example.com"},{"accountId":"a2","displayName":"Jane Roe"}]`))
+		case "jane@example.com":
+			w.Write([]byte(`[{"accountId":"a1","displayName":"Jane Doe","emailAddress":"jane@example.com"},{"accountId":"a3","displayName":"Janet"}]`))
+		default:
+			w.Write([]byte(`[]`))
+		}
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL, "me@example.com", "", "token")
+
+	user, err := c.ResolveUser("jane@example.com")
+	if err != nil || user.AccountID != "a1" {
+		t.Errorf("exact email: user = %+v, err = %v", user, err)
+	}
+	if _, err := c.ResolveUser("jane"); err == nil || !strings.Contains(err.Error(), "Jane Doe, Jane Roe") {
+		t.Errorf("ambiguous: err = %v", err)
+	}
+	if _, err := c.ResolveUser("nobody"); err == nil {
+		t.Error("no match not reported")
+	}
+}
@@ -0,0 +1,349 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// CreateMeta describes the fields of the create screen for one project and
+// issue type.
+type CreateMeta struct {
+	Project   string
+	IssueType CreateMetaIssueType
+	Fields    []CreateMetaField
+}
+
+// CreateMetaIssueType is an issue type that can be created in a project.
+type CreateMetaIssueType struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Subtask bool   `json:"subtask"`
+}
+
+// CreateMetaField is one field of a create screen.
+type CreateMetaField struct {
+	FieldID         string         `json:"fieldId"`
+	Key             string         `json:"key"`
+	Name            string         `json:"name"`
+	Required        bool           `json:"required"`
+	HasDefaultValue bool           `json:"hasDefaultValue"`
+	Schema          FieldSchema    `json:"schema"`
+	AllowedValues   []AllowedValue `json:"allowedValues"`
+}
+
+// AllowedValue is one permitted value of a field, such as a priority,
+// component, version or select-list option.
+type AllowedValue struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	Key   string `json:"key"`
+}
+
+// Label returns the value's human-readable name.
+func (v AllowedValue) Label() string {
+	switch {
+	case v.Name != "":
+		return v.Name
+	case v.Value != "":
+		return v.Value
+	}
+	return v.Key
+}
+
+// Field returns the create-screen field with the given ID, or nil.
+func (m *CreateMeta) Field(id string) *CreateMetaField {
+	for i := range m.Fields {
+		if m.Fields[i].FieldID == id {
+			return &m.Fields[i]
+		}
+	}
+	return nil
+}
+
+// Missing returns the required fields without a default that fields does
+// not set, in screen order.
+func (m *CreateMeta) Missing(fields CreateIssueFields) ([]CreateMetaField, error) {
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	var set map[string]json.RawMessage
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+	var missing []CreateMetaField
+	for _, f := range m.Fields {
+		if !f.Required || f.HasDefaultValue {
+			continue
+		}
+		raw, ok := set[f.FieldID]
+		if !ok || string(raw) == `""` || string(raw) == "null" || string(raw) == "[]" {
+			missing = append(missing, f)
+		}
+	}
+	return missing, nil
+}
+
+// Normalize checks the priority, components and fix versions in fields
+// against the allowed values and rewrites them to their canonical names.
+// Fields missing from the create screen are reported as errors.
+func (m *CreateMeta) Normalize(fields *CreateIssueFields) error {
+	var errs []error
+	one := func(id string, ref *NameRef) {
+		if ref == nil {
+			return
+		}
+		f := m.Field(id)
+		if f == nil {
+			errs = append(errs, fmt.Errorf("%s cannot be set when creating a %s in %s", id, m.IssueType.Name, m.Project))
+			return
+		}
+		v, err := f.Match(ref.Name)
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		ref.Name = v.Label()
+	}
+	one("priority", fields.Priority)
+	for i := range fields.Components {
+		one("components", &fields.Components[i])
+	}
+	for i := range fields.FixVersions {
+		one("fixVersions", &fields.FixVersions[i])
+	}
+	if len(fields.Labels) > 0 && m.Field("labels") == nil {
+		errs = append(errs, fmt.Errorf("labels cannot be set when creating a %s in %s", m.IssueType.Name, m.Project))
+	}
+	return errors.Join(errs...)
+}
+
+// Match finds the allowed value for input: an exact case-insensitive match
+// on the name, value or ID, else a unique prefix. Fields without allowed
+// values accept anything.
+func (f *CreateMetaField) Match(input string) (AllowedValue, error) {
+	input = strings.TrimSpace(input)
+	if len(f.AllowedValues) == 0 {
+		return AllowedValue{Name: input}, nil
+	}
+	for _, v := range f.AllowedValues {
+		if strings.EqualFold(v.Label(), input) || v.ID == input {
+			return v, nil
+		}
+	}
+	var matches []AllowedValue
+	for _, v := range f.AllowedValues {
+		if strings.HasPrefix(strings.ToLower(v.Label()), strings.ToLower(input)) {
+			matches = append(matches, v)
+		}
+	}
+	if len(matches) == 1 && input != "" {
+		return matches[0], nil
+	}
+	return AllowedValue{}, fmt.Errorf("invalid %s %q (allowed: %s)", strings.ToLower(f.Name), input, strings.Join(f.Labels(), ", "))
+}
+
+// Labels returns the names of the allowed values.
+func (f *CreateMetaField) Labels() []string {
+	labels := make([]string, len(f.AllowedValues))
+	for i, v := range f.AllowedValues {
+		labels[i] = v.Label()
+	}
+	return labels
+}
+
+// Complete returns the allowed values starting with prefix, for shell
+// completion.
+func (f *CreateMetaField) Complete(prefix string) []string {
+	var out []string
+	for _, l := range f.Labels() {
+		if strings.HasPrefix(strings.ToLower(l), strings.ToLower(prefix)) {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// IsArray reports whether the field takes several values.
+func (f *CreateMetaField) IsArray() bool { return f.Schema.Type == "array" }
+
+// GetCreateMeta returns the create screen for an issue type (by name) in a
+// project. Servers without the per-type createmeta endpoints fall back to
+// the older expanded createmeta query.
+func (c *Client) GetCreateMeta(projectKey, issueType string) (*CreateMeta, error) {
+	return c.GetCreateMetaContext(context.Background(), projectKey, issueType)
+}
+
+// GetCreateMetaContext is like GetCreateMeta but carries ctx for cancellation.
+func (c *Client) GetCreateMetaContext(ctx context.Context, projectKey, issueType string) (*CreateMeta, error) {
+	types, err := c.GetCreateMetaIssueTypesContext(ctx, projectKey)
+	if errors.Is(err, ErrNotFound) {
+		return c.legacyCreateMeta(ctx, projectKey, issueType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &CreateMeta{Project: projectKey}
+	var names []string
+	for _, t := range types {
+		names = append(names, t.Name)
+		if strings.EqualFold(t.Name, issueType) || t.ID == issueType {
+			meta.IssueType = t
+		}
+	}
+	if meta.IssueType.ID == "" {
+		return nil, fmt.Errorf("issue type %q is not available in project %s (available: %s)", issueType, projectKey, strings.Join(names, ", "))
+	}
+
+	endpoint := fmt.Sprintf("/rest/api/2/issue/createmeta/%s/issuetypes/%s", url.PathEscape(projectKey), url.PathEscape(meta.IssueType.ID))
+	err = c.createMetaPages(ctx, endpoint, "create fields for "+projectKey+" "+meta.IssueType.Name, func(raw json.RawMessage) error {
+		var f CreateMetaField
+		if err := json.Unmarshal(raw, &f); err != nil {
+			return err
+		}
+		meta.Fields = append(meta.Fields, f)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// GetCreateMetaIssueTypes lists the issue types that can be created in a
+// project.
+func (c *Client) GetCreateMetaIssueTypes(projectKey string) ([]CreateMetaIssueType, error) {
+	return c.GetCreateMetaIssueTypesContext(context.Background(), projectKey)
+}
+
+// GetCreateMetaIssueTypesContext is like GetCreateMetaIssueTypes but carries ctx for cancellation.
+func (c *Client) GetCreateMetaIssueTypesContext(ctx context.Context, projectKey string) ([]CreateMetaIssueType, error) {
+	var types []CreateMetaIssueType
+	endpoint := fmt.Sprintf("/rest/api/2/issue/createmeta/%s/issuetypes", url.PathEscape(projectKey))
+	err := c.createMetaPages(ctx, endpoint, "issue types for project "+projectKey, func(raw json.RawMessage) error {
+		var t CreateMetaIssueType
+		if err := json.Unmarshal(raw, &t); err != nil {
+			return err
+		}
+		types = append(types, t)
+		return nil
+	})
+	return types, err
+}
+
+// createMetaPage is one page of a createmeta listing. Data Center returns
+// the items as "values"; Cloud as "issueTypes" or "fields".
+type createMetaPage struct {
+	Total      int               `json:"total"`
+	IsLast     *bool             `json:"isLast"`
+	Values     []json.RawMessage `json:"values"`
+	IssueTypes []json.RawMessage `json:"issueTypes"`
+	Fields     []json.RawMessage `json:"fields"`
+}
+
+func (p createMetaPage) items() []json.RawMessage {
+	items := append([]json.RawMessage(nil), p.Values...)
+	items = append(items, p.IssueTypes...)
+	return append(items, p.Fields...)
+}
+
+// createMetaPages calls each for every item of a paginated createmeta listing.
+func (c *Client) createMetaPages(ctx context.Context, endpoint, resource string, each func(json.RawMessage) error) error {
+	startAt := 0
+	for {
+		params := url.Values{}
+		params.Add("startAt", fmt.Sprintf("%d", startAt))
+		params.Add("maxResults", "50")
+
+		page, err := c.getCreateMetaPage(ctx, endpoint+"?"+params.Encode(), resource)
+		if err != nil {
+			return err
+		}
+		items := page.items()
+		for _, raw := range items {
+			if err := each(raw); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+		}
+
+		if len(items) == 0 || (page.IsLast != nil && *page.IsLast) || startAt+len(items) >= page.Total {
+			return nil
+		}
+		startAt += len(items)
+	}
+}
+
+func (c *Client) getCreateMetaPage(ctx context.Context, endpoint, resource string) (*createMetaPage, error) {
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, 200, resource); err != nil {
+		return nil, err
+	}
+
+	var page createMetaPage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &page, nil
+}
+
+// legacyCreateMeta uses /rest/api/2/issue/createmeta with expanded fields,
+// the only form offered by older Jira servers.
+func (c *Client) legacyCreateMeta(ctx context.Context, projectKey, issueType string) (*CreateMeta, error) {
+	params := url.Values{}
+	params.Add("projectKeys", projectKey)
+	params.Add("expand", "projects.issuetypes.fields")
+
+	resp, err := c.makeRequest(ctx, "GET", "/rest/api/2/issue/createmeta?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, 200, "create metadata for project "+projectKey); err != nil {
+		return nil, err
+	}
+
+	var legacy struct {
+		Projects []struct {
+			Key        string `json:"key"`
+			IssueTypes []struct {
+				CreateMetaIssueType
+				Fields map[string]CreateMetaField `json:"fields"`
+			} `json:"issuetypes"`
+		} `json:"projects"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&legacy); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(legacy.Projects) == 0 {
+		return nil, fmt.Errorf("project %s not found or you cannot create issues in it", projectKey)
+	}
+
+	var names []string
+	for _, t := range legacy.Projects[0].IssueTypes {
+		names = append(names, t.Name)
+		if !strings.EqualFold(t.Name, issueType) && t.ID != issueType {
+			continue
+		}
+		meta := &CreateMeta{Project: projectKey, IssueType: t.CreateMetaIssueType}
+		for id, f := range t.Fields {
+			f.FieldID = id
+			meta.Fields = append(meta.Fields, f)
+		}
+		sort.Slice(meta.Fields, func(i, j int) bool { return meta.Fields[i].Name < meta.Fields[j].Name })
+		return meta, nil
+	}
+	return nil, fmt.Errorf("issue type %q is not available in project %s (available: %s)", issueType, projectKey, strings.Join(names, ", "))
+}
@@ -0,0 +1,147 @@
+package jira
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetCreateMetaPaginated(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/issue/createmeta/PROJ/issuetypes":
+			w.Write([]byte(`{"issueTypes":[{"id":"1","name":"Bug"},{"id":"2","name":"Task"}],"total":2}`))
+		case "/rest/api/2/issue/createmeta/PROJ/issuetypes/2":
+			if r.URL.Query().Get("startAt") == "0" {
+				w.Write([]byte(`{"fields":[
+					{"fieldId":"summary","name":"Summary","required":true,"schema":{"type":"string"}},
+					{"fieldId":"priority","name":"Priority","required":true,"hasDefaultValue":true,"schema":{"type":"priority"},
+					 "allowedValues":[{"id":"1","name":"Highest"},{"id":"2","name":"High"},{"id":"3","name":"Low"}]}
+				],"total":3,"isLast":false}`))
+				return
+			}
+			w.Write([]byte(`{"fields":[
+				{"fieldId":"components","name":"Components","required":true,"schema":{"type":"array","items":"component"},
+				 "allowedValues":[{"id":"10","name":"Backend"},{"id":"11","name":"Frontend"}]}
+			],"total":3,"isLast":true}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "me@example.com", "", "token")
+	meta, err := c.GetCreateMeta("PROJ", "task")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.IssueType.ID != "2" || len(meta.Fields) != 3 {
+		t.Fatalf("meta = %+v", meta)
+	}
+
+	fields := CreateIssueFields{
+		Project:   ProjectRef{Key: "PROJ"},
+		Summary:   "Hello",
+		IssueType: IssueTypeRef{Name: "Task"},
+	}
+	missing, err := meta.Missing(fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(missing) != 1 || missing[0].FieldID != "components" {
+		t.Errorf("Missing = %+v", missing)
+	}
+
+	fields.Components = []NameRef{{Name: "back"}}
+	if missing, _ := meta.Missing(fields); len(missing) != 0 {
+		t.Errorf("Missing with components = %+v", missing)
+	}
+
+	if _, err := c.GetCreateMeta("PROJ", "Epic"); err == nil || !strings.Contains(err.Error(), "Bug, Task") {
+		t.Errorf("unknown issue type err = %v", err)
+	}
+}
+
+func TestCreateMetaNormalize(t *testing.T) {
+	meta := &CreateMeta{
+		Project:   "PROJ",
+		IssueType: CreateMetaIssueType{Name: "Task"},
+		Fields: []CreateMetaField{
+			{FieldID: "priority", Name: "Priority", AllowedValues: []AllowedValue{{Name: "Highest"}, {Name: "High"}, {Name: "Low"}}},
+			{FieldID: "components", Name: "Components", AllowedValues: []AllowedValue{{Name: "Backend"}, {Name: "Frontend"}}},
+		},
+	}
+
+	fields := CreateIssueFields{
+		Priority:   &NameRef{Name: "high"},
+		Components: []NameRef{{Name: "front"}},
+	}
+	if err := meta.Normalize(&fields); err != nil {
+		t.Fatal(err)
+	}
+	if fields.Priority.Name != "High" || fields.Components[0].Name != "Frontend" {
+		t.Errorf("normalized = %+v %+v", fields.Priority, fields.Components)
+	}
+
+	// "Hi" is a prefix of both Highest and High.
+	fields = CreateIssueFields{Priority: &NameRef{Name: "Hi"}, Labels: []string{"x"}}
+	err := meta.Normalize(&fields)
+	if err == nil || !strings.Contains(err.Error(), "allowed: Highest, High, Low") {
+		t.Errorf("ambiguous priority err = %v", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "labels cannot be set") {
+		t.Errorf("labels off-screen err = %v", err)
+	}
+}
+
+func TestGetCreateMetaLegacyFallback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/issue/createmeta" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("expand") != "projects.issuetypes.fields" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"projects":[{"key":"PROJ","issuetypes":[
+			{"id":"3","name":"Story","fields":{
+				"summary":{"name":"Summary","required":true,"schema":{"type":"string"}},
+				"customfield_10020":{"name":"Team","required":true,"schema":{"type":"option"},"allowedValues":[{"id":"100","value":"Platform"}]}
+			}}
+		]}]}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "me@example.com", "", "token")
+	meta, err := c.GetCreateMeta("PROJ", "Story")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := meta.Field("customfield_10020")
+	if f == nil || f.Name != "Team" {
+		t.Fatalf("fields = %+v", meta.Fields)
+	}
+	if v, err := f.Match("plat"); err != nil || v.ID != "100" {
+		t.Errorf("Match = %+v, %v", v, err)
+	}
+	if got := f.Complete("P"); len(got) != 1 || got[0] != "Platform" {
+		t.Errorf("Complete = %v", got)
+	}
+}
+
+func TestGetCreateMetaForbidden(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"errorMessages":["You cannot create issues here"]}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "me@example.com", "", "token")
+	_, err := c.GetCreateMeta("PROJ", "Task")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !errors.Is(err, ErrForbidden) {
+		t.Errorf("err = %v", err)
+	}
+}
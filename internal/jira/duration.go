@@ -0,0 +1,107 @@
+package jira
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DurationUnits defines how Jira's day and week units convert to hours; Jira
+// defaults to 8-hour days and 5-day weeks.
+type DurationUnits struct {
+	HoursPerDay float64
+	DaysPerWeek float64
+}
+
+// DefaultDurationUnits are Jira's default working-time units.
+var DefaultDurationUnits = DurationUnits{HoursPerDay: 8, DaysPerWeek: 5}
+
+var durationPart = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([wdhm])`)
+
+// ParseDuration parses a Jira duration such as "1h30m", "1h 30m", "2d",
+// "1.5h" or "1w 2d" using the default units. A bare number is minutes, as in
+// Jira.
+func ParseDuration(s string) (time.Duration, error) {
+	return DefaultDurationUnits.Parse(s)
+}
+
+// Parse parses a Jira duration using u for days and weeks.
+func (u DurationUnits) Parse(s string) (time.Duration, error) {
+	in := strings.ToLower(strings.TrimSpace(s))
+	if in == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+	if n, err := strconv.ParseFloat(in, 64); err == nil {
+		return u.check(s, time.Duration(n*float64(time.Minute)))
+	}
+
+	var total float64
+	seen := map[string]bool{}
+	for rest := in; rest != ""; rest = strings.TrimLeft(rest, " ") {
+		m := durationPart.FindStringSubmatch(rest)
+		if m == nil || seen[m[2]] {
+			return 0, fmt.Errorf("invalid duration %q: use units w, d, h and m, e.g. 1h30m or 2d", s)
+		}
+		seen[m[2]] = true
+		n, _ := strconv.ParseFloat(m[1], 64)
+		switch m[2] {
+		case "w":
+			total += n * u.DaysPerWeek * u.HoursPerDay * float64(time.Hour)
+		case "d":
+			total += n * u.HoursPerDay * float64(time.Hour)
+		case "h":
+			total += n * float64(time.Hour)
+		case "m":
+			total += n * float64(time.Minute)
+		}
+		rest = rest[len(m[0]):]
+	}
+	return u.check(s, time.Duration(total))
+}
+
+// check rejects durations Jira would not accept for a worklog.
+func (u DurationUnits) check(s string, d time.Duration) (time.Duration, error) {
+	if d < time.Minute {
+		return 0, fmt.Errorf("invalid duration %q: must be at least 1m", s)
+	}
+	return d.Round(time.Minute), nil
+}
+
+// FormatDuration renders d in Jira's style, e.g. "1d 2h 30m", using the
+// default units.
+func FormatDuration(d time.Duration) string {
+	return DefaultDurationUnits.Format(d)
+}
+
+// Format renders d in Jira's style using u for days and weeks. Durations
+// under a minute are "0m".
+func (u DurationUnits) Format(d time.Duration) string {
+	minutes := int64(d.Round(time.Minute) / time.Minute)
+	if minutes <= 0 {
+		return "0m"
+	}
+	perDay := int64(u.HoursPerDay * 60)
+	perWeek := int64(u.DaysPerWeek * float64(perDay))
+
+	var parts []string
+	for _, unit := range []struct {
+		size int64
+		name string
+	}{{perWeek, "w"}, {perDay, "d"}, {60, "h"}, {1, "m"}} {
+		if unit.size <= 0 || minutes < unit.size {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%d%s", minutes/unit.size, unit.name))
+		minutes %= unit.size
+	}
+	return strings.Join(parts, " ")
+}
+
+// FormatHours renders d as hours with up to two decimals, e.g. "1.5h", for
+// reports that add durations up.
+func FormatHours(d time.Duration) string {
+	return strconv.FormatFloat(math.Round(d.Hours()*100)/100, 'f', -1, 64) + "h"
+}
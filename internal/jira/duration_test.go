@@ -0,0 +1,52 @@
+package jira
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	for in, want := range map[string]time.Duration{
+		"1h30m":  90 * time.Minute,
+		"1h 30m": 90 * time.Minute,
+		"90m":    90 * time.Minute,
+		"1.5h":   90 * time.Minute,
+		"45":     45 * time.Minute,
+		"2d":     16 * time.Hour,
+		"1w 1d":  48 * time.Hour,
+		" 2H ":   2 * time.Hour,
+	} {
+		got, err := ParseDuration(in)
+		if err != nil || got != want {
+			t.Errorf("ParseDuration(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "1x", "h", "1h 2h", "30s", "0m", "1h-30m"} {
+		if _, err := ParseDuration(in); err == nil {
+			t.Errorf("ParseDuration(%q) did not fail", in)
+		}
+	}
+
+	short := DurationUnits{HoursPerDay: 7.5, DaysPerWeek: 4}
+	if got, _ := short.Parse("1w"); got != 30*time.Hour {
+		t.Errorf("custom units 1w = %v", got)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		0:                            "0m",
+		45 * time.Minute:             "45m",
+		90 * time.Minute:             "1h 30m",
+		10 * time.Hour:               "1d 2h",
+		41*time.Hour + time.Minute:   "1w 1h 1m",
+		8*time.Hour + 29*time.Second: "1d",
+	} {
+		if got := FormatDuration(d); got != want {
+			t.Errorf("FormatDuration(%v) = %q; want %q", d, got, want)
+		}
+	}
+	if got := FormatHours(100 * time.Minute); got != "1.67h" {
+		t.Errorf("FormatHours = %q", got)
+	}
+}
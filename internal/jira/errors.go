@@ -0,0 +1,126 @@
+package jira
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"jet/internal/httpclient"
+)
+
+// Sentinel errors matched by *APIError through errors.Is.
+var (
+	ErrUnauthorized = errors.New("authentication failed")
+	ErrForbidden    = errors.New("permission denied")
+	ErrNotFound     = errors.New("not found")
+	ErrRateLimited  = errors.New("rate limited")
+)
+
+// maxErrorBody caps how much of an error response is read.
+const maxErrorBody = 64 << 10
+
+// APIError is a failed Jira REST call. Jira reports problems as
+// {"errorMessages": [...], "errors": {"field": "message"}}; both are kept so
+// callers can show which field was rejected.
+type APIError struct {
+	StatusCode  int
+	Method      string
+	URL         string
+	Resource    string            // what was requested, e.g. "issue PROJ-1"
+	Messages    []string          // errorMessages
+	FieldErrors map[string]string // errors, keyed by field ID
+	RateLimit   string            // rate-limit summary for 429 responses
+}
+
+// Error returns a one-line description followed by Jira's messages.
+func (e *APIError) Error() string {
+	var msg string
+	switch e.StatusCode {
+	case http.StatusUnauthorized:
+		msg = "authentication failed - check your credentials"
+	case http.StatusForbidden:
+		msg = fmt.Sprintf("access denied to %s", e.Resource)
+	case http.StatusNotFound:
+		msg = fmt.Sprintf("%s not found", e.Resource)
+	case http.StatusTooManyRequests:
+		msg = fmt.Sprintf("rate limited while requesting %s", e.Resource)
+		if e.RateLimit != "" {
+			msg += " (" + e.RateLimit + ")"
+		}
+	default:
+		msg = fmt.Sprintf("HTTP %d: request failed for %s", e.StatusCode, e.Resource)
+	}
+	if details := e.Details(); len(details) > 0 {
+		msg += ": " + strings.Join(details, "; ")
+	}
+	return msg
+}
+
+// Details returns Jira's error messages followed by the field errors as
+// "field: message", sorted by field.
+func (e *APIError) Details() []string {
+	details := append([]string(nil), e.Messages...)
+	fields := make([]string, 0, len(e.FieldErrors))
+	for f := range e.FieldErrors {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	for _, f := range fields {
+		details = append(details, f+": "+e.FieldErrors[f])
+	}
+	return details
+}
+
+// Unwrap returns the sentinel error for the status code, if any.
+func (e *APIError) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	}
+	return nil
+}
+
+// newAPIError builds an *APIError from a failed response, decoding Jira's
+// error body when present.
+func newAPIError(resp *http.Response, resource string) *APIError {
+	e := &APIError{StatusCode: resp.StatusCode, Resource: resource}
+	if resp.Request != nil {
+		e.Method = resp.Request.Method
+		if resp.Request.URL != nil {
+			e.URL = resp.Request.URL.String()
+		}
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		e.RateLimit = httpclient.ParseRateLimit(resp.Header).String()
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	var decoded struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+	}
+	if json.Unmarshal(body, &decoded) == nil {
+		e.Messages = decoded.ErrorMessages
+		if len(decoded.Errors) > 0 {
+			e.FieldErrors = decoded.Errors
+		}
+		return e
+	}
+
+	// Not Jira's JSON (e.g. a proxy error); keep short plain-text bodies.
+	text := strings.TrimSpace(string(body))
+	if text != "" && !strings.HasPrefix(text, "<") && len(text) <= 200 && !strings.Contains(text, "\n") {
+		e.Messages = []string{text}
+	}
+	return e
+}
@@ -0,0 +1,87 @@
+package jira
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCreateIssueFieldErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errorMessages":[],"errors":{"priority":"Priority name 'Urgent' is not valid","summary":"You must specify a summary of the issue."}}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "me@example.com", "", "token")
+	_, err := c.CreateIssue("PROJ", "", "", "Task", "")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v (%T), want *APIError", err, err)
+	}
+	if apiErr.StatusCode != 400 || apiErr.Method != "POST" || !strings.HasSuffix(apiErr.URL, "/rest/api/2/issue") {
+		t.Errorf("unexpected error fields: %+v", apiErr)
+	}
+	if got := apiErr.FieldErrors["summary"]; got != "You must specify a summary of the issue." {
+		t.Errorf("summary field error = %q", got)
+	}
+	want := "HTTP 400: request failed for issue creation: priority: Priority name 'Urgent' is not valid; summary: You must specify a summary of the issue."
+	if err.Error() != want {
+		t.Errorf("Error() =\n  %s\nwant\n  %s", err.Error(), want)
+	}
+}
+
+func TestAPIErrorSentinels(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+		text   string
+	}{
+		{401, ErrUnauthorized, "authentication failed - check your credentials"},
+		{403, ErrForbidden, "access denied to issue PROJ-1"},
+		{404, ErrNotFound, "issue PROJ-1 not found: Issue does not exist or you do not have permission to see it."},
+	}
+	for _, tt := range tests {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			if tt.status == 404 {
+				w.Write([]byte(`{"errorMessages":["Issue does not exist or you do not have permission to see it."],"errors":{}}`))
+			}
+		}))
+		c := NewClient(srv.URL, "me@example.com", "", "token")
+		_, err := c.GetIssue("PROJ-1")
+		srv.Close()
+
+		if !errors.Is(err, tt.want) {
+			t.Errorf("status %d: errors.Is(%v, %v) = false", tt.status, err, tt.want)
+		}
+		if err == nil || err.Error() != tt.text {
+			t.Errorf("status %d: Error() = %v, want %q", tt.status, err, tt.text)
+		}
+	}
+}
+
+func TestAPIErrorPlainTextBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Bad JQL near 'ORDER'"))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "me@example.com", "", "token")
+	_, err := c.SearchIssues("project = ", 10)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want *APIError", err)
+	}
+	if len(apiErr.Messages) != 1 || apiErr.Messages[0] != "Bad JQL near 'ORDER'" {
+		t.Errorf("Messages = %q", apiErr.Messages)
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Error("400 must not match ErrNotFound")
+	}
+}
@@ -0,0 +1,426 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fieldCacheTTL is how long a site's field list is reused from disk.
+const fieldCacheTTL = 24 * time.Hour
+
+// fieldErrorTTL is how long a failed field lookup is remembered.
+const fieldErrorTTL = 30 * time.Second
+
+// epicLinkSchema is the schema type of the classic "Epic Link" custom field.
+const epicLinkSchema = "com.pyxis.greenhopper.jira:gh-epic-link"
+
+// Field describes a system or custom field as returned by /rest/api/2/field.
+type Field struct {
+	ID     string      `json:"id"`
+	Key    string      `json:"key,omitempty"`
+	Name   string      `json:"name"`
+	Custom bool        `json:"custom"`
+	Schema FieldSchema `json:"schema"`
+}
+
+// FieldSchema is the type information of a field. Type is e.g. "string",
+// "number", "option", "user" or "array"; Items is the element type of arrays;
+// Custom is the plugin type of custom fields.
+type FieldSchema struct {
+	Type   string `json:"type,omitempty"`
+	Items  string `json:"items,omitempty"`
+	Custom string `json:"custom,omitempty"`
+}
+
+// FieldRegistry maps field names to IDs for one Jira site.
+type FieldRegistry struct {
+	Fields []Field
+
+	// users looks up the values of user fields; Client.FieldRegistry sets
+	// it to the client's ResolveUserContext.
+	users UserLookup
+}
+
+// UserLookup finds the user a command-line value names: "me", an account
+// ID, a username or an email, as Client.ResolveUserContext does.
+type UserLookup func(ctx context.Context, who string) (*User, error)
+
+// Lookup finds a field by ID (e.g. "customfield_10016"), key or name. Names
+// match case-insensitively; a name shared by several fields is an error
+// listing their IDs so the caller can use one directly.
+func (r *FieldRegistry) Lookup(nameOrID string) (*Field, error) {
+	want := strings.TrimSpace(nameOrID)
+	for i := range r.Fields {
+		if r.Fields[i].ID == want || (r.Fields[i].Key != "" && r.Fields[i].Key == want) {
+			return &r.Fields[i], nil
+		}
+	}
+	var matches []*Field
+	for i := range r.Fields {
+		if strings.EqualFold(r.Fields[i].Name, want) {
+			matches = append(matches, &r.Fields[i])
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("unknown field %q (run 'jet fields' to list available fields)", want)
+	case 1:
+		return matches[0], nil
+	}
+	ids := make([]string, len(matches))
+	for i, f := range matches {
+		ids[i] = f.ID
+	}
+	return nil, fmt.Errorf("field name %q is ambiguous; use one of the IDs: %s", want, strings.Join(ids, ", "))
+}
+
+// Name returns the display name of a field ID, or the ID itself if unknown.
+func (r *FieldRegistry) Name(id string) string {
+	for _, f := range r.Fields {
+		if f.ID == id {
+			return f.Name
+		}
+	}
+	return id
+}
+
+// EpicLinkID returns the ID of the site's Epic Link field, or "" if the site
+// has none (team-managed projects use parent instead).
+func (r *FieldRegistry) EpicLinkID() string {
+	for _, f := range r.Fields {
+		if f.Schema.Custom == epicLinkSchema {
+			return f.ID
+		}
+	}
+	for _, f := range r.Fields {
+		if f.Custom && strings.EqualFold(f.Name, "Epic Link") {
+			return f.ID
+		}
+	}
+	return ""
+}
+
+// StoryPointsID returns the ID of the site's story points field, or "" if
+// it has none. Company-managed projects use "Story Points", team-managed
+// ones "Story point estimate".
+func (r *FieldRegistry) StoryPointsID() string {
+	for _, name := range []string{"Story Points", "Story point estimate"} {
+		for _, f := range r.Fields {
+			if f.Custom && strings.EqualFold(f.Name, name) {
+				return f.ID
+			}
+		}
+	}
+	return ""
+}
+
+// ParseFieldValues turns "Name=Value" assignments into an update map keyed
+// by field ID, converting each value to the shape the field's schema expects.
+func (r *FieldRegistry) ParseFieldValues(assignments []string) (map[string]interface{}, error) {
+	return r.ParseFieldValuesContext(context.Background(), assignments)
+}
+
+// ParseFieldValuesContext is like ParseFieldValues but carries ctx for the
+// lookups of user fields.
+func (r *FieldRegistry) ParseFieldValuesContext(ctx context.Context, assignments []string) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(assignments))
+	for _, a := range assignments {
+		name, raw, ok := strings.Cut(a, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid field %q: expected NAME=VALUE", a)
+		}
+		f, err := r.Lookup(name)
+		if err != nil {
+			return nil, err
+		}
+		v, err := f.Value(ctx, strings.TrimSpace(raw), r.users)
+		if err != nil {
+			return nil, err
+		}
+		values[f.ID] = v
+	}
+	return values, nil
+}
+
+// Value converts a command-line string to the JSON value Jira expects for
+// the field. A value that is itself a JSON object or array is sent as is.
+// Array fields take comma-separated values. Users are looked up with users
+// and referred to as User.Ref does, so both Cloud and Data Center take them.
+func (f *Field) Value(ctx context.Context, raw string, users UserLookup) (interface{}, error) {
+	if strings.HasPrefix(raw, "{") || strings.HasPrefix(raw, "[") {
+		if json.Valid([]byte(raw)) {
+			return json.RawMessage(raw), nil
+		}
+	}
+	if f.Schema.Type == "array" {
+		var items []interface{}
+		for _, part := range strings.Split(raw, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			v, err := scalarValue(ctx, f, f.Schema.Items, part, users)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+		}
+		if items == nil {
+			items = []interface{}{}
+		}
+		return items, nil
+	}
+	return scalarValue(ctx, f, f.Schema.Type, raw, users)
+}
+
+func scalarValue(ctx context.Context, f *Field, typ, raw string, users UserLookup) (interface{}, error) {
+	switch typ {
+	case "number":
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("field %q expects a number, got %q", f.Name, raw)
+		}
+		return n, nil
+	case "option":
+		return map[string]string{"value": raw}, nil
+	case "user":
+		if users == nil {
+			return nil, fmt.Errorf("field %q: cannot look up user %q", f.Name, raw)
+		}
+		user, err := users(ctx, raw)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", f.Name, err)
+		}
+		return user.Ref(), nil
+	case "priority", "issuetype", "component", "version", "resolution", "group":
+		return map[string]string{"name": raw}, nil
+	case "project":
+		return map[string]string{"key": raw}, nil
+	case "json":
+		// Sprint and similar fields take a numeric ID.
+		if n, err := strconv.Atoi(raw); err == nil {
+			return n, nil
+		}
+	}
+	return raw, nil
+}
+
+// FormatFieldValue renders a raw field value for display: names, values and
+// display names are extracted from objects, and arrays are comma-joined.
+func FormatFieldValue(raw json.RawMessage) string {
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return string(raw)
+	}
+	if m, ok := v.(map[string]interface{}); ok && m["type"] == "doc" {
+		return ADFToText(raw)
+	}
+	return formatValue(v)
+}
+
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			if s := formatValue(item); s != "" {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, ", ")
+	case map[string]interface{}:
+		for _, k := range []string{"displayName", "value", "name", "key"} {
+			if s, ok := v[k].(string); ok && s != "" {
+				if child, ok := v["child"].(map[string]interface{}); ok {
+					return s + " / " + formatValue(child)
+				}
+				return s
+			}
+		}
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+	return fmt.Sprint(v)
+}
+
+// GetFields lists every system and custom field on the site.
+func (c *Client) GetFields() ([]Field, error) {
+	return c.GetFieldsContext(context.Background())
+}
+
+// GetFieldsContext is like GetFields but carries ctx for cancellation.
+func (c *Client) GetFieldsContext(ctx context.Context) ([]Field, error) {
+	resp, err := c.makeRequest(ctx, "GET", "/rest/api/2/field", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, 200, "field list"); err != nil {
+		return nil, err
+	}
+
+	var fields []Field
+	if err := json.NewDecoder(resp.Body).Decode(&fields); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	sort.SliceStable(fields, func(i, j int) bool { return fields[i].Name < fields[j].Name })
+	return fields, nil
+}
+
+// FieldRegistry returns the site's fields, fetched once per client and cached
+// in CacheDir for a day.
+func (c *Client) FieldRegistry(ctx context.Context) (*FieldRegistry, error) {
+	c.fieldsMu.Lock()
+	defer c.fieldsMu.Unlock()
+	if c.fields != nil {
+		return c.fields, nil
+	}
+	if c.fieldsErr != nil && time.Since(c.fieldsErrAt) < fieldErrorTTL {
+		return nil, c.fieldsErr
+	}
+
+	if fields, ok := c.readFieldCache(); ok {
+		c.fields = &FieldRegistry{Fields: fields, users: c.ResolveUserContext}
+		return c.fields, nil
+	}
+	fields, err := c.GetFieldsContext(ctx)
+	if err != nil {
+		// Remember real failures for a while so every request doesn't
+		// retry the lookup, but not for good: the next try may work.
+		if ctx.Err() == nil {
+			c.fieldsErr, c.fieldsErrAt = err, time.Now()
+		}
+		return nil, err
+	}
+	c.writeFieldCache(fields)
+	c.fields = &FieldRegistry{Fields: fields, users: c.ResolveUserContext}
+	return c.fields, nil
+}
+
+// RefreshFieldRegistry discards cached fields and fetches them again.
+func (c *Client) RefreshFieldRegistry(ctx context.Context) (*FieldRegistry, error) {
+	c.fieldsMu.Lock()
+	c.fields, c.fieldsErr = nil, nil
+	if path := c.fieldCachePath(); path != "" {
+		os.Remove(path)
+	}
+	c.fieldsMu.Unlock()
+	return c.FieldRegistry(ctx)
+}
+
+// epicLinkField returns the Epic Link field ID, or "" when it cannot be
+// discovered; callers then simply go without epic links.
+func (c *Client) epicLinkField(ctx context.Context) string {
+	reg, err := c.FieldRegistry(ctx)
+	if err != nil {
+		return ""
+	}
+	return reg.EpicLinkID()
+}
+
+// withEpicLink appends the site's Epic Link field to a fields parameter.
+func (c *Client) withEpicLink(ctx context.Context, fields string) string {
+	if id := c.epicLinkField(ctx); id != "" {
+		return fields + "," + id
+	}
+	return fields
+}
+
+// resolveEpicLinks fills Fields.EpicLink from the discovered Epic Link field.
+func (c *Client) resolveEpicLinks(ctx context.Context, issues []Issue) {
+	id := c.epicLinkField(ctx)
+	if id == "" {
+		return
+	}
+	for i := range issues {
+		raw, ok := issues[i].Fields.Custom[id]
+		if !ok {
+			continue
+		}
+		// Cloud returns the epic key as a string; older servers an object.
+		var key string
+		if json.Unmarshal(raw, &key) == nil && key != "" {
+			issues[i].Fields.EpicLink = &EpicLink{Key: key}
+			continue
+		}
+		var link EpicLink
+		if json.Unmarshal(raw, &link) == nil && link.Key != "" {
+			issues[i].Fields.EpicLink = &link
+		}
+	}
+}
+
+type fieldCache struct {
+	Fetched time.Time `json:"fetched"`
+	Fields  []Field   `json:"fields"`
+}
+
+var unsafePathChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// fieldCachePath returns CacheDir/fields/<site>.json, or "" without a CacheDir.
+func (c *Client) fieldCachePath() string {
+	return c.cachePath("fields")
+}
+
+// cachePath returns CacheDir/<kind>/<site>.json, or "" without a CacheDir.
+func (c *Client) cachePath(kind string) string {
+	if c.CacheDir == "" {
+		return ""
+	}
+	site := c.BaseURL
+	if u, err := url.Parse(c.BaseURL); err == nil && u.Host != "" {
+		site = u.Host + u.Path
+	}
+	name := strings.Trim(unsafePathChars.ReplaceAllString(site, "_"), "_")
+	return filepath.Join(c.CacheDir, kind, name+".json")
+}
+
+func (c *Client) readFieldCache() ([]Field, bool) {
+	path := c.fieldCachePath()
+	if path == "" {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var cache fieldCache
+	if json.Unmarshal(data, &cache) != nil || time.Since(cache.Fetched) > fieldCacheTTL || len(cache.Fields) == 0 {
+		return nil, false
+	}
+	return cache.Fields, true
+}
+
+// writeFieldCache is best-effort; a failed write only costs a refetch.
+func (c *Client) writeFieldCache(fields []Field) {
+	path := c.fieldCachePath()
+	if path == "" {
+		return
+	}
+	data, err := json.Marshal(fieldCache{Fetched: time.Now(), Fields: fields})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	os.WriteFile(path, data, 0600)
+}
@@ -0,0 +1,208 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const fieldListJSON = `[
+	{"id":"summary","name":"Summary","custom":false,"schema":{"type":"string","system":"summary"}},
+	{"id":"labels","name":"Labels","custom":false,"schema":{"type":"array","items":"string","system":"labels"}},
+	{"id":"customfield_10014","name":"Epic Link","custom":true,"schema":{"type":"any","custom":"com.pyxis.greenhopper.jira:gh-epic-link"}},
+	{"id":"customfield_10016","name":"Story Points","custom":true,"schema":{"type":"number"}},
+	{"id":"customfield_10020","name":"Team","custom":true,"schema":{"type":"option"}},
+	{"id":"customfield_10030","name":"Reviewers","custom":true,"schema":{"type":"array","items":"user"}},
+	{"id":"customfield_10040","name":"Team","custom":true,"schema":{"type":"string"}}
+]`
+
+func testRegistry(t *testing.T) *FieldRegistry {
+	t.Helper()
+	var fields []Field
+	if err := json.Unmarshal([]byte(fieldListJSON), &fields); err != nil {
+		t.Fatal(err)
+	}
+	return &FieldRegistry{Fields: fields}
+}
+
+func TestFieldRegistryLookup(t *testing.T) {
+	reg := testRegistry(t)
+
+	if f, err := reg.Lookup("story points"); err != nil || f.ID != "customfield_10016" {
+		t.Errorf("Lookup by name = %v, %v", f, err)
+	}
+	if f, err := reg.Lookup("customfield_10020"); err != nil || f.Name != "Team" {
+		t.Errorf("Lookup by ID = %v, %v", f, err)
+	}
+	if _, err := reg.Lookup("Team"); err == nil || !strings.Contains(err.Error(), "customfield_10020, customfield_10040") {
+		t.Errorf("ambiguous Lookup err = %v", err)
+	}
+	if _, err := reg.Lookup("Nope"); err == nil {
+		t.Error("unknown field did not fail")
+	}
+	if id := reg.EpicLinkID(); id != "customfield_10014" {
+		t.Errorf("EpicLinkID = %q", id)
+	}
+}
+
+func TestParseFieldValues(t *testing.T) {
+	reg := testRegistry(t)
+	// Cloud users have account IDs; Data Center users only usernames.
+	reg.users = func(ctx context.Context, who string) (*User, error) {
+		if who == "jdoe" {
+			return &User{Name: "jdoe"}, nil
+		}
+		return &User{AccountID: "id-" + who}, nil
+	}
+	got, err := reg.ParseFieldValues([]string{
+		"Story Points=5",
+		"customfield_10020=Platform",
+		"Labels=a, b",
+		"Reviewers=jane@example.com,jdoe",
+		`customfield_10040={"raw":true}`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"customfield_10016": 5.0,
+		"customfield_10020": map[string]string{"value": "Platform"},
+		"labels":            []interface{}{"a", "b"},
+		"customfield_10030": []interface{}{
+			&UserRef{AccountID: "id-jane@example.com"},
+			&UserRef{Name: "jdoe"},
+		},
+		"customfield_10040": json.RawMessage(`{"raw":true}`),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseFieldValues =\n%#v\nwant\n%#v", got, want)
+	}
+
+	for _, bad := range []string{"Story Points=lots", "no-equals", "Unknown=1"} {
+		if _, err := reg.ParseFieldValues([]string{bad}); err == nil {
+			t.Errorf("ParseFieldValues(%q) did not fail", bad)
+		}
+	}
+}
+
+func TestFormatFieldValue(t *testing.T) {
+	tests := map[string]string{
+		`5`:                                     "5",
+		`"text"`:                                "text",
+		`{"value":"Platform"}`:                  "Platform",
+		`{"value":"A","child":{"value":"B"}}`:   "A / B",
+		`[{"name":"One"},{"name":"Two"}]`:       "One, Two",
+		`{"displayName":"Ann","accountId":"x"}`: "Ann",
+		`null`:                                  "",
+	}
+	for in, want := range tests {
+		if got := FormatFieldValue(json.RawMessage(in)); got != want {
+			t.Errorf("FormatFieldValue(%s) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFieldRegistryCachedPerSite(t *testing.T) {
+	var fieldCalls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/field":
+			fieldCalls.Add(1)
+			w.Write([]byte(fieldListJSON))
+		case "/rest/api/2/issue/PROJ-2":
+			if !strings.Contains(r.URL.Query().Get("fields"), "customfield_10014") {
+				t.Errorf("epic link field not requested: %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"key":"PROJ-2","fields":{"summary":"Child","customfield_10014":"PROJ-1","customfield_10016":3,"customfield_10020":null}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	c := NewClient(srv.URL, "me@example.com", "", "token")
+	c.CacheDir = dir
+	issue, err := c.GetIssue("PROJ-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issue.Fields.EpicLink == nil || issue.Fields.EpicLink.Key != "PROJ-1" {
+		t.Errorf("EpicLink = %+v", issue.Fields.EpicLink)
+	}
+	if out, _ := json.Marshal(issue.Fields); !strings.Contains(string(out), `"customfield_10014":{"key":"PROJ-1","summary":""}`) {
+		t.Errorf("epic link missing from JSON output: %s", out)
+	}
+	if string(issue.Fields.Custom["customfield_10016"]) != "3" {
+		t.Errorf("Custom = %v", issue.Fields.Custom)
+	}
+	if _, ok := issue.Fields.Custom["customfield_10020"]; ok {
+		t.Error("null custom field kept")
+	}
+
+	// A second client for the same site reads the field list from disk.
+	c2 := NewClient(srv.URL, "me@example.com", "", "token")
+	c2.CacheDir = dir
+	if _, err := c2.FieldRegistry(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if n := fieldCalls.Load(); n != 1 {
+		t.Errorf("field list fetched %d times, want 1", n)
+	}
+	if _, err := c2.RefreshFieldRegistry(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if n := fieldCalls.Load(); n != 2 {
+		t.Errorf("field list fetched %d times after refresh, want 2", n)
+	}
+}
+
+func TestFieldRegistryRetriesAfterFailure(t *testing.T) {
+	var fieldCalls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fieldCalls.Add(1) == 1 {
+			http.Error(w, `{"errorMessages":["not now"]}`, http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(fieldListJSON))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "me@example.com", "", "token")
+	for i := 0; i < 2; i++ {
+		if _, err := c.FieldRegistry(context.Background()); err == nil {
+			t.Fatal("failed lookup not reported")
+		}
+	}
+	if n := fieldCalls.Load(); n != 1 {
+		t.Errorf("field list fetched %d times, want the failure remembered", n)
+	}
+
+	c.fieldsErrAt = time.Now().Add(-fieldErrorTTL)
+	if _, err := c.FieldRegistry(context.Background()); err != nil {
+		t.Errorf("lookup not retried after the failure expired: %v", err)
+	}
+}
+
+func TestCreateIssueFieldsMarshalCustom(t *testing.T) {
+	fields := CreateIssueFields{
+		Project:   ProjectRef{Key: "PROJ"},
+		Summary:   "Hello",
+		IssueType: IssueTypeRef{Name: "Task"},
+		Custom:    map[string]interface{}{"customfield_10016": 5},
+	}
+	data, err := json.Marshal(CreateIssueRequest{Fields: fields})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"fields":{"customfield_10016":5,"issuetype":{"name":"Task"},"project":{"key":"PROJ"},"summary":"Hello"}}`
+	if string(data) != want {
+		t.Errorf("got  %s\nwant %s", data, want)
+	}
+}
@@ -0,0 +1,225 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// JQLAutocompleteData is what Jira offers for completing JQL: the fields
+// that can be searched, the functions and the reserved words.
+type JQLAutocompleteData struct {
+	Fields        []JQLField    `json:"visibleFieldNames"`
+	Functions     []JQLFunction `json:"visibleFunctionNames"`
+	ReservedWords []string      `json:"jqlReservedWords"`
+}
+
+// JQLField is a searchable field. Value is what goes in the query (quoted
+// when it contains spaces, e.g. "\"Story Points\"" or "cf[10016]").
+type JQLField struct {
+	Value       string   `json:"value"`
+	DisplayName string   `json:"displayName"`
+	Orderable   string   `json:"orderable"`
+	Searchable  string   `json:"searchable"`
+	CFID        string   `json:"cfid,omitempty"`
+	Operators   []string `json:"operators"`
+	Types       []string `json:"types"`
+}
+
+// JQLFunction is a JQL function such as currentUser().
+type JQLFunction struct {
+	Value       string   `json:"value"`
+	DisplayName string   `json:"displayName"`
+	IsList      string   `json:"isList,omitempty"`
+	Types       []string `json:"types"`
+}
+
+// Field returns the field whose value or display name is name, ignoring
+// case and surrounding quotes, or nil.
+func (d *JQLAutocompleteData) Field(name string) *JQLField {
+	name = strings.Trim(name, `"'`)
+	for i, f := range d.Fields {
+		if strings.EqualFold(strings.Trim(f.Value, `"'`), name) || strings.EqualFold(f.DisplayName, name) ||
+			f.CFID != "" && strings.EqualFold(f.CFID, name) {
+			return &d.Fields[i]
+		}
+	}
+	return nil
+}
+
+// JQLSuggestion is a suggested value for a field.
+type JQLSuggestion struct {
+	Value       string `json:"value"`
+	DisplayName string `json:"displayName"`
+}
+
+// JQLError is a problem Jira found in a query. Line and Column are 1-based
+// and 0 when Jira did not say where the problem is.
+type JQLError struct {
+	Message string `json:"message"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+}
+
+func (e JQLError) Error() string { return e.Message }
+
+// JQLValidation is the result of validating one query.
+type JQLValidation struct {
+	Query  string     `json:"query"`
+	Errors []JQLError `json:"errors"`
+}
+
+// Valid reports whether Jira found no errors.
+func (v JQLValidation) Valid() bool { return len(v.Errors) == 0 }
+
+// GetJQLAutocompleteData returns the fields, functions and reserved words
+// used to complete JQL.
+func (c *Client) GetJQLAutocompleteData() (*JQLAutocompleteData, error) {
+	return c.GetJQLAutocompleteDataContext(context.Background())
+}
+
+// GetJQLAutocompleteDataContext is like GetJQLAutocompleteData but carries ctx for cancellation.
+func (c *Client) GetJQLAutocompleteDataContext(ctx context.Context) (*JQLAutocompleteData, error) {
+	resp, err := c.makeRequest(ctx, "GET", "/rest/api/3/jql/autocompletedata", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, 200, "JQL autocomplete data"); err != nil {
+		return nil, err
+	}
+	var data JQLAutocompleteData
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &data, nil
+}
+
+// GetJQLSuggestions returns values of field starting with prefix, as Jira
+// suggests them while typing a query.
+func (c *Client) GetJQLSuggestions(field, prefix string) ([]JQLSuggestion, error) {
+	return c.GetJQLSuggestionsContext(context.Background(), field, prefix)
+}
+
+// GetJQLSuggestionsContext is like GetJQLSuggestions but carries ctx for cancellation.
+func (c *Client) GetJQLSuggestionsContext(ctx context.Context, field, prefix string) ([]JQLSuggestion, error) {
+	params := url.Values{}
+	params.Set("fieldName", field)
+	params.Set("fieldValue", prefix)
+
+	resp, err := c.makeRequest(ctx, "GET", "/rest/api/3/jql/autocompletedata/suggestions?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, 200, fmt.Sprintf("JQL suggestions for %s", field)); err != nil {
+		return nil, err
+	}
+	var result struct {
+		Results []JQLSuggestion `json:"results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result.Results, nil
+}
+
+// ValidateJQL asks Jira to parse and strictly validate queries (unknown
+// fields, values and functions are errors), without running them.
+func (c *Client) ValidateJQL(queries ...string) ([]JQLValidation, error) {
+	return c.ValidateJQLContext(context.Background(), queries...)
+}
+
+// ValidateJQLContext is like ValidateJQL but carries ctx for cancellation.
+func (c *Client) ValidateJQLContext(ctx context.Context, queries ...string) ([]JQLValidation, error) {
+	body := map[string][]string{"queries": queries}
+	resp, err := c.makeRequest(ctx, "POST", "/rest/api/3/jql/parse?validation=strict", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, 200, "JQL validation"); err != nil {
+		// Sites without the parse endpoint: run each query for one result
+		// and read the errors of the search instead.
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == 404 {
+			return c.validateBySearch(ctx, queries)
+		}
+		return nil, err
+	}
+	var result struct {
+		Queries []struct {
+			Query  string   `json:"query"`
+			Errors []string `json:"errors"`
+		} `json:"queries"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	validations := make([]JQLValidation, len(result.Queries))
+	for i, q := range result.Queries {
+		validations[i] = JQLValidation{Query: q.Query, Errors: []JQLError{}}
+		for _, msg := range q.Errors {
+			validations[i].Errors = append(validations[i].Errors, newJQLError(q.Query, msg))
+		}
+	}
+	return validations, nil
+}
+
+func (c *Client) validateBySearch(ctx context.Context, queries []string) ([]JQLValidation, error) {
+	validations := make([]JQLValidation, len(queries))
+	for i, q := range queries {
+		validations[i] = JQLValidation{Query: q, Errors: []JQLError{}}
+		_, err := c.SearchIssuesContext(ctx, q, 1)
+		var apiErr *APIError
+		if err == nil {
+			continue
+		}
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != 400 {
+			return nil, err
+		}
+		// Every 400 means the query was refused, even without a reason.
+		details := apiErr.Details()
+		if len(details) == 0 {
+			details = []string{apiErr.Error()}
+		}
+		for _, msg := range details {
+			validations[i].Errors = append(validations[i].Errors, newJQLError(q, msg))
+		}
+	}
+	return validations, nil
+}
+
+var (
+	jqlLocation = regexp.MustCompile(`\(line (\d+), character (\d+)\)`)
+	jqlQuoted   = regexp.MustCompile(`'([^']+)'`)
+)
+
+// newJQLError locates a Jira error message in query. Syntax errors carry
+// "(line L, character C)"; others, such as an unknown field or value, quote
+// the offending text, which is then looked up in the query.
+func newJQLError(query, msg string) JQLError {
+	e := JQLError{Message: msg}
+	if m := jqlLocation.FindStringSubmatch(msg); m != nil {
+		e.Line, _ = strconv.Atoi(m[1])
+		e.Column, _ = strconv.Atoi(m[2])
+		return e
+	}
+	for _, m := range jqlQuoted.FindAllStringSubmatch(msg, -1) {
+		if at := strings.Index(query, m[1]); at >= 0 {
+			before := query[:at]
+			e.Line = strings.Count(before, "\n") + 1
+			e.Column = len([]rune(before[strings.LastIndex(before, "\n")+1:])) + 1
+			return e
+		}
+	}
+	return e
+}
@@ -0,0 +1,87 @@
+package jira
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidateJQL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/rest/api/3/jql/parse" || r.URL.Query().Get("validation") != "strict" {
+			http.NotFound(w, r)
+			return
+		}
+		var body struct{ Queries []string }
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"queries":[
+			{"query":"project = X","structure":{}},
+			{"query":"project = X AND sttus = Done","errors":["Field 'sttus' does not exist or you do not have permission to view it."]},
+			{"query":"project X","errors":["Error in the JQL Query: Expecting operator but got 'X'. (line 1, character 9)"]}
+		]}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "me@example.com", "", "token")
+	got, err := c.ValidateJQL("project = X", "project = X AND sttus = Done", "project X")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 || !got[0].Valid() || got[1].Valid() {
+		t.Fatalf("validations = %+v", got)
+	}
+	if e := got[1].Errors[0]; e.Line != 1 || e.Column != 17 {
+		t.Errorf("unknown field at %d:%d", e.Line, e.Column)
+	}
+	if e := got[2].Errors[0]; e.Line != 1 || e.Column != 9 {
+		t.Errorf("syntax error at %d:%d", e.Line, e.Column)
+	}
+}
+
+func TestValidateJQLFallsBackToSearch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/search/jql" {
+			http.NotFound(w, r)
+			return
+		}
+		switch r.URL.Query().Get("jql") {
+		case "bad":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errorMessages":["Error in the JQL Query: The character 'b' is a reserved JQL character. (line 1, character 1)"]}`))
+			return
+		case "vague":
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"issues":[]}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "me@example.com", "", "token")
+	got, err := c.ValidateJQL("good", "bad", "vague")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got[0].Valid() || got[1].Valid() || got[1].Errors[0].Column != 1 {
+		t.Errorf("validations = %+v", got)
+	}
+	if got[2].Valid() || got[2].Errors[0].Message != "HTTP 400: request failed for issue search" {
+		t.Errorf("400 without details: %+v", got[2])
+	}
+}
+
+func TestJQLAutocompleteDataField(t *testing.T) {
+	data := JQLAutocompleteData{Fields: []JQLField{
+		{Value: "status", DisplayName: "Status"},
+		{Value: `"Story Points"`, DisplayName: "Story Points - cf[10016]", CFID: "cf[10016]"},
+	}}
+	for _, name := range []string{"STATUS", `"story points"`, "cf[10016]"} {
+		if data.Field(name) == nil {
+			t.Errorf("Field(%q) = nil", name)
+		}
+	}
+	if data.Field("sprint") != nil {
+		t.Error("Field(sprint) found a field")
+	}
+}
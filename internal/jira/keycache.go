@@ -0,0 +1,139 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// keyCacheTTL is how long a checked key is trusted without asking Jira
+// again. Older entries are still used when Jira cannot be reached.
+const keyCacheTTL = time.Hour
+
+// KeyCheck is what CheckIssueKey found out about an issue key.
+type KeyCheck struct {
+	Key    string `json:"key"`
+	Exists bool   `json:"exists"`
+	Status string `json:"status,omitempty"`
+	Done   bool   `json:"done"` // in the "done" status category
+
+	// Offline is set when Jira could not be reached and the answer comes
+	// from the key cache. Known is false when the cache did not have the
+	// key either; KnownProject then says whether it had another key of the
+	// same project.
+	Offline      bool `json:"offline,omitempty"`
+	Known        bool `json:"known"`
+	KnownProject bool `json:"knownProject,omitempty"`
+}
+
+type keyCacheEntry struct {
+	Status  string    `json:"status"`
+	Done    bool      `json:"done"`
+	Checked time.Time `json:"checked"`
+}
+
+// keyCache is the file CheckIssueKey remembers existing issues in, per site
+// under CacheDir/issuekeys.
+type keyCache struct {
+	Keys map[string]keyCacheEntry `json:"keys"`
+}
+
+// CheckIssueKey reports whether key exists and whether it is done.
+func (c *Client) CheckIssueKey(key string) (KeyCheck, error) {
+	return c.CheckIssueKeyContext(context.Background(), key)
+}
+
+// CheckIssueKeyContext is like CheckIssueKey but carries ctx for cancellation.
+// Answers are cached in CacheDir, so keys checked within the last hour are
+// not looked up again, and keys seen before can be checked offline.
+func (c *Client) CheckIssueKeyContext(ctx context.Context, key string) (KeyCheck, error) {
+	key = strings.ToUpper(key)
+	cache := c.readKeyCache()
+	if e, ok := cache.Keys[key]; ok && time.Since(e.Checked) < keyCacheTTL {
+		return KeyCheck{Key: key, Exists: true, Status: e.Status, Done: e.Done, Known: true}, nil
+	}
+
+	resp, err := c.makeRequest(ctx, "GET", "/rest/api/2/issue/"+url.PathEscape(key)+"?fields=status", nil)
+	if err != nil {
+		return cache.offline(key), nil
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, 200, fmt.Sprintf("issue %s", key)); err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == 404 {
+			return KeyCheck{Key: key, Known: true}, nil
+		}
+		if errors.As(err, &apiErr) && apiErr.StatusCode >= 500 {
+			return cache.offline(key), nil
+		}
+		return KeyCheck{Key: key}, err
+	}
+	var issue struct {
+		Fields struct {
+			Status Status `json:"status"`
+		} `json:"fields"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
+		return KeyCheck{Key: key}, fmt.Errorf("failed to decode response: %w", err)
+	}
+	status := issue.Fields.Status
+	done := status.Category != nil && status.Category.Key == "done"
+
+	cache.Keys[key] = keyCacheEntry{Status: status.Name, Done: done, Checked: time.Now()}
+	c.writeKeyCache(cache)
+	return KeyCheck{Key: key, Exists: true, Status: status.Name, Done: done, Known: true}, nil
+}
+
+// offline answers from the cache when Jira cannot be reached.
+func (kc keyCache) offline(key string) KeyCheck {
+	if e, ok := kc.Keys[key]; ok {
+		return KeyCheck{Key: key, Exists: true, Status: e.Status, Done: e.Done, Offline: true, Known: true}
+	}
+	check := KeyCheck{Key: key, Offline: true}
+	project, _, _ := strings.Cut(key, "-")
+	for k := range kc.Keys {
+		if p, _, _ := strings.Cut(k, "-"); p == project {
+			check.KnownProject = true
+			break
+		}
+	}
+	return check
+}
+
+func (c *Client) readKeyCache() keyCache {
+	cache := keyCache{Keys: make(map[string]keyCacheEntry)}
+	path := c.cachePath("issuekeys")
+	if path == "" {
+		return cache
+	}
+	if data, err := os.ReadFile(path); err == nil {
+		json.Unmarshal(data, &cache)
+	}
+	if cache.Keys == nil {
+		cache.Keys = make(map[string]keyCacheEntry)
+	}
+	return cache
+}
+
+// writeKeyCache is best-effort, like writeFieldCache.
+func (c *Client) writeKeyCache(cache keyCache) {
+	path := c.cachePath("issuekeys")
+	if path == "" {
+		return
+	}
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	os.WriteFile(path, data, 0600)
+}
@@ -0,0 +1,75 @@
+package jira
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCheckIssueKey(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Query().Get("fields") != "status" {
+			t.Errorf("fields = %q", r.URL.Query().Get("fields"))
+		}
+		switch r.URL.Path {
+		case "/rest/api/2/issue/PROJ-1":
+			w.Write([]byte(`{"key":"PROJ-1","fields":{"status":{"name":"In Progress","statusCategory":{"key":"indeterminate"}}}}`))
+		case "/rest/api/2/issue/PROJ-2":
+			w.Write([]byte(`{"key":"PROJ-2","fields":{"status":{"name":"Closed","statusCategory":{"key":"done"}}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errorMessages":["Issue does not exist or you do not have permission to see it."]}`))
+		}
+	}))
+
+	dir := t.TempDir()
+	newClient := func() *Client {
+		c := NewClient(srv.URL, "me@example.com", "", "token")
+		c.HTTPClient = &http.Client{}
+		c.CacheDir = dir
+		return c
+	}
+	c := newClient()
+
+	check, err := c.CheckIssueKey("proj-1")
+	if err != nil || !check.Exists || check.Done || check.Status != "In Progress" || check.Offline {
+		t.Errorf("PROJ-1: %+v, %v", check, err)
+	}
+	check, err = c.CheckIssueKey("PROJ-2")
+	if err != nil || !check.Exists || !check.Done {
+		t.Errorf("PROJ-2: %+v, %v", check, err)
+	}
+	check, err = c.CheckIssueKey("PROJ-3")
+	if err != nil || check.Exists || check.Offline {
+		t.Errorf("PROJ-3: %+v, %v", check, err)
+	}
+
+	// Recently checked keys are answered from the cache.
+	before := calls.Load()
+	if check, err := newClient().CheckIssueKey("PROJ-1"); err != nil || !check.Exists || check.Offline {
+		t.Errorf("cached PROJ-1: %+v, %v", check, err)
+	}
+	if calls.Load() != before {
+		t.Error("cached key looked up again")
+	}
+
+	// Offline, the cache still answers for keys it has seen.
+	srv.Close()
+	c = newClient()
+	c.writeKeyCache(keyCache{Keys: map[string]keyCacheEntry{"PROJ-2": {Status: "Closed", Done: true}}})
+	check, err = c.CheckIssueKey("PROJ-2")
+	if err != nil || !check.Offline || !check.Known || !check.Done {
+		t.Errorf("offline PROJ-2: %+v, %v", check, err)
+	}
+	check, err = c.CheckIssueKey("PROJ-9")
+	if err != nil || !check.Offline || check.Known || !check.KnownProject {
+		t.Errorf("offline PROJ-9: %+v, %v", check, err)
+	}
+	check, err = c.CheckIssueKey("OTHER-1")
+	if err != nil || !check.Offline || check.KnownProject {
+		t.Errorf("offline OTHER-1: %+v, %v", check, err)
+	}
+}
@@ -0,0 +1,55 @@
+package jira
+
+import (
+	"regexp"
+	"strings"
+)
+
+// issueKeyPattern matches issue keys such as PROJ-123: a project key of
+// capital letters, digits and underscores starting with a letter, a dash and
+// the issue number.
+var issueKeyPattern = regexp.MustCompile(`\b[A-Z][A-Z0-9_]+-[1-9][0-9]*\b`)
+
+// leadingKeyPattern matches an issue key at the start of a string.
+var leadingKeyPattern = regexp.MustCompile(`^` + issueKeyPattern.String())
+
+// FindIssueKeys returns the issue keys in s, in order of appearance and
+// without duplicates. Keys must be written in capitals.
+func FindIssueKeys(s string) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, key := range issueKeyPattern.FindAllString(s, -1) {
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// IsIssueKey reports whether s, in any case, is exactly one issue key.
+func IsIssueKey(s string) bool {
+	s = strings.ToUpper(s)
+	loc := issueKeyPattern.FindStringIndex(s)
+	return loc != nil && loc[0] == 0 && loc[1] == len(s)
+}
+
+// LeadingIssueKey returns the issue key that s starts with, after any
+// leading space, or "" if it starts with none. Like FindIssueKeys it only
+// takes keys written in capitals.
+func LeadingIssueKey(s string) string {
+	return leadingKeyPattern.FindString(strings.TrimLeft(s, " \t\r\n"))
+}
+
+// BranchIssueKey returns the issue key that the branch name, or one of its
+// /-separated parts, starts with, in any case: PROJ-123-fix-login and
+// feature/proj-123-fix-login both give PROJ-123. Key-like words further into
+// a part, as in bump-go-1.22, are not keys. It returns "" if there is none.
+func BranchIssueKey(branch string) string {
+	for _, part := range strings.Split(branch, "/") {
+		if key := leadingKeyPattern.FindString(strings.ToUpper(part)); key != "" {
+			return key
+		}
+	}
+	return ""
+}
@@ -0,0 +1,70 @@
+package jira
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindIssueKeys(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want []string
+	}{
+		{"PROJ-123: fix login", []string{"PROJ-123"}},
+		{"FEATURE/PROJ-12-ADD-EXPORT", []string{"PROJ-12"}},
+		{"Merge AB_C-1 and X2-7 (again AB_C-1)", []string{"AB_C-1", "X2-7"}},
+		{"proj-123 lower case", nil},
+		{"PROJ-0 PROJ-12abc 1A-2 P-3", nil},
+	} {
+		if got := FindIssueKeys(tc.in); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("FindIssueKeys(%q) = %v, want %v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestIsIssueKey(t *testing.T) {
+	for in, want := range map[string]bool{
+		"PROJ-123":                              true,
+		"proj-123":                              true,
+		"PROJ-123 fix":                          false,
+		"PROJ":                                  false,
+		"fix login":                             false,
+		"https://x.atlassian.net/browse/PROJ-1": false,
+	} {
+		if got := IsIssueKey(in); got != want {
+			t.Errorf("IsIssueKey(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestLeadingIssueKey(t *testing.T) {
+	for in, want := range map[string]string{
+		"PROJ-1: handle UTF-8 input": "PROJ-1",
+		"  PROJ-12 fix login":        "PROJ-12",
+		"Handle UTF-8 for PROJ-1":    "",
+		"proj-1: lower case":         "",
+		"PROJ-1x: not a key":         "",
+	} {
+		if got := LeadingIssueKey(in); got != want {
+			t.Errorf("LeadingIssueKey(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestBranchIssueKey(t *testing.T) {
+	for in, want := range map[string]string{
+		"PROJ-123-fix-login":         "PROJ-123",
+		"proj-123-fix-login":         "PROJ-123",
+		"feature/proj-12-add-export": "PROJ-12",
+		"user/jdoe/AB_C-1":           "AB_C-1",
+		"bump-go-1.22":               "",
+		"handle-utf-8-input":         "",
+		"feature/bump-go-1.22":       "",
+		"main":                       "",
+		"release/2.3":                "",
+	} {
+		if got := BranchIssueKey(in); got != want {
+			t.Errorf("BranchIssueKey(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,378 @@
+package jira
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gomarkdown/markdown"
+	"github.com/gomarkdown/markdown/ast"
+	"github.com/gomarkdown/markdown/parser"
+)
+
+// taskMarker matches the "[ ] " / "[x] " prefix of a GitHub-style task item.
+var taskMarker = regexp.MustCompile(`^\[([ xX])\]\s+`)
+
+// MarkdownToADF converts Markdown into an ADF document suitable for the
+// Jira v3 write APIs. It uses the same gomarkdown parser as the Confluence
+// storage converter, so both accept the same Markdown dialect.
+func MarkdownToADF(md string) *ADFNode {
+	extensions := parser.CommonExtensions | parser.Tables
+	p := parser.NewWithExtensions(extensions)
+	root := markdown.Parse([]byte(md), p)
+
+	doc := &ADFNode{Type: "doc", Version: 1}
+	w := adfWriter{}
+	doc.Content = w.blocks(root.GetChildren())
+	if len(doc.Content) == 0 {
+		doc.Content = []ADFNode{{Type: "paragraph"}}
+	}
+	return doc
+}
+
+// adfWriter converts a gomarkdown AST to ADF nodes.
+type adfWriter struct {
+	nextLocalID int
+}
+
+func (w *adfWriter) localID() string {
+	w.nextLocalID++
+	return "task-" + strconv.Itoa(w.nextLocalID)
+}
+
+func (w *adfWriter) blocks(nodes []ast.Node) []ADFNode {
+	var out []ADFNode
+	for _, n := range nodes {
+		out = append(out, w.block(n)...)
+	}
+	return out
+}
+
+func (w *adfWriter) block(node ast.Node) []ADFNode {
+	switch n := node.(type) {
+	case *ast.Paragraph:
+		content := w.inlines(n.Children, nil)
+		if len(content) == 0 {
+			return nil
+		}
+		return []ADFNode{{Type: "paragraph", Content: content}}
+
+	case *ast.Heading:
+		level := n.Level
+		if level < 1 || level > 6 {
+			level = 1
+		}
+		return []ADFNode{{
+			Type:    "heading",
+			Attrs:   map[string]interface{}{"level": level},
+			Content: w.inlines(n.Children, nil),
+		}}
+
+	case *ast.BlockQuote:
+		return []ADFNode{{Type: "blockquote", Content: w.blocks(n.Children)}}
+
+	case *ast.List:
+		return []ADFNode{w.list(n)}
+
+	case *ast.CodeBlock:
+		code := strings.TrimRight(string(n.Literal), "\n")
+		cb := ADFNode{Type: "codeBlock"}
+		if lang := strings.TrimSpace(string(n.Info)); lang != "" {
+			cb.Attrs = map[string]interface{}{"language": lang}
+		}
+		if code != "" {
+			cb.Content = []ADFNode{{Type: "text", Text: code}}
+		}
+		return []ADFNode{cb}
+
+	case *ast.HorizontalRule:
+		return []ADFNode{{Type: "rule"}}
+
+	case *ast.Table:
+		return []ADFNode{w.table(n)}
+
+	case *ast.HTMLBlock:
+		text := strings.TrimSpace(string(n.Literal))
+		if text == "" {
+			return nil
+		}
+		return []ADFNode{{Type: "paragraph", Content: []ADFNode{{Type: "text", Text: text}}}}
+	}
+
+	// Anything else: keep its children so text is never dropped.
+	if c := node.AsContainer(); c != nil {
+		return w.blocks(c.Children)
+	}
+	if l := node.AsLeaf(); l != nil && len(l.Literal) > 0 {
+		return []ADFNode{{Type: "paragraph", Content: []ADFNode{{Type: "text", Text: string(l.Literal)}}}}
+	}
+	return nil
+}
+
+// list converts a Markdown list. Bullet lists whose items all start with
+// "[ ]" or "[x]" become ADF task lists, unless an item holds more than a task
+// item can: ADF task items take only text and nested task lists, so a list
+// with further paragraphs or other lists in an item stays a bullet list.
+func (w *adfWriter) list(n *ast.List) ADFNode {
+	if n.ListFlags&ast.ListTypeOrdered != 0 {
+		list := ADFNode{Type: "orderedList"}
+		if n.Start > 1 {
+			list.Attrs = map[string]interface{}{"order": n.Start}
+		}
+		list.Content = w.listItems(n.Children)
+		return list
+	}
+	if isTaskList(n) {
+		return w.taskList(n)
+	}
+	return ADFNode{Type: "bulletList", Content: w.listItems(n.Children)}
+}
+
+func (w *adfWriter) listItems(items []ast.Node) []ADFNode {
+	var out []ADFNode
+	for _, item := range items {
+		content := w.blocks(item.GetChildren())
+		if len(content) == 0 || content[0].Type != "paragraph" {
+			// ADF list items must start with a paragraph.
+			content = append([]ADFNode{{Type: "paragraph"}}, content...)
+		}
+		out = append(out, ADFNode{Type: "listItem", Content: content})
+	}
+	return out
+}
+
+func (w *adfWriter) taskList(n *ast.List) ADFNode {
+	list := ADFNode{Type: "taskList", Attrs: map[string]interface{}{"localId": w.localID()}}
+	for _, item := range n.Children {
+		var inline []ADFNode
+		var nested []ADFNode
+		state := "TODO"
+		for i, child := range item.GetChildren() {
+			if p, ok := child.(*ast.Paragraph); ok && i == 0 {
+				inline = w.inlines(p.Children, nil)
+				if len(inline) > 0 && inline[0].Type == "text" {
+					if m := taskMarker.FindStringSubmatch(inline[0].Text); m != nil {
+						if m[1] != " " {
+							state = "DONE"
+						}
+						inline[0].Text = inline[0].Text[len(m[0]):]
+						if inline[0].Text == "" {
+							inline = inline[1:]
+						}
+					}
+				}
+				continue
+			}
+			// isTaskList made sure the rest are task lists.
+			nested = append(nested, w.taskList(child.(*ast.List)))
+		}
+		list.Content = append(list.Content, ADFNode{
+			Type:    "taskItem",
+			Attrs:   map[string]interface{}{"localId": w.localID(), "state": state},
+			Content: inline,
+		})
+		list.Content = append(list.Content, nested...)
+	}
+	return list
+}
+
+func isTaskList(n *ast.List) bool {
+	if n.ListFlags&ast.ListTypeOrdered != 0 || len(n.Children) == 0 {
+		return false
+	}
+	for _, item := range n.Children {
+		children := item.GetChildren()
+		if len(children) == 0 {
+			return false
+		}
+		p, ok := children[0].(*ast.Paragraph)
+		if !ok || len(p.Children) == 0 {
+			return false
+		}
+		t, ok := p.Children[0].(*ast.Text)
+		if !ok || !taskMarker.Match(t.Literal) {
+			return false
+		}
+		for _, child := range children[1:] {
+			if l, ok := child.(*ast.List); !ok || !isTaskList(l) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (w *adfWriter) table(n *ast.Table) ADFNode {
+	table := ADFNode{Type: "table"}
+	var walkRows func(nodes []ast.Node)
+	walkRows = func(nodes []ast.Node) {
+		for _, child := range nodes {
+			row, ok := child.(*ast.TableRow)
+			if !ok {
+				walkRows(child.GetChildren())
+				continue
+			}
+			r := ADFNode{Type: "tableRow"}
+			for _, c := range row.Children {
+				cell, ok := c.(*ast.TableCell)
+				if !ok {
+					continue
+				}
+				cellType := "tableCell"
+				if cell.IsHeader {
+					cellType = "tableHeader"
+				}
+				para := ADFNode{Type: "paragraph", Content: w.inlines(cell.Children, nil)}
+				r.Content = append(r.Content, ADFNode{Type: cellType, Content: []ADFNode{para}})
+			}
+			table.Content = append(table.Content, r)
+		}
+	}
+	walkRows(n.Children)
+	return table
+}
+
+// inlines converts inline Markdown nodes to ADF text nodes carrying marks.
+func (w *adfWriter) inlines(nodes []ast.Node, marks []ADFMark) []ADFNode {
+	var out []ADFNode
+	for _, node := range nodes {
+		out = append(out, w.inline(node, marks)...)
+	}
+	return mergeText(out)
+}
+
+func (w *adfWriter) inline(node ast.Node, marks []ADFMark) []ADFNode {
+	switch n := node.(type) {
+	case *ast.Text:
+		// Soft line breaks inside a paragraph are kept as newlines by the
+		// parser; Markdown renders them as spaces.
+		return textNode(strings.ReplaceAll(string(n.Literal), "\n", " "), marks)
+
+	case *ast.Code:
+		return textNode(string(n.Literal), withMark(marks, ADFMark{Type: "code"}))
+
+	case *ast.Strong:
+		return w.inlines(n.Children, withMark(marks, ADFMark{Type: "strong"}))
+
+	case *ast.Emph:
+		return w.inlines(n.Children, withMark(marks, ADFMark{Type: "em"}))
+
+	case *ast.Del:
+		return w.inlines(n.Children, withMark(marks, ADFMark{Type: "strike"}))
+
+	case *ast.Subscript:
+		return textNode(string(n.Literal), withMark(marks, ADFMark{Type: "subsup", Attrs: map[string]interface{}{"type": "sub"}}))
+
+	case *ast.Superscript:
+		return textNode(string(n.Literal), withMark(marks, ADFMark{Type: "subsup", Attrs: map[string]interface{}{"type": "sup"}}))
+
+	case *ast.Link:
+		link := ADFMark{Type: "link", Attrs: map[string]interface{}{"href": string(n.Destination)}}
+		if len(n.Title) > 0 {
+			link.Attrs["title"] = string(n.Title)
+		}
+		content := w.inlines(n.Children, withMark(marks, link))
+		if len(content) == 0 {
+			content = textNode(string(n.Destination), withMark(marks, link))
+		}
+		return content
+
+	case *ast.Image:
+		// Inline images cannot be expressed as ADF media without an upload,
+		// so keep them as links to the image.
+		alt := plainMarkdownText(n)
+		if alt == "" {
+			alt = string(n.Destination)
+		}
+		return textNode(alt, withMark(marks, ADFMark{Type: "link", Attrs: map[string]interface{}{"href": string(n.Destination)}}))
+
+	case *ast.Hardbreak:
+		return []ADFNode{{Type: "hardBreak"}}
+
+	case *ast.Softbreak:
+		return textNode(" ", marks)
+
+	case *ast.HTMLSpan:
+		return textNode(string(n.Literal), marks)
+	}
+
+	if c := node.AsContainer(); c != nil {
+		return w.inlines(c.Children, marks)
+	}
+	if l := node.AsLeaf(); l != nil {
+		return textNode(string(l.Literal), marks)
+	}
+	return nil
+}
+
+func textNode(text string, marks []ADFMark) []ADFNode {
+	if text == "" {
+		return nil
+	}
+	n := ADFNode{Type: "text", Text: text}
+	if len(marks) > 0 {
+		n.Marks = append([]ADFMark(nil), marks...)
+	}
+	return []ADFNode{n}
+}
+
+// withMark returns a copy of marks with m appended. The code mark may only be
+// combined with link in ADF, so other marks are dropped alongside it.
+func withMark(marks []ADFMark, m ADFMark) []ADFMark {
+	out := make([]ADFMark, 0, len(marks)+1)
+	for _, existing := range marks {
+		if m.Type == "code" && existing.Type != "link" {
+			continue
+		}
+		out = append(out, existing)
+	}
+	return append(out, m)
+}
+
+// mergeText joins adjacent text nodes that carry identical marks.
+func mergeText(nodes []ADFNode) []ADFNode {
+	var out []ADFNode
+	for _, n := range nodes {
+		if len(out) > 0 {
+			last := &out[len(out)-1]
+			if last.Type == "text" && n.Type == "text" && sameMarks(last.Marks, n.Marks) {
+				last.Text += n.Text
+				continue
+			}
+		}
+		out = append(out, n)
+	}
+	return out
+}
+
+// sameMarks reports whether two runs of text carry the same marks, attrs
+// included: sub and sup, or links with different titles, do not merge.
+func sameMarks(a, b []ADFMark) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Type != b[i].Type || len(a[i].Attrs) != len(b[i].Attrs) {
+			return false
+		}
+		if len(a[i].Attrs) > 0 && !reflect.DeepEqual(a[i].Attrs, b[i].Attrs) {
+			return false
+		}
+	}
+	return true
+}
+
+// plainMarkdownText returns the concatenated literal text below node.
+func plainMarkdownText(node ast.Node) string {
+	var sb strings.Builder
+	ast.WalkFunc(node, func(n ast.Node, entering bool) ast.WalkStatus {
+		if entering {
+			if l := n.AsLeaf(); l != nil {
+				sb.Write(l.Literal)
+			}
+		}
+		return ast.GoToNext
+	})
+	return sb.String()
+}
@@ -0,0 +1,64 @@
+package jira
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestMarkdownToADFGolden converts every testdata/markdown/*.md file to ADF
+// and compares the indented JSON against the .json golden next to it.
+func TestMarkdownToADFGolden(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "markdown", "*.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inputs) == 0 {
+		t.Fatal("no markdown fixtures found")
+	}
+	for _, in := range inputs {
+		md, err := os.ReadFile(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := json.MarshalIndent(MarkdownToADF(string(md)), "", "  ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkGolden(t, strings.TrimSuffix(in, ".md")+".json", string(out))
+	}
+}
+
+func TestMarkdownToADFRoundTrip(t *testing.T) {
+	md := "## Plan\n\n- **one**\n- two with `code`\n\n1. first\n2. [link](https://example.com)\n\n- [ ] todo\n- [x] done"
+	raw, err := json.Marshal(MarkdownToADF(md))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ADFToMarkdown(raw); got != md {
+		t.Errorf("round trip mismatch\n--- got ---\n%s\n--- want ---\n%s", got, md)
+	}
+}
+
+func TestSameMarks(t *testing.T) {
+	sub := []ADFMark{{Type: "subsup", Attrs: map[string]interface{}{"type": "sub"}}}
+	sup := []ADFMark{{Type: "subsup", Attrs: map[string]interface{}{"type": "sup"}}}
+	if sameMarks(sub, sup) {
+		t.Error("sub and sup marks compared equal")
+	}
+	if !sameMarks(sub, []ADFMark{{Type: "subsup", Attrs: map[string]interface{}{"type": "sub"}}}) {
+		t.Error("equal sub marks compared different")
+	}
+	if !sameMarks([]ADFMark{{Type: "strong"}}, []ADFMark{{Type: "strong", Attrs: map[string]interface{}{}}}) {
+		t.Error("empty and missing attrs compared different")
+	}
+}
+
+func TestMarkdownToADFEmpty(t *testing.T) {
+	doc := MarkdownToADF("")
+	if doc.Type != "doc" || doc.Version != 1 || len(doc.Content) != 1 || doc.Content[0].Type != "paragraph" {
+		t.Errorf("empty markdown should yield a doc with one empty paragraph, got %+v", doc)
+	}
+}
@@ -0,0 +1,34 @@
+package jira
+
+import "strings"
+
+// CloseStatuses are the statuses jet close looks for, in order.
+var CloseStatuses = []string{"done", "closed", "resolved", "complete"}
+
+// MatchTransition finds the transition to status, matched case-insensitively
+// and preferring an exact name over one containing status.
+func MatchTransition(transitions []Transition, status string) *Transition {
+	status = strings.ToLower(status)
+	for i := range transitions {
+		if strings.ToLower(transitions[i].To.Name) == status {
+			return &transitions[i]
+		}
+	}
+	for i := range transitions {
+		if strings.Contains(strings.ToLower(transitions[i].To.Name), status) {
+			return &transitions[i]
+		}
+	}
+	return nil
+}
+
+// MatchAnyTransition returns the transition to the first of statuses that
+// MatchTransition finds.
+func MatchAnyTransition(transitions []Transition, statuses []string) *Transition {
+	for _, status := range statuses {
+		if t := MatchTransition(transitions, status); t != nil {
+			return t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package jira
+
+import "testing"
+
+func TestMatchTransition(t *testing.T) {
+	transitions := []Transition{
+		{ID: "1", To: Status{Name: "In Progress"}},
+		{ID: "2", To: Status{Name: "Done Done"}},
+		{ID: "3", To: Status{Name: "Done"}},
+		{ID: "4", To: Status{Name: "Closed"}},
+	}
+	for _, tc := range []struct {
+		status string
+		want   string
+	}{
+		{"done", "3"},
+		{"PROGRESS", "1"},
+		{"review", ""},
+	} {
+		got := ""
+		if tr := MatchTransition(transitions, tc.status); tr != nil {
+			got = tr.ID
+		}
+		if got != tc.want {
+			t.Errorf("MatchTransition(%q) = %q, want %q", tc.status, got, tc.want)
+		}
+	}
+
+	// Statuses are tried in order, whatever the order of the transitions.
+	if tr := MatchAnyTransition(transitions, CloseStatuses); tr == nil || tr.ID != "3" {
+		t.Errorf("MatchAnyTransition(CloseStatuses) = %+v, want transition 3", tr)
+	}
+	if tr := MatchAnyTransition(transitions[:1], CloseStatuses); tr != nil {
+		t.Errorf("MatchAnyTransition without a close transition = %+v, want nil", tr)
+	}
+}
@@ -0,0 +1,247 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// Worklog is time logged against an issue.
+type Worklog struct {
+	ID               string `json:"id"`
+	IssueID          string `json:"issueId"`
+	IssueKey         string `json:"issueKey,omitempty"`     // filled in by GetUserWorklogs
+	IssueSummary     string `json:"issueSummary,omitempty"` // filled in by GetUserWorklogs
+	Author           User   `json:"author"`
+	Comment          string `json:"comment"`
+	Started          string `json:"started"`
+	TimeSpent        string `json:"timeSpent"`
+	TimeSpentSeconds int    `json:"timeSpentSeconds"`
+}
+
+// StartTime returns when the work started, or the zero time if unparseable.
+func (w Worklog) StartTime() time.Time {
+	t, _ := ParseTime(w.Started)
+	return t
+}
+
+// Duration returns the time spent.
+func (w Worklog) Duration() time.Duration {
+	return time.Duration(w.TimeSpentSeconds) * time.Second
+}
+
+// WorklogInput is the time to log or the new values of a worklog.
+type WorklogInput struct {
+	Started   time.Time
+	TimeSpent time.Duration
+	Comment   string
+
+	// RemainingEstimate, when non-zero, sets the issue's remaining estimate
+	// instead of letting Jira reduce it automatically.
+	RemainingEstimate time.Duration
+}
+
+// TimeTracking holds an issue's estimates and logged time.
+type TimeTracking struct {
+	OriginalEstimate         string `json:"originalEstimate,omitempty"`
+	RemainingEstimate        string `json:"remainingEstimate,omitempty"`
+	TimeSpent                string `json:"timeSpent,omitempty"`
+	OriginalEstimateSeconds  int    `json:"originalEstimateSeconds,omitempty"`
+	RemainingEstimateSeconds int    `json:"remainingEstimateSeconds,omitempty"`
+	TimeSpentSeconds         int    `json:"timeSpentSeconds,omitempty"`
+}
+
+// IsEmpty reports whether no estimate or time has been recorded.
+func (t *TimeTracking) IsEmpty() bool {
+	return t == nil || (t.OriginalEstimate == "" && t.RemainingEstimate == "" && t.TimeSpent == "")
+}
+
+type worklogRequest struct {
+	Started          string `json:"started"`
+	TimeSpentSeconds int    `json:"timeSpentSeconds"`
+	Comment          string `json:"comment,omitempty"`
+}
+
+func (in WorklogInput) request() worklogRequest {
+	started := in.Started
+	if started.IsZero() {
+		started = time.Now()
+	}
+	return worklogRequest{
+		Started:          started.Format(jiraTimeLayout),
+		TimeSpentSeconds: int(in.TimeSpent / time.Second),
+		Comment:          in.Comment,
+	}
+}
+
+// estimateParams tells Jira how to adjust the remaining estimate.
+func (in WorklogInput) estimateParams() string {
+	if in.RemainingEstimate <= 0 {
+		return ""
+	}
+	params := url.Values{}
+	params.Add("adjustEstimate", "new")
+	params.Add("newEstimate", fmt.Sprintf("%dm", int(in.RemainingEstimate/time.Minute)))
+	return "?" + params.Encode()
+}
+
+// GetWorklogs lists every worklog of an issue, oldest first.
+func (c *Client) GetWorklogs(issueKey string) ([]Worklog, error) {
+	return c.GetWorklogsContext(context.Background(), issueKey)
+}
+
+// GetWorklogsContext is like GetWorklogs but carries ctx for cancellation.
+func (c *Client) GetWorklogsContext(ctx context.Context, issueKey string) ([]Worklog, error) {
+	var worklogs []Worklog
+	startAt := 0
+	for {
+		params := url.Values{}
+		params.Add("startAt", fmt.Sprintf("%d", startAt))
+		params.Add("maxResults", "1000")
+
+		resp, err := c.makeRequest(ctx, "GET", fmt.Sprintf("/rest/api/2/issue/%s/worklog?%s", url.PathEscape(issueKey), params.Encode()), nil)
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Total    int       `json:"total"`
+			Worklogs []Worklog `json:"worklogs"`
+		}
+		err = checkResponse(resp, 200, "worklogs of "+issueKey)
+		if err == nil {
+			if derr := json.NewDecoder(resp.Body).Decode(&page); derr != nil {
+				err = fmt.Errorf("failed to decode response: %w", derr)
+			}
+		}
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		worklogs = append(worklogs, page.Worklogs...)
+		startAt += len(page.Worklogs)
+		if len(page.Worklogs) == 0 || startAt >= page.Total {
+			return worklogs, nil
+		}
+	}
+}
+
+// AddWorklog logs time against an issue.
+func (c *Client) AddWorklog(issueKey string, in WorklogInput) (*Worklog, error) {
+	return c.AddWorklogContext(context.Background(), issueKey, in)
+}
+
+// AddWorklogContext is like AddWorklog but carries ctx for cancellation.
+func (c *Client) AddWorklogContext(ctx context.Context, issueKey string, in WorklogInput) (*Worklog, error) {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/worklog%s", url.PathEscape(issueKey), in.estimateParams())
+	return c.sendWorklog(ctx, "POST", endpoint, 201, "issue "+issueKey, in)
+}
+
+// UpdateWorklog replaces the time, start and comment of a worklog.
+func (c *Client) UpdateWorklog(issueKey, worklogID string, in WorklogInput) (*Worklog, error) {
+	return c.UpdateWorklogContext(context.Background(), issueKey, worklogID, in)
+}
+
+// UpdateWorklogContext is like UpdateWorklog but carries ctx for cancellation.
+func (c *Client) UpdateWorklogContext(ctx context.Context, issueKey, worklogID string, in WorklogInput) (*Worklog, error) {
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s/worklog/%s%s", url.PathEscape(issueKey), url.PathEscape(worklogID), in.estimateParams())
+	return c.sendWorklog(ctx, "PUT", endpoint, 200, "worklog "+worklogID+" of "+issueKey, in)
+}
+
+// DeleteWorklog removes a worklog from an issue.
+func (c *Client) DeleteWorklog(issueKey, worklogID string) error {
+	return c.DeleteWorklogContext(context.Background(), issueKey, worklogID)
+}
+
+// DeleteWorklogContext is like DeleteWorklog but carries ctx for cancellation.
+func (c *Client) DeleteWorklogContext(ctx context.Context, issueKey, worklogID string) error {
+	resp, err := c.makeRequest(ctx, "DELETE", fmt.Sprintf("/rest/api/2/issue/%s/worklog/%s", url.PathEscape(issueKey), url.PathEscape(worklogID)), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return checkResponse(resp, 204, "worklog "+worklogID+" of "+issueKey)
+}
+
+func (c *Client) sendWorklog(ctx context.Context, method, endpoint string, successCode int, resource string, in WorklogInput) (*Worklog, error) {
+	resp, err := c.makeRequest(ctx, method, endpoint, in.request())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, successCode, resource); err != nil {
+		return nil, err
+	}
+
+	var worklog Worklog
+	if err := json.NewDecoder(resp.Body).Decode(&worklog); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &worklog, nil
+}
+
+// GetUserWorklogs returns the worklogs user started in [from, to), across
+// all issues, ordered by start time. Each worklog's IssueKey and
+// IssueSummary are set.
+func (c *Client) GetUserWorklogs(user *User, from, to time.Time) ([]Worklog, error) {
+	return c.GetUserWorklogsContext(context.Background(), user, from, to)
+}
+
+// GetUserWorklogsContext is like GetUserWorklogs but carries ctx for cancellation.
+func (c *Client) GetUserWorklogsContext(ctx context.Context, user *User, from, to time.Time) ([]Worklog, error) {
+	author := user.AccountID
+	if author == "" {
+		author = user.Name
+	}
+	// worklogDate is day-granular, so widen by a day on each side and trim
+	// precisely below; this also covers time-zone differences with the server.
+	jql := fmt.Sprintf(`worklogAuthor = "%s" AND worklogDate >= "%s" AND worklogDate <= "%s" ORDER BY key`,
+		EscapeString(author), from.AddDate(0, 0, -1).Format("2006-01-02"), to.AddDate(0, 0, 1).Format("2006-01-02"))
+
+	var issues []Issue
+	for startAt := 0; ; {
+		resp, err := c.SearchIssuesWithPaginationContext(ctx, jql, startAt, 100)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, resp.Issues...)
+		startAt += len(resp.Issues)
+		if len(resp.Issues) == 0 || startAt >= resp.Total {
+			break
+		}
+	}
+
+	var worklogs []Worklog
+	for _, issue := range issues {
+		all, err := c.GetWorklogsContext(ctx, issue.Key)
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range all {
+			if !sameUser(&w.Author, user) {
+				continue
+			}
+			if t := w.StartTime(); t.Before(from) || !t.Before(to) {
+				continue
+			}
+			w.IssueKey = issue.Key
+			w.IssueSummary = issue.Fields.Summary
+			worklogs = append(worklogs, w)
+		}
+	}
+	sort.SliceStable(worklogs, func(i, j int) bool { return worklogs[i].StartTime().Before(worklogs[j].StartTime()) })
+	return worklogs, nil
+}
+
+// sameUser compares by account ID on Cloud and by username on Data Center.
+func sameUser(a, b *User) bool {
+	if a.AccountID != "" || b.AccountID != "" {
+		return a.AccountID == b.AccountID
+	}
+	return a.Name != "" && a.Name == b.Name
+}
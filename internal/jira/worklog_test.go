@@ -0,0 +1,71 @@
+package jira
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAddWorklog(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/rest/api/2/issue/PROJ-1/worklog" {
+			http.NotFound(w, r)
+			return
+		}
+		if q := r.URL.Query(); q.Get("adjustEstimate") != "new" || q.Get("newEstimate") != "240m" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["timeSpentSeconds"] != float64(5400) || body["comment"] != "Review" || body["started"] != "2024-03-01T13:00:00.000+0000" {
+			t.Errorf("body = %v", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"100","timeSpentSeconds":5400,"started":"2024-03-01T13:00:00.000+0000"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "me@example.com", "", "token")
+	w, err := c.AddWorklog("PROJ-1", WorklogInput{
+		Started:           time.Date(2024, 3, 1, 13, 0, 0, 0, time.UTC),
+		TimeSpent:         90 * time.Minute,
+		Comment:           "Review",
+		RemainingEstimate: 4 * time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.ID != "100" || w.Duration() != 90*time.Minute {
+		t.Errorf("worklog = %+v", w)
+	}
+}
+
+func TestGetUserWorklogs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/search/jql":
+			w.Write([]byte(`{"issues":[{"key":"PROJ-1","fields":{"summary":"One"}}],"total":1}`))
+		case "/rest/api/2/issue/PROJ-1/worklog":
+			w.Write([]byte(`{"total":3,"worklogs":[
+				{"id":"1","author":{"accountId":"me"},"started":"2024-03-04T10:00:00.000+0000","timeSpentSeconds":3600},
+				{"id":"2","author":{"accountId":"someone"},"started":"2024-03-04T11:00:00.000+0000","timeSpentSeconds":600},
+				{"id":"3","author":{"accountId":"me"},"started":"2024-02-28T10:00:00.000+0000","timeSpentSeconds":1800}
+			]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "me@example.com", "", "token")
+	from := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	worklogs, err := c.GetUserWorklogs(&User{AccountID: "me"}, from, from.AddDate(0, 0, 7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(worklogs) != 1 || worklogs[0].ID != "1" || worklogs[0].IssueKey != "PROJ-1" || worklogs[0].IssueSummary != "One" {
+		t.Errorf("worklogs = %+v", worklogs)
+	}
+}
@@ -0,0 +1,304 @@
+// Package output writes lists of records in the machine-readable formats
+// shared by the listing commands: plain tables, CSV, YAML, JSON, JSON Lines
+// and Go templates, with optional column selection.
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Formats accepted by Write. Readable is the commands' own coloured output
+// and is not handled here.
+const (
+	Readable = "readable"
+	Table    = "table"
+	CSV      = "csv"
+	YAML     = "yaml"
+	JSON     = "json"
+	JSONL    = "jsonl"
+	Template = "template"
+)
+
+// Formats lists every format name, Readable first.
+var Formats = []string{Readable, Table, CSV, YAML, JSON, JSONL, Template}
+
+// Options selects how a list is written.
+type Options struct {
+	Format   string
+	Template string   // for Template: executed once per row
+	Columns  []string // column names; empty means the default columns
+}
+
+// Column is one named value of a row. Extra columns are left out unless
+// asked for by name.
+type Column[T any] struct {
+	Name  string
+	Value func(T) string
+	Extra bool
+}
+
+// Via adapts columns of U to rows of T, e.g. to reuse issue columns for rows
+// that wrap an issue.
+func Via[T, U any](cols []Column[U], get func(T) U) []Column[T] {
+	out := make([]Column[T], len(cols))
+	for i, c := range cols {
+		value := c.Value
+		out[i] = Column[T]{Name: c.Name, Value: func(row T) string { return value(get(row)) }, Extra: c.Extra}
+	}
+	return out
+}
+
+// Check validates opts against the available columns without writing
+// anything, so flag mistakes can be reported before any slow work.
+func Check[T any](cols []Column[T], opts Options) error {
+	_, err := prepare(cols, opts)
+	return err
+}
+
+type plan[T any] struct {
+	cols []Column[T] // selected columns, nil when whole rows are written
+	tmpl *template.Template
+}
+
+func prepare[T any](cols []Column[T], opts Options) (plan[T], error) {
+	var p plan[T]
+	switch opts.Format {
+	case Table, CSV, YAML, JSON, JSONL:
+	case Template:
+		if opts.Template == "" {
+			return p, fmt.Errorf("--format template needs --template")
+		}
+		if len(opts.Columns) > 0 {
+			return p, fmt.Errorf("--columns cannot be combined with --format template")
+		}
+		tmpl, err := template.New("row").Funcs(funcs).Parse(opts.Template)
+		if err != nil {
+			return p, fmt.Errorf("invalid template: %w", err)
+		}
+		p.tmpl = tmpl
+		return p, nil
+	default:
+		return p, fmt.Errorf("unknown format %q (use %s)", opts.Format, strings.Join(Formats, ", "))
+	}
+	if opts.Template != "" {
+		return p, fmt.Errorf("--template needs --format template")
+	}
+
+	selected, err := selectColumns(cols, opts.Columns)
+	if err != nil {
+		return p, err
+	}
+	// JSON and YAML write whole rows unless columns are chosen.
+	if len(opts.Columns) > 0 || opts.Format == Table || opts.Format == CSV {
+		p.cols = selected
+	}
+	return p, nil
+}
+
+func selectColumns[T any](cols []Column[T], names []string) ([]Column[T], error) {
+	if len(names) == 0 {
+		var out []Column[T]
+		for _, c := range cols {
+			if !c.Extra {
+				out = append(out, c)
+			}
+		}
+		return out, nil
+	}
+	out := make([]Column[T], 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		found := false
+		for _, c := range cols {
+			if strings.EqualFold(c.Name, name) {
+				out = append(out, c)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown column %q (available: %s)", name, columnNames(cols))
+		}
+	}
+	return out, nil
+}
+
+func columnNames[T any](cols []Column[T]) string {
+	names := make([]string, len(cols))
+	for i, c := range cols {
+		names[i] = c.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+var funcs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// Write writes rows to w as opts asks. Rows are marshalled whole for JSON,
+// JSON Lines and YAML (with the same keys as JSON), and given to templates
+// as they are; tables and CSV show the selected columns.
+func Write[T any](w io.Writer, rows []T, cols []Column[T], opts Options) error {
+	p, err := prepare(cols, opts)
+	if err != nil {
+		return err
+	}
+
+	switch opts.Format {
+	case Table:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		header := make([]string, len(p.cols))
+		for i, c := range p.cols {
+			header[i] = strings.ToUpper(c.Name)
+		}
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+		for _, row := range rows {
+			fields := p.values(row)
+			for i, f := range fields {
+				// Tabs and newlines would break the alignment.
+				fields[i] = strings.Join(strings.Fields(f), " ")
+			}
+			fmt.Fprintln(tw, strings.Join(fields, "\t"))
+		}
+		return tw.Flush()
+
+	case CSV:
+		cw := csv.NewWriter(w)
+		header := make([]string, len(p.cols))
+		for i, c := range p.cols {
+			header[i] = c.Name
+		}
+		cw.Write(header)
+		for _, row := range rows {
+			cw.Write(p.values(row))
+		}
+		cw.Flush()
+		return cw.Error()
+
+	case Template:
+		for _, row := range rows {
+			var buf bytes.Buffer
+			if err := p.tmpl.Execute(&buf, row); err != nil {
+				return fmt.Errorf("template failed: %w", err)
+			}
+			if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+				buf.WriteByte('\n')
+			}
+			if _, err := w.Write(buf.Bytes()); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case JSONL:
+		enc := json.NewEncoder(w)
+		for _, row := range rows {
+			if err := enc.Encode(p.record(row)); err != nil {
+				return fmt.Errorf("failed to format JSON: %w", err)
+			}
+		}
+		return nil
+	}
+
+	records := make([]any, len(rows))
+	for i, row := range rows {
+		records[i] = p.record(row)
+	}
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format JSON: %w", err)
+	}
+	if opts.Format == YAML {
+		return writeYAML(w, data)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+func (p plan[T]) values(row T) []string {
+	values := make([]string, len(p.cols))
+	for i, c := range p.cols {
+		values[i] = c.Value(row)
+	}
+	return values
+}
+
+// record is what JSON, JSON Lines and YAML marshal for row: the row itself,
+// or an object of the selected columns in order.
+func (p plan[T]) record(row T) any {
+	if p.cols == nil {
+		return row
+	}
+	return orderedRecord{names: p.colNames(), values: p.values(row)}
+}
+
+func (p plan[T]) colNames() []string {
+	names := make([]string, len(p.cols))
+	for i, c := range p.cols {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// orderedRecord marshals as a JSON object keeping the column order.
+type orderedRecord struct {
+	names  []string
+	values []string
+}
+
+func (r orderedRecord) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, name := range r.names {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		value, _ := json.Marshal(r.values[i])
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// writeYAML re-encodes JSON as block-style YAML. Going through JSON keeps
+// the keys and their order the same in both formats.
+func writeYAML(w io.Writer, data []byte) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to format YAML: %w", err)
+	}
+	blockStyle(&doc)
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to format YAML: %w", err)
+	}
+	return enc.Close()
+}
+
+// blockStyle drops the flow style and quoting JSON parses with; the encoder
+// still quotes strings that would otherwise read as another type.
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
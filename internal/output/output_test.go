@@ -0,0 +1,149 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type item struct {
+	Key    string   `json:"key"`
+	Status string   `json:"status"`
+	Labels []string `json:"labels"`
+}
+
+var items = []item{
+	{Key: "PROJ-1", Status: "To Do", Labels: []string{"a", "b"}},
+	{Key: "PROJ-2", Status: "In Progress, blocked"},
+}
+
+var itemColumns = []Column[item]{
+	{Name: "key", Value: func(i item) string { return i.Key }},
+	{Name: "status", Value: func(i item) string { return i.Status }},
+	{Name: "labels", Value: func(i item) string { return strings.Join(i.Labels, ",") }, Extra: true},
+}
+
+func write(t *testing.T, opts Options) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := Write(&buf, items, itemColumns, opts); err != nil {
+		t.Fatalf("Write(%+v): %v", opts, err)
+	}
+	return buf.String()
+}
+
+func TestWriteTable(t *testing.T) {
+	got := write(t, Options{Format: Table})
+	want := "KEY     STATUS\nPROJ-1  To Do\nPROJ-2  In Progress, blocked\n"
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	got = write(t, Options{Format: Table, Columns: []string{"labels", "KEY"}})
+	want = "LABELS  KEY\na,b     PROJ-1\n        PROJ-2\n"
+	if got != want {
+		t.Errorf("selected columns: got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	got := write(t, Options{Format: CSV, Columns: []string{"key", "status", "labels"}})
+	want := "key,status,labels\nPROJ-1,To Do,\"a,b\"\nPROJ-2,\"In Progress, blocked\",\n"
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	got := write(t, Options{Format: JSON})
+	if !strings.Contains(got, `"labels": [`) || !strings.HasPrefix(got, "[\n") {
+		t.Errorf("whole rows expected, got:\n%s", got)
+	}
+
+	got = write(t, Options{Format: JSONL, Columns: []string{"status", "key"}})
+	want := `{"status":"To Do","key":"PROJ-1"}` + "\n" + `{"status":"In Progress, blocked","key":"PROJ-2"}` + "\n"
+	if got != want {
+		t.Errorf("jsonl: got:\n%s\nwant:\n%s", got, want)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, []item(nil), itemColumns, Options{Format: JSON}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "[]\n" {
+		t.Errorf("no rows: got %q", buf.String())
+	}
+}
+
+func TestWriteYAML(t *testing.T) {
+	got := write(t, Options{Format: YAML})
+	want := `- key: PROJ-1
+  status: To Do
+  labels:
+    - a
+    - b
+- key: PROJ-2
+  status: In Progress, blocked
+  labels: null
+`
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	// Strings that look like other types stay strings.
+	var buf bytes.Buffer
+	rows := []item{{Key: "123", Status: "true"}}
+	if err := Write(&buf, rows, itemColumns, Options{Format: YAML, Columns: []string{"key", "status"}}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "- key: \"123\"\n  status: \"true\"\n"; buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteTemplate(t *testing.T) {
+	got := write(t, Options{Format: Template, Template: `{{.Key}} {{lower .Status}} {{join .Labels "+"}}`})
+	want := "PROJ-1 to do a+b\nPROJ-2 in progress, blocked \n"
+	if got != want {
+		t.Errorf("got:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	for _, tc := range []struct {
+		opts Options
+		err  string
+	}{
+		{Options{Format: "xml"}, `unknown format "xml"`},
+		{Options{Format: Table, Columns: []string{"key", "nope"}}, `unknown column "nope" (available: key, status, labels)`},
+		{Options{Format: Template}, "needs --template"},
+		{Options{Format: Template, Template: "{{.Key"}, "invalid template"},
+		{Options{Format: Template, Template: "{{.Key}}", Columns: []string{"key"}}, "cannot be combined"},
+		{Options{Format: CSV, Template: "{{.Key}}"}, "--template needs --format template"},
+	} {
+		err := Check(itemColumns, tc.opts)
+		if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("Check(%+v) = %v, want error containing %q", tc.opts, err, tc.err)
+		}
+	}
+	if err := Check(itemColumns, Options{Format: YAML, Columns: []string{"Key"}}); err != nil {
+		t.Errorf("Check: %v", err)
+	}
+}
+
+func TestVia(t *testing.T) {
+	type wrapped struct {
+		Section string
+		Item    item
+	}
+	cols := append([]Column[wrapped]{{Name: "section", Value: func(w wrapped) string { return w.Section }}},
+		Via(itemColumns, func(w wrapped) item { return w.Item })...)
+	var buf bytes.Buffer
+	rows := []wrapped{{Section: "done", Item: items[0]}}
+	if err := Write(&buf, rows, cols, Options{Format: CSV}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "section,key,status\ndone,PROJ-1,To Do\n"; buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
@@ -3,6 +3,7 @@
 package prs
 
 import (
+	"context"
 	"fmt"
 	"sort"
 
@@ -89,20 +90,30 @@ func (o Options) wantGitHub() bool { return o.Source == "" || o.Source == "all"
 
 // Mine returns your open PRs across the configured sources.
 func Mine(cfg *Config, opts Options) ([]PR, []error) {
-	return collect(cfg, opts, "owner:self is:open -is:wip", github.Authored)
+	return MineContext(context.Background(), cfg, opts)
+}
+
+// MineContext is like Mine but carries ctx for cancellation.
+func MineContext(ctx context.Context, cfg *Config, opts Options) ([]PR, []error) {
+	return collect(ctx, cfg, opts, "owner:self is:open -is:wip", github.AuthoredContext)
 }
 
 // Team returns open PRs awaiting your review across the configured sources.
 func Team(cfg *Config, opts Options) ([]PR, []error) {
+	return TeamContext(context.Background(), cfg, opts)
+}
+
+// TeamContext is like Team but carries ctx for cancellation.
+func TeamContext(ctx context.Context, cfg *Config, opts Options) ([]PR, []error) {
 	q := fmt.Sprintf("is:open -is:wip -is:ignored -owner:self (reviewer:self OR cc:self)")
 	if cfg.GerritFilter != "" {
 		q = fmt.Sprintf("(%s) %s", q, cfg.GerritFilter)
 	}
-	return collect(cfg, opts, q, github.ReviewRequested)
+	return collect(ctx, cfg, opts, q, github.ReviewRequestedContext)
 }
 
 // collect runs the gerrit query and the github lister, merging into unified PRs.
-func collect(cfg *Config, opts Options, gerritQuery string, ghList func([]string, int) ([]github.PR, error)) ([]PR, []error) {
+func collect(ctx context.Context, cfg *Config, opts Options, gerritQuery string, ghList func(context.Context, []string, int) ([]github.PR, error)) ([]PR, []error) {
 	limit := opts.Limit
 	if limit <= 0 {
 		limit = 25
@@ -115,7 +126,7 @@ func collect(cfg *Config, opts Options, gerritQuery string, ghList func([]string
 		if err != nil {
 			errs = append(errs, fmt.Errorf("gerrit: %w", err))
 		} else {
-			changes, err := gerrit.NewClient(gcfg).ListChanges(gerritQuery, limit)
+			changes, err := gerrit.NewClient(gcfg).ListChangesContext(ctx, gerritQuery, limit)
 			if err != nil {
 				errs = append(errs, fmt.Errorf("gerrit: %w", err))
 			} else {
@@ -134,7 +145,7 @@ func collect(cfg *Config, opts Options, gerritQuery string, ghList func([]string
 		} else if len(cfg.GitHubRepos) == 0 {
 			errs = append(errs, fmt.Errorf("github: no repos configured (set github_repos in [prs])"))
 		} else {
-			ghPRs, err := ghList(cfg.GitHubRepos, limit)
+			ghPRs, err := ghList(ctx, cfg.GitHubRepos, limit)
 			if err != nil {
 				errs = append(errs, fmt.Errorf("github: %w", err))
 			}
@@ -0,0 +1,201 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	fileStoreName = "secrets.enc"
+	keyFileName   = "secrets.key"
+
+	// PassphraseEnv, when set, derives the encryption key from a passphrase
+	// instead of the generated key file.
+	PassphraseEnv = "JET_SECRETS_PASSPHRASE"
+
+	pbkdf2Iterations = 600000
+)
+
+// FileStore keeps secrets in an AES-256-GCM encrypted file. The key comes
+// from JET_SECRETS_PASSPHRASE when set, otherwise from a random key file
+// created next to the data with 0600 permissions.
+type FileStore struct {
+	dir string
+}
+
+// encryptedFile is the on-disk format of the store.
+type encryptedFile struct {
+	Version int    `json:"version"`
+	KDF     string `json:"kdf"` // "keyfile" or "pbkdf2-sha256"
+	Salt    []byte `json:"salt,omitempty"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+// NewFileStore returns a file store rooted at dir (normally ~/.jet).
+func NewFileStore(dir string) (*FileStore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("secret store directory not set")
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) Name() string { return "file" }
+
+func (s *FileStore) Get(key string) (string, error) {
+	values, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	v, ok := values[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return v, nil
+}
+
+func (s *FileStore) Set(key, value string) error {
+	values, err := s.load()
+	if err != nil {
+		return err
+	}
+	values[key] = value
+	return s.save(values)
+}
+
+func (s *FileStore) Delete(key string) error {
+	values, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := values[key]; !ok {
+		return ErrNotFound
+	}
+	delete(values, key)
+	return s.save(values)
+}
+
+func (s *FileStore) path() string { return filepath.Join(s.dir, fileStoreName) }
+
+func (s *FileStore) load() (map[string]string, error) {
+	values := make(map[string]string)
+	raw, err := os.ReadFile(s.path())
+	if os.IsNotExist(err) {
+		return values, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret store: %w", err)
+	}
+
+	var f encryptedFile
+	if err := json.Unmarshal(raw, &f); err != nil {
+		return nil, fmt.Errorf("failed to decode secret store: %w", err)
+	}
+	gcm, err := s.cipher(f.KDF, f.Salt, false)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := gcm.Open(nil, f.Nonce, f.Data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secret store (wrong key or passphrase?)")
+	}
+	if err := json.Unmarshal(plain, &values); err != nil {
+		return nil, fmt.Errorf("failed to decode secret store: %w", err)
+	}
+	return values, nil
+}
+
+func (s *FileStore) save(values map[string]string) error {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", s.dir, err)
+	}
+
+	f := encryptedFile{Version: 1, KDF: "keyfile"}
+	if os.Getenv(PassphraseEnv) != "" {
+		f.KDF = "pbkdf2-sha256"
+		f.Salt = make([]byte, 16)
+		if _, err := rand.Read(f.Salt); err != nil {
+			return err
+		}
+	}
+	gcm, err := s.cipher(f.KDF, f.Salt, true)
+	if err != nil {
+		return err
+	}
+	f.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(f.Nonce); err != nil {
+		return err
+	}
+	plain, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	f.Data = gcm.Seal(nil, f.Nonce, plain, nil)
+
+	out, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	// Write atomically so an interrupted save never corrupts existing secrets.
+	tmp := s.path() + ".tmp"
+	if err := os.WriteFile(tmp, out, 0600); err != nil {
+		return fmt.Errorf("failed to write secret store: %w", err)
+	}
+	return os.Rename(tmp, s.path())
+}
+
+// cipher returns the AEAD for the given key derivation. create allows a
+// missing key file to be generated (only when saving).
+func (s *FileStore) cipher(kdf string, salt []byte, create bool) (cipher.AEAD, error) {
+	var key []byte
+	var err error
+	switch kdf {
+	case "pbkdf2-sha256":
+		pass := os.Getenv(PassphraseEnv)
+		if pass == "" {
+			return nil, fmt.Errorf("secret store is passphrase-protected: set %s", PassphraseEnv)
+		}
+		key, err = pbkdf2.Key(sha256.New, pass, salt, pbkdf2Iterations, 32)
+	case "keyfile", "":
+		key, err = s.keyFile(create)
+	default:
+		return nil, fmt.Errorf("unsupported secret store format %q", kdf)
+	}
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (s *FileStore) keyFile(create bool) ([]byte, error) {
+	path := filepath.Join(s.dir, keyFileName)
+	key, err := os.ReadFile(path)
+	if err == nil {
+		if len(key) != 32 {
+			return nil, fmt.Errorf("invalid key file %s", path)
+		}
+		return key, nil
+	}
+	if !os.IsNotExist(err) || !create {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	key = make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, key, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write key file: %w", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,88 @@
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileStoreRoundTrip(t *testing.T) {
+	t.Setenv(PassphraseEnv, "")
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.Get("jira"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get on empty store: got %v, want ErrNotFound", err)
+	}
+	if err := store.Set("jira", "s3cret-token"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Set("profile work", "other"); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, fileStoreName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(raw, []byte("s3cret-token")) {
+		t.Error("token stored in plaintext")
+	}
+	info, err := os.Stat(filepath.Join(dir, keyFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("key file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	// A fresh store instance must decrypt what the first one wrote.
+	reopened, _ := NewFileStore(dir)
+	if got, err := reopened.Get("jira"); err != nil || got != "s3cret-token" {
+		t.Errorf("Get = (%q, %v)", got, err)
+	}
+	if err := reopened.Delete("jira"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reopened.Get("jira"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("deleted secret still present: %v", err)
+	}
+	if got, _ := reopened.Get("profile work"); got != "other" {
+		t.Errorf("unrelated secret lost: %q", got)
+	}
+}
+
+func TestFileStorePassphrase(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(PassphraseEnv, "correct horse")
+	store, _ := NewFileStore(dir)
+	if err := store.Set("jira", "tok"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, keyFileName)); !os.IsNotExist(err) {
+		t.Error("passphrase mode should not create a key file")
+	}
+	if got, err := store.Get("jira"); err != nil || got != "tok" {
+		t.Errorf("Get = (%q, %v)", got, err)
+	}
+
+	t.Setenv(PassphraseEnv, "wrong")
+	if _, err := store.Get("jira"); err == nil {
+		t.Error("wrong passphrase should fail to decrypt")
+	}
+	t.Setenv(PassphraseEnv, "")
+	if _, err := store.Get("jira"); err == nil {
+		t.Error("missing passphrase should be reported")
+	}
+}
+
+func TestOpenUnknownBackend(t *testing.T) {
+	if _, err := Open("vault"); err == nil {
+		t.Error("expected error for unknown backend")
+	}
+}
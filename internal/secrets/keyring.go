@@ -0,0 +1,142 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// keyringService is the service/label under which tokens are stored.
+const keyringService = "jet"
+
+// KeyringStore uses the OS credential store through its command-line tool:
+// `security` (macOS Keychain) or `secret-tool` (libsecret on Linux).
+type KeyringStore struct {
+	tool string
+}
+
+// KeyringAvailable reports whether an OS keyring tool is installed.
+func KeyringAvailable() bool {
+	_, err := exec.LookPath(keyringTool())
+	return err == nil
+}
+
+func keyringTool() string {
+	if runtime.GOOS == "darwin" {
+		return "security"
+	}
+	return "secret-tool"
+}
+
+// NewKeyringStore returns a keyring-backed store, or an error if the
+// platform's keyring tool is not installed.
+func NewKeyringStore() (*KeyringStore, error) {
+	tool := keyringTool()
+	if _, err := exec.LookPath(tool); err != nil {
+		return nil, fmt.Errorf("keyring not available: %s not found in PATH (use token_store = file instead)", tool)
+	}
+	return &KeyringStore{tool: tool}, nil
+}
+
+func (s *KeyringStore) Name() string { return "keyring" }
+
+func (s *KeyringStore) Get(key string) (string, error) {
+	var args []string
+	if s.tool == "security" {
+		args = []string{"find-generic-password", "-s", keyringService, "-a", key, "-w"}
+	} else {
+		args = []string{"lookup", "service", keyringService, "account", key}
+	}
+	out, stderr, err := s.exec("", args...)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && s.notFound(exitErr.ExitCode(), stderr) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", s.toolError(args[0], err, stderr)
+	}
+	return strings.TrimRight(out, "\r\n"), nil
+}
+
+// notFound reports whether a lookup that exited with code and stderr found no
+// item. security exits with errSecItemNotFound (44); secret-tool exits 1
+// without a message. Other failures, such as a locked keychain, are errors.
+func (s *KeyringStore) notFound(code int, stderr string) bool {
+	if s.tool == "security" {
+		return code == 44
+	}
+	return code == 1 && strings.TrimSpace(stderr) == ""
+}
+
+func (s *KeyringStore) Set(key, value string) error {
+	// Both tools get the token on stdin, so it never shows in ps. security
+	// only reads a password from stdin in its interactive mode, where the
+	// command itself is the input; -X takes the password as hex, which
+	// needs no quoting.
+	if s.tool == "security" {
+		line := fmt.Sprintf("add-generic-password -U -s %s -a %s -X %s\n", quoteSecurityArg(keyringService), quoteSecurityArg(key), hex.EncodeToString([]byte(value)))
+		cmd := exec.Command(s.tool, "-i")
+		cmd.Stdin = strings.NewReader(line)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		err := cmd.Run()
+		msg := strings.TrimSpace(stderr.String())
+		if err != nil {
+			return fmt.Errorf("%s -i: %w: %s", s.tool, err, msg)
+		}
+		// security -i exits 0 when the command fails, but says why.
+		if msg != "" {
+			return fmt.Errorf("%s add-generic-password: %s", s.tool, msg)
+		}
+		return nil
+	}
+	_, err := s.run(value, "store", "--label", keyringService+" "+key, "service", keyringService, "account", key)
+	return err
+}
+
+func (s *KeyringStore) Delete(key string) error {
+	var err error
+	if s.tool == "security" {
+		_, err = s.run("", "delete-generic-password", "-s", keyringService, "-a", key)
+	} else {
+		_, err = s.run("", "clear", "service", keyringService, "account", key)
+	}
+	return err
+}
+
+// quoteSecurityArg quotes arg for a command line read by security -i.
+func quoteSecurityArg(arg string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arg) + `"`
+}
+
+func (s *KeyringStore) run(stdin string, args ...string) (string, error) {
+	out, stderr, err := s.exec(stdin, args...)
+	if err != nil {
+		return "", s.toolError(args[0], err, stderr)
+	}
+	return out, nil
+}
+
+// exec runs the keyring tool with args and returns what it printed.
+func (s *KeyringStore) exec(stdin string, args ...string) (stdout, stderr string, err error) {
+	cmd := exec.Command(s.tool, args...)
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	var out, errOut bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &errOut
+	err = cmd.Run()
+	return out.String(), errOut.String(), err
+}
+
+func (s *KeyringStore) toolError(command string, err error, stderr string) error {
+	if msg := strings.TrimSpace(stderr); msg != "" {
+		return fmt.Errorf("%s %s: %w: %s", s.tool, command, err, msg)
+	}
+	return fmt.Errorf("%s %s: %w", s.tool, command, err)
+}
@@ -0,0 +1,74 @@
+// Package secrets stores API tokens outside of ~/.jira_config. A Store is a
+// simple key/value vault; the "file" backend encrypts values on disk and works
+// on headless machines, the "keyring" backend uses the OS credential store.
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ErrNotFound is returned by Store.Get when no secret exists for a key.
+var ErrNotFound = errors.New("secret not found")
+
+// Store is a backend that can persist secrets by key.
+type Store interface {
+	// Name returns the backend name used in token_store (e.g. "file").
+	Name() string
+	Get(key string) (string, error)
+	Set(key, value string) error
+	Delete(key string) error
+}
+
+// backends maps backend names to constructors. Register adds to it.
+var backends = map[string]func() (Store, error){
+	"file":    func() (Store, error) { return NewFileStore(defaultDir()) },
+	"keyring": func() (Store, error) { return NewKeyringStore() },
+}
+
+// Register makes a backend available to Open under name, replacing any
+// existing backend with that name.
+func Register(name string, open func() (Store, error)) {
+	backends[strings.ToLower(name)] = open
+}
+
+// Backends returns the names of all registered backends, sorted.
+func Backends() []string {
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Open returns the named backend.
+func Open(name string) (Store, error) {
+	open, ok := backends[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, fmt.Errorf("unknown secret store %q (available: %s)", name, strings.Join(Backends(), ", "))
+	}
+	return open()
+}
+
+// Default returns the preferred backend on this machine: the OS keyring when
+// its command-line tool is installed, otherwise the encrypted file store.
+func Default() (Store, error) {
+	if KeyringAvailable() {
+		return Open("keyring")
+	}
+	return Open("file")
+}
+
+// defaultDir returns ~/.jet, where the file backend keeps its data.
+func defaultDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".jet"
+	}
+	return filepath.Join(home, ".jet")
+}
@@ -0,0 +1,284 @@
+// Package templates reads issue templates: YAML files in ~/.jet/templates/
+// describing an issue, and optionally children to create under it, with
+// ${name} variables filled in when the template is used.
+package templates
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Template is an issue template. A template named bug lives in bug.yaml.
+//
+//	about: Bug with a triage checklist
+//	vars:
+//	  component:        # no default: must be given
+//	  severity: Medium
+//	issue:
+//	  project: PROJ
+//	  type: Bug
+//	  summary: "[${component}] ${title}"
+//	  priority: ${severity}
+//	  components: ["${component}"]
+//	children:
+//	  - type: Sub-task
+//	    summary: Reproduce ${title}
+type Template struct {
+	Name     string             `yaml:"-"`
+	About    string             `yaml:"about"`
+	Vars     map[string]*string `yaml:"vars"` // defaults; nil when required
+	Issue    Issue              `yaml:"issue"`
+	Children []Issue            `yaml:"children"`
+}
+
+// Issue holds the fields of one issue of a template. Fields names further
+// fields by name or ID, as jet create --field does.
+type Issue struct {
+	Project     string            `yaml:"project"`
+	Type        string            `yaml:"type"`
+	Parent      string            `yaml:"parent"` // an existing parent or epic, for the top issue
+	Summary     string            `yaml:"summary"`
+	Description string            `yaml:"description"`
+	Markdown    bool              `yaml:"markdown"`
+	Priority    string            `yaml:"priority"`
+	Labels      []string          `yaml:"labels"`
+	Components  []string          `yaml:"components"`
+	FixVersions []string          `yaml:"fixVersions"`
+	Assignee    string            `yaml:"assignee"`
+	Fields      map[string]string `yaml:"fields"`
+}
+
+// Dir returns the directory templates are read from.
+func Dir() string {
+	return filepath.Join(os.Getenv("HOME"), ".jet", "templates")
+}
+
+// Load reads the template called name from Dir, or from the file name when
+// it is a path to a .yaml or .yml file.
+func Load(name string) (*Template, error) {
+	if strings.ContainsRune(name, os.PathSeparator) || strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml") {
+		return read(name)
+	}
+	for _, ext := range []string{".yaml", ".yml"} {
+		t, err := read(filepath.Join(Dir(), name+ext))
+		if !errors.Is(err, fs.ErrNotExist) {
+			return t, err
+		}
+	}
+
+	all, err := List()
+	if err != nil {
+		return nil, err
+	}
+	if len(all) == 0 {
+		return nil, fmt.Errorf("template %q not found: no templates in %s", name, Dir())
+	}
+	var names []string
+	for _, t := range all {
+		names = append(names, t.Name)
+	}
+	return nil, fmt.Errorf("template %q not found (available: %s)", name, strings.Join(names, ", "))
+}
+
+// List returns the templates in Dir by name.
+func List() ([]*Template, error) {
+	entries, err := os.ReadDir(Dir())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read templates: %w", err)
+	}
+	var all []*Template
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+		t, err := read(filepath.Join(Dir(), e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, t)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	return all, nil
+}
+
+func read(path string) (*Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	t, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("template %s: %w", path, err)
+	}
+	base := filepath.Base(path)
+	t.Name = strings.TrimSuffix(base, filepath.Ext(base))
+	return t, nil
+}
+
+// Parse decodes a template. Unknown keys are errors, to catch misspelt
+// fields.
+func Parse(data []byte) (*Template, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var t Template
+	if err := dec.Decode(&t); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	for i, c := range t.Children {
+		if strings.TrimSpace(c.Summary) == "" {
+			return nil, fmt.Errorf("child %d has no summary", i+1)
+		}
+		if c.Parent != "" {
+			return nil, fmt.Errorf("child %d: children are created under the template's issue and cannot set a parent", i+1)
+		}
+	}
+	return &t, nil
+}
+
+// ParseVars turns NAME=VALUE arguments into variables.
+func ParseVars(args []string) (map[string]string, error) {
+	vars := make(map[string]string)
+	for _, arg := range args {
+		name, value, ok := strings.Cut(arg, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid variable %q: use NAME=VALUE", arg)
+		}
+		vars[name] = value
+	}
+	return vars, nil
+}
+
+// varPattern matches ${name}, and $$ for a literal $.
+var varPattern = regexp.MustCompile(`\$\$|\$\{([A-Za-z_][A-Za-z0-9_-]*)\}`)
+
+// Expand returns a copy of t with its variables replaced by vars, or by
+// their defaults. Variables that have no value are an error, as are vars
+// the template does not use or declare. List values that expand to nothing
+// are dropped, so an empty variable can leave out a label.
+func (t *Template) Expand(vars map[string]string) (*Template, error) {
+	values := make(map[string]string)
+	for name, def := range t.Vars {
+		if def != nil {
+			values[name] = *def
+		}
+	}
+	for name, v := range vars {
+		values[name] = v
+	}
+
+	used := make(map[string]bool)
+	missing := make(map[string]bool)
+	expand := func(s string) string {
+		return varPattern.ReplaceAllStringFunc(s, func(m string) string {
+			if m == "$$" {
+				return "$"
+			}
+			name := m[2 : len(m)-1]
+			used[name] = true
+			v, ok := values[name]
+			if !ok {
+				missing[name] = true
+			}
+			return v
+		})
+	}
+	expandIssue := func(in Issue) Issue {
+		out := in
+		for _, s := range []*string{&out.Project, &out.Type, &out.Parent, &out.Summary, &out.Description, &out.Priority, &out.Assignee} {
+			*s = expand(*s)
+		}
+		for _, list := range []*[]string{&out.Labels, &out.Components, &out.FixVersions} {
+			var expanded []string
+			for _, v := range *list {
+				if v = strings.TrimSpace(expand(v)); v != "" {
+					expanded = append(expanded, v)
+				}
+			}
+			*list = expanded
+		}
+		if in.Fields != nil {
+			out.Fields = make(map[string]string, len(in.Fields))
+			for name, v := range in.Fields {
+				out.Fields[name] = expand(v)
+			}
+		}
+		return out
+	}
+
+	out := *t
+	out.Issue = expandIssue(t.Issue)
+	out.Children = nil
+	for _, c := range t.Children {
+		out.Children = append(out.Children, expandIssue(c))
+	}
+
+	if len(missing) > 0 {
+		names := sortedKeys(missing)
+		return nil, fmt.Errorf("template %s needs %s (use --var NAME=VALUE)", t.Name, strings.Join(names, ", "))
+	}
+	for _, name := range sortedKeys(vars) {
+		if _, declared := t.Vars[name]; !declared && !used[name] {
+			return nil, fmt.Errorf("template %s has no variable %q", t.Name, name)
+		}
+	}
+	return &out, nil
+}
+
+// Variables lists the variables t declares or uses, by name.
+func (t *Template) Variables() []string {
+	names := make(map[string]bool)
+	for name := range t.Vars {
+		names[name] = true
+	}
+	collect := func(s string) {
+		for _, m := range varPattern.FindAllStringSubmatch(s, -1) {
+			if m[1] != "" {
+				names[m[1]] = true
+			}
+		}
+	}
+	for _, issue := range append([]Issue{t.Issue}, t.Children...) {
+		for _, s := range slices.Concat([]string{issue.Project, issue.Type, issue.Parent, issue.Summary, issue.Description, issue.Priority, issue.Assignee},
+			issue.Labels, issue.Components, issue.FixVersions) {
+			collect(s)
+		}
+		for _, v := range issue.Fields {
+			collect(v)
+		}
+	}
+	return sortedKeys(names)
+}
+
+// FieldArgs returns the Fields of i as NAME=VALUE arguments, sorted by name.
+func (i Issue) FieldArgs() []string {
+	var args []string
+	for _, name := range sortedKeys(i.Fields) {
+		args = append(args, name+"="+i.Fields[name])
+	}
+	return args
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,124 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const bugTemplate = `about: Bug with a triage checklist
+vars:
+  component:
+  severity: Medium
+  team: ""
+issue:
+  project: PROJ
+  type: Bug
+  summary: "[${component}] ${title}"
+  description: |
+    Costs $$5 an hour.
+  priority: ${severity}
+  labels: [bug, "${team}"]
+  components: ["${component}"]
+  fields:
+    Story Points: 3
+children:
+  - type: Sub-task
+    summary: Reproduce ${title}
+  - summary: Fix ${title}
+`
+
+func TestExpand(t *testing.T) {
+	tmpl, err := Parse([]byte(bugTemplate))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl.Name = "bug"
+
+	got, err := tmpl.Expand(map[string]string{"component": "api", "title": "Login fails"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	issue := got.Issue
+	if issue.Summary != "[api] Login fails" || issue.Priority != "Medium" || issue.Description != "Costs $5 an hour.\n" {
+		t.Errorf("issue = %+v", issue)
+	}
+	if !reflect.DeepEqual(issue.Labels, []string{"bug"}) || !reflect.DeepEqual(issue.Components, []string{"api"}) {
+		t.Errorf("labels %q, components %q", issue.Labels, issue.Components)
+	}
+	if args := issue.FieldArgs(); !reflect.DeepEqual(args, []string{"Story Points=3"}) {
+		t.Errorf("field args = %q", args)
+	}
+	if len(got.Children) != 2 || got.Children[1].Summary != "Fix Login fails" {
+		t.Errorf("children = %+v", got.Children)
+	}
+	if tmpl.Issue.Summary != "[${component}] ${title}" {
+		t.Error("Expand changed the template")
+	}
+	if vars := strings.Join(tmpl.Variables(), " "); vars != "component severity team title" {
+		t.Errorf("variables = %s", vars)
+	}
+
+	for want, vars := range map[string]map[string]string{
+		"template bug needs component, title (use --var NAME=VALUE)": {"severity": "High"},
+		`template bug has no variable "compnent"`:                    {"component": "api", "title": "x", "compnent": "db"},
+	} {
+		if _, err := tmpl.Expand(vars); err == nil || err.Error() != want {
+			t.Errorf("Expand(%v) = %v, want %s", vars, err, want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for doc, want := range map[string]string{
+		"issue:\n  sumary: x\n":                        "field sumary not found",
+		"children:\n  - type: Task\n":                  "child 1 has no summary",
+		"children:\n  - summary: x\n    parent: P-1\n": "child 1: children are created under",
+	} {
+		if _, err := Parse([]byte(doc)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Parse(%q) = %v, want %s", doc, err, want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if _, err := Load("bug"); err == nil || !strings.Contains(err.Error(), "no templates in") {
+		t.Errorf("without templates: %v", err)
+	}
+
+	if err := os.MkdirAll(Dir(), 0755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(Dir(), "bug.yaml"), []byte(bugTemplate), 0644)
+	os.WriteFile(filepath.Join(Dir(), "spike.yml"), []byte("issue:\n  type: Spike\n"), 0644)
+	os.WriteFile(filepath.Join(Dir(), "notes.txt"), []byte("not a template"), 0644)
+
+	for _, name := range []string{"bug", "spike", filepath.Join(Dir(), "bug.yaml")} {
+		tmpl, err := Load(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := strings.TrimSuffix(filepath.Base(name), ".yaml"); tmpl.Name != want {
+			t.Errorf("Load(%s).Name = %s", name, tmpl.Name)
+		}
+	}
+	if _, err := Load("epic"); err == nil || err.Error() != `template "epic" not found (available: bug, spike)` {
+		t.Errorf("missing template: %v", err)
+	}
+}
+
+func TestParseVars(t *testing.T) {
+	vars, err := ParseVars([]string{"component=api", "title=a=b", "empty="})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(vars, map[string]string{"component": "api", "title": "a=b", "empty": ""}) {
+		t.Errorf("vars = %v", vars)
+	}
+	if _, err := ParseVars([]string{"component"}); err == nil {
+		t.Error("no error for a variable without a value")
+	}
+}
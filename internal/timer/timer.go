@@ -0,0 +1,192 @@
+// Package timer keeps a local start/stop work timer that survives restarts
+// and converts into Jira worklogs.
+package timer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"jet/internal/jira"
+)
+
+// Timer is a running work timer for one issue.
+type Timer struct {
+	IssueKey string    `json:"issueKey"`
+	Started  time.Time `json:"started"`
+	Comment  string    `json:"comment,omitempty"`
+}
+
+// Elapsed returns how long the timer has been running at now.
+func (t *Timer) Elapsed(now time.Time) time.Duration {
+	if d := now.Sub(t.Started); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// Rounding turns elapsed time into the time to log. Elapsed time is rounded
+// to a multiple of Increment (up, down or to the nearest); anything under
+// Minimum after rounding is not logged.
+type Rounding struct {
+	Increment time.Duration
+	Mode      string // "up", "down" or "nearest"
+	Minimum   time.Duration
+}
+
+// DefaultRounding rounds to the nearest minute, the smallest unit Jira logs.
+var DefaultRounding = Rounding{Increment: time.Minute, Mode: "nearest", Minimum: time.Minute}
+
+// Apply rounds d. It returns 0 when the result is below the minimum.
+func (r Rounding) Apply(d time.Duration) time.Duration {
+	inc := r.Increment
+	if inc <= 0 {
+		inc = time.Minute
+	}
+	var out time.Duration
+	switch r.Mode {
+	case "up":
+		out = (d + inc - 1).Truncate(inc)
+	case "down":
+		out = d.Truncate(inc)
+	default:
+		out = d.Round(inc)
+	}
+	// Jira cannot log less than a minute whatever the rounding says.
+	min := r.Minimum
+	if min < time.Minute {
+		min = time.Minute
+	}
+	if out < min {
+		return 0
+	}
+	return out
+}
+
+// ErrNotRunning is returned when stopping without a running timer.
+var ErrNotRunning = errors.New("no timer is running")
+
+// Store persists the active timer in a JSON file.
+type Store struct {
+	Path     string
+	Rounding Rounding
+}
+
+// DefaultPath returns ~/.jet/timer.json.
+func DefaultPath() string {
+	return filepath.Join(os.Getenv("HOME"), ".jet", "timer.json")
+}
+
+// Load returns the running timer, or nil when none is running.
+func (s *Store) Load() (*Timer, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read timer: %w", err)
+	}
+	var t Timer
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("failed to read timer %s: %w", s.Path, err)
+	}
+	if t.IssueKey == "" {
+		return nil, nil
+	}
+	return &t, nil
+}
+
+// Start starts a timer for issueKey at now. It fails if a timer is already
+// running; the caller decides whether to stop it first.
+func (s *Store) Start(issueKey, comment string, now time.Time) (*Timer, error) {
+	running, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+	if running != nil {
+		return nil, fmt.Errorf("a timer is already running for %s", running.IssueKey)
+	}
+	t := &Timer{IssueKey: issueKey, Started: now, Comment: comment}
+	if err := s.save(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Worklogger posts worklogs; *jira.Client implements it.
+type Worklogger interface {
+	AddWorklogContext(ctx context.Context, issueKey string, in jira.WorklogInput) (*jira.Worklog, error)
+}
+
+// StopResult describes a stopped timer.
+type StopResult struct {
+	Timer   *Timer
+	Elapsed time.Duration
+	Logged  time.Duration // 0 when the time was too short to log
+	Worklog *jira.Worklog
+}
+
+// Stop logs the running timer as a worklog starting when the timer did,
+// with the elapsed time rounded by the store's rules, and clears it. A
+// non-empty comment replaces the one given at start. When posting fails the
+// timer keeps running so no time is lost.
+func (s *Store) Stop(ctx context.Context, wl Worklogger, comment string, now time.Time) (*StopResult, error) {
+	t, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrNotRunning
+	}
+	res := &StopResult{Timer: t, Elapsed: t.Elapsed(now)}
+	res.Logged = s.Rounding.Apply(res.Elapsed)
+	if comment == "" {
+		comment = t.Comment
+	}
+
+	if res.Logged > 0 {
+		res.Worklog, err = wl.AddWorklogContext(ctx, t.IssueKey, jira.WorklogInput{
+			Started:   t.Started,
+			TimeSpent: res.Logged,
+			Comment:   comment,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to log work on %s (timer still running): %w", t.IssueKey, err)
+		}
+	}
+	if err := s.Clear(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Clear removes the running timer without logging it.
+func (s *Store) Clear() error {
+	if err := os.Remove(s.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to clear timer: %w", err)
+	}
+	return nil
+}
+
+// save writes the timer atomically so a crash never leaves half a file.
+func (s *Store) save(t *Timer) error {
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0700); err != nil {
+		return fmt.Errorf("failed to save timer: %w", err)
+	}
+	tmp := s.Path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to save timer: %w", err)
+	}
+	if err := os.Rename(tmp, s.Path); err != nil {
+		return fmt.Errorf("failed to save timer: %w", err)
+	}
+	return nil
+}
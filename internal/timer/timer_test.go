@@ -0,0 +1,160 @@
+package timer
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"jet/internal/jira"
+)
+
+func TestRoundingApply(t *testing.T) {
+	tests := []struct {
+		name string
+		r    Rounding
+		in   time.Duration
+		want time.Duration
+	}{
+		{"default nearest", DefaultRounding, 61*time.Minute + 29*time.Second, 61 * time.Minute},
+		{"default under a minute", DefaultRounding, 20 * time.Second, 0},
+		{"default rounds up to a minute", DefaultRounding, 40 * time.Second, time.Minute},
+		{"up to quarter", Rounding{Increment: 15 * time.Minute, Mode: "up"}, 16 * time.Minute, 30 * time.Minute},
+		{"up exact", Rounding{Increment: 15 * time.Minute, Mode: "up"}, 30 * time.Minute, 30 * time.Minute},
+		{"down to quarter", Rounding{Increment: 15 * time.Minute, Mode: "down"}, 29 * time.Minute, 15 * time.Minute},
+		{"down below increment", Rounding{Increment: 15 * time.Minute, Mode: "down"}, 14 * time.Minute, 0},
+		{"nearest quarter", Rounding{Increment: 15 * time.Minute, Mode: "nearest"}, 23 * time.Minute, 30 * time.Minute},
+		{"below minimum", Rounding{Increment: time.Minute, Minimum: 5 * time.Minute}, 4 * time.Minute, 0},
+		{"at minimum", Rounding{Increment: time.Minute, Minimum: 5 * time.Minute}, 5 * time.Minute, 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Apply(tt.in); got != tt.want {
+				t.Errorf("Apply(%s) = %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func testStore(t *testing.T) *Store {
+	return &Store{Path: filepath.Join(t.TempDir(), ".jet", "timer.json"), Rounding: DefaultRounding}
+}
+
+func TestStoreStartLoadClear(t *testing.T) {
+	s := testStore(t)
+	if got, err := s.Load(); err != nil || got != nil {
+		t.Fatalf("Load() on empty store = %v, %v", got, err)
+	}
+
+	started := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	if _, err := s.Start("PROJ-1", "design review", started); err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.IssueKey != "PROJ-1" || !got.Started.Equal(started) || got.Comment != "design review" {
+		t.Errorf("Load() = %+v", got)
+	}
+	if _, err := s.Start("PROJ-2", "", started); err == nil {
+		t.Error("Start() with a running timer succeeded")
+	}
+
+	if err := s.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := s.Load(); got != nil {
+		t.Errorf("Load() after Clear() = %+v", got)
+	}
+	if err := s.Clear(); err != nil {
+		t.Errorf("Clear() without a timer: %v", err)
+	}
+}
+
+type fakeWorklogger struct {
+	key   string
+	input jira.WorklogInput
+	err   error
+}
+
+func (f *fakeWorklogger) AddWorklogContext(ctx context.Context, issueKey string, in jira.WorklogInput) (*jira.Worklog, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.key, f.input = issueKey, in
+	return &jira.Worklog{ID: "100", TimeSpentSeconds: int(in.TimeSpent / time.Second)}, nil
+}
+
+func TestStoreStop(t *testing.T) {
+	started := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("logs rounded time", func(t *testing.T) {
+		s := testStore(t)
+		s.Rounding = Rounding{Increment: 15 * time.Minute, Mode: "up"}
+		s.Start("PROJ-1", "from start", started)
+
+		wl := &fakeWorklogger{}
+		res, err := s.Stop(context.Background(), wl, "", started.Add(50*time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Elapsed != 50*time.Minute || res.Logged != time.Hour || res.Worklog.ID != "100" {
+			t.Errorf("Stop() = %+v", res)
+		}
+		if wl.key != "PROJ-1" || !wl.input.Started.Equal(started) || wl.input.TimeSpent != time.Hour || wl.input.Comment != "from start" {
+			t.Errorf("posted %s %+v", wl.key, wl.input)
+		}
+		if got, _ := s.Load(); got != nil {
+			t.Errorf("timer still running after Stop(): %+v", got)
+		}
+	})
+
+	t.Run("comment replaces start comment", func(t *testing.T) {
+		s := testStore(t)
+		s.Start("PROJ-1", "from start", started)
+		wl := &fakeWorklogger{}
+		if _, err := s.Stop(context.Background(), wl, "from stop", started.Add(time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+		if wl.input.Comment != "from stop" {
+			t.Errorf("comment = %q", wl.input.Comment)
+		}
+	})
+
+	t.Run("failure keeps timer", func(t *testing.T) {
+		s := testStore(t)
+		s.Start("PROJ-1", "", started)
+		wl := &fakeWorklogger{err: errors.New("boom")}
+		if _, err := s.Stop(context.Background(), wl, "", started.Add(time.Hour)); err == nil {
+			t.Fatal("Stop() succeeded")
+		}
+		if got, _ := s.Load(); got == nil || got.IssueKey != "PROJ-1" {
+			t.Errorf("timer lost after failed Stop(): %+v", got)
+		}
+	})
+
+	t.Run("too short is not logged", func(t *testing.T) {
+		s := testStore(t)
+		s.Start("PROJ-1", "", started)
+		wl := &fakeWorklogger{}
+		res, err := s.Stop(context.Background(), wl, "", started.Add(20*time.Second))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Logged != 0 || res.Worklog != nil || wl.key != "" {
+			t.Errorf("Stop() = %+v, posted to %q", res, wl.key)
+		}
+		if got, _ := s.Load(); got != nil {
+			t.Errorf("timer still running: %+v", got)
+		}
+	})
+
+	t.Run("not running", func(t *testing.T) {
+		s := testStore(t)
+		if _, err := s.Stop(context.Background(), &fakeWorklogger{}, "", started); !errors.Is(err, ErrNotRunning) {
+			t.Errorf("Stop() error = %v, want ErrNotRunning", err)
+		}
+	})
+}
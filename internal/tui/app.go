@@ -2,7 +2,9 @@ package tui
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -13,6 +15,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"jet/internal/jira"
+	"jet/internal/timer"
 )
 
 type viewID int
@@ -26,6 +29,9 @@ const (
 	viewWorkflowEditor
 	viewStandup
 	viewPRs
+	viewKanban
+	viewTree
+	viewBulk
 )
 
 // App is the top-level Bubble Tea model.
@@ -37,7 +43,9 @@ type App struct {
 	activeView viewID
 	viewStack  []viewID
 
-	dashboard  DashboardModel
+	dashboard  DashboardModel // the active tab
+	tabs       []DashboardModel
+	activeTab  int
 	detail     DetailModel
 	form       FormModel
 	transition TransitionModel
@@ -45,40 +53,66 @@ type App struct {
 	workflowEditor WorkflowEditorModel
 	standup        StandupModel
 	prs            PRsModel
+	kanban         KanbanModel
+	tree           TreeModel
+	bulk           BulkModel
 
 	taskManager  *TaskManager
+	fetches      *fetchTracker
 	notification string
 
+	timers *timer.Store // nil disables the work timer
+	timer  *timer.Timer // the running timer, nil when none
+
 	err    error
 	errMsg string
 }
 
-// NewApp creates a new App model.
-func NewApp(client *jira.Client, initialJQL string, tm *TaskManager) App {
+// NewApp creates a new App model with one dashboard tab per entry of tabs
+// (at least the default dashboard). Its background loads are cancelled when
+// ctx is.
+func NewApp(ctx context.Context, client *jira.Client, tabs []Tab, tm *TaskManager) App {
+	fetches := newFetchTracker(ctx)
+	if len(tabs) == 0 {
+		tabs = []Tab{{}}
+	}
+	dashboards := newDashboardTabs(tabs, fetches)
+	dashboards[0].requested = true
 	return App{
 		client:      client,
 		activeView:  viewDashboard,
-		dashboard:   NewDashboardModel(initialJQL),
+		dashboard:   dashboards[0],
+		tabs:        dashboards,
 		taskManager: tm,
+		fetches:     fetches,
 	}
 }
 
-// Run launches the Bubble Tea program.
-func Run(client *jira.Client, initialJQL string) error {
+// Run launches the Bubble Tea program. The first of tabs is the default
+// dashboard; the number keys switch between them. Cancelling ctx stops the
+// program and any requests in flight. timers, when non-nil, backs the work
+// timer shown in the status bar.
+func Run(ctx context.Context, client *jira.Client, tabs []Tab, timers *timer.Store) error {
 	tm := NewTaskManager()
-	app := NewApp(client, initialJQL, tm)
-	p := tea.NewProgram(app, tea.WithAltScreen())
+	app := NewApp(ctx, client, tabs, tm)
+	app.timers = timers
+	defer app.fetches.cancelAll()
+	p := tea.NewProgram(app, tea.WithAltScreen(), tea.WithContext(ctx))
 	tm.SetProgram(p)
 	_, err := p.Run()
 	return err
 }
 
 func (a App) Init() tea.Cmd {
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		a.dashboard.Init(),
-		fetchIssues(a.client, a.dashboard.jql, 50),
+		a.dashboard.reload(a.client),
 		migrateWorkflowsCmd,
-	)
+	}
+	if a.timers != nil {
+		cmds = append(cmds, loadTimer(a.timers), timerTick())
+	}
+	return tea.Batch(cmds...)
 }
 
 func migrateWorkflowsCmd() tea.Msg {
@@ -98,7 +132,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.height = msg.Height
 		// Reserve 2 lines for status/help bar
 		contentHeight := a.height - 2
-		a.dashboard = a.dashboard.SetSize(a.width, contentHeight)
+		a.dashboard = a.dashboard.SetSize(a.width, a.dashboardHeight())
 		// Only resize views that are currently active (avoids nil pointer on uninitialized models)
 		switch a.activeView {
 		case viewDetail:
@@ -115,6 +149,12 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			a.standup = a.standup.SetSize(a.width, contentHeight)
 		case viewPRs:
 			a.prs = a.prs.SetSize(a.width, contentHeight)
+		case viewKanban:
+			a.kanban = a.kanban.SetSize(a.width, contentHeight)
+		case viewTree:
+			a.tree = a.tree.SetSize(a.width, contentHeight)
+		case viewBulk:
+			a.bulk = a.bulk.SetSize(a.width, contentHeight)
 		}
 		return a, nil
 
@@ -130,7 +170,19 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case errMsg:
 		a.err = msg.err
 		a.errMsg = msg.err.Error()
-		cmds = append(cmds, clearErrAfter(NotifyMedium))
+		if a.activeView == viewKanban {
+			a.kanban = a.kanban.Failed()
+		}
+		if a.activeView == viewTree {
+			a.tree = a.tree.Failed()
+		}
+		// Leave field-level messages from Jira up long enough to read.
+		var apiErr *jira.APIError
+		if errors.As(msg.err, &apiErr) && len(apiErr.Details()) > 0 {
+			cmds = append(cmds, clearErrAfter(NotifyXLong))
+		} else {
+			cmds = append(cmds, clearErrAfter(NotifyMedium))
+		}
 		return a, tea.Batch(cmds...)
 
 	case clearErrMsg:
@@ -144,7 +196,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.activeView = viewDetail
 		a.detail = NewDetailModel()
 		a.detail = a.detail.SetSize(a.width, a.height-2)
-		return a, fetchIssue(a.client, msg.key)
+		return a, fetchIssue(a.fetches.start(fetchDetail), a.client, msg.key)
 
 	case navigateToFormMsg:
 		a.viewStack = append(a.viewStack, a.activeView)
@@ -156,11 +208,19 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case navigateToTransitionMsg:
 		a.viewStack = append(a.viewStack, a.activeView)
 		a.activeView = viewTransition
+		if msg.issues != nil {
+			a.transition = NewBulkTransitionModel(msg.issues)
+			a.transition = a.transition.SetSize(a.width, a.height-2)
+			return a, tea.Batch(a.transition.Init(), fetchBulkTransitions(a.fetches.start(fetchBulk), a.client, msg.issues))
+		}
 		a.transition = NewTransitionModel(msg.key)
 		a.transition = a.transition.SetSize(a.width, a.height-2)
 		return a, tea.Batch(a.transition.Init(), fetchTransitions(a.client, msg.key))
 
 	case goBackMsg:
+		if slot, ok := slotFor(a.activeView); ok {
+			a.fetches.cancel(slot)
+		}
 		if len(a.viewStack) > 0 {
 			a.activeView = a.viewStack[len(a.viewStack)-1]
 			a.viewStack = a.viewStack[:len(a.viewStack)-1]
@@ -168,25 +228,29 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, nil
 
 	case refreshDashboardMsg:
-		if a.dashboard.viewingProjectEpics != "" {
-			return a, fetchProjectEpics(a.client, a.dashboard.viewingProjectEpics)
-		}
-		if a.dashboard.viewingEpic != "" {
-			return a, fetchEpicChildren(a.client, a.dashboard.viewingEpic)
-		}
-		return a, fetchIssues(a.client, a.dashboard.jql, 50)
+		return a, a.dashboard.reload(a.client)
 
-	// API result messages that may need routing
+	case switchTabMsg:
+		return a.switchTab(msg.tab)
+
+	// API result messages that may need routing; dashboard loads go to the
+	// tab that started them, active or not.
 	case epicChildrenLoadedMsg:
-		a.dashboard = a.dashboard.SetEpicChildren(msg.issues, msg.epicKey)
+		if d, ok := a.dashboardTab(msg.tab); ok {
+			a.setDashboardTab(msg.tab, d.SetEpicChildren(msg.issues, msg.epicKey))
+		}
 		return a, nil
 
 	case projectEpicsLoadedMsg:
-		a.dashboard = a.dashboard.SetProjectEpics(msg.issues, msg.projectKey, msg.total)
+		if d, ok := a.dashboardTab(msg.tab); ok {
+			a.setDashboardTab(msg.tab, d.SetProjectEpics(msg.issues, msg.projectKey, msg.total))
+		}
 		return a, nil
 
 	case issuesLoadedMsg:
-		a.dashboard = a.dashboard.SetIssues(msg.issues, msg.total)
+		if d, ok := a.dashboardTab(msg.tab); ok {
+			a.setDashboardTab(msg.tab, d.SetIssues(msg.issues, msg.total))
+		}
 		return a, nil
 
 	case issueLoadedMsg:
@@ -197,6 +261,29 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.transition = a.transition.SetTransitions(msg.transitions, msg.issueKey)
 		return a, nil
 
+	case bulkTransitionsLoadedMsg:
+		a.transition = a.transition.SetBulkTransitions(msg)
+		return a, nil
+
+	case bulkStartMsg:
+		// The status picker a bulk transition came from makes way for it.
+		if a.activeView == viewTransition && len(a.viewStack) > 0 {
+			a.activeView = a.viewStack[len(a.viewStack)-1]
+			a.viewStack = a.viewStack[:len(a.viewStack)-1]
+		}
+		a.viewStack = append(a.viewStack, a.activeView)
+		a.activeView = viewBulk
+		a.bulk = NewBulkModel(msg.change, msg.issues)
+		a.bulk.fetches = a.fetches
+		a.bulk = a.bulk.SetSize(a.width, a.height-2)
+		return a, nil
+
+	case bulkDoneMsg:
+		// Keep the failures marked so they can be retried.
+		a.bulk, _ = a.bulk.Update(msg, a.client)
+		a.dashboard = a.dashboard.SetMarked(a.bulk.Failed())
+		return a, a.refreshDashboard()
+
 	case issueCreatedMsg:
 		a.errMsg = ""
 		a.err = nil
@@ -220,7 +307,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case commentAddedMsg:
 		// Refresh the detail view
 		if a.detail.issue != nil {
-			return a, fetchIssue(a.client, a.detail.issue.Key)
+			return a, fetchIssue(a.fetches.start(fetchDetail), a.client, a.detail.issue.Key)
 		}
 		return a, nil
 
@@ -345,6 +432,29 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.notification = ""
 		return a, nil
 
+	// Work timer messages
+	case toggleTimerMsg:
+		if a.timers == nil {
+			return a, nil
+		}
+		ctx := context.Background()
+		if a.fetches != nil {
+			ctx = a.fetches.base
+		}
+		return a, toggleTimer(ctx, a.timers, a.client, msg.issueKey)
+
+	case timerToggledMsg:
+		a.timer = msg.started
+		a.notification = timerNotification(msg)
+		return a, clearNotificationAfter(NotifyMedium)
+
+	case timerLoadedMsg:
+		a.timer = msg.timer
+		return a, nil
+
+	case timerTickMsg:
+		return a, tea.Batch(loadTimer(a.timers), timerTick())
+
 	case navigateToTaskViewerMsg:
 		a.viewStack = append(a.viewStack, a.activeView)
 		a.activeView = viewTaskViewer
@@ -369,13 +479,38 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		a.activeView = viewPRs
 		a.prs = NewPRsModel(msg.scope)
+		a.prs.fetches = a.fetches
 		a.prs = a.prs.SetSize(a.width, a.height-2)
-		return a, tea.Batch(a.prs.Init(), fetchPRs(msg.scope))
+		return a, tea.Batch(a.prs.Init(), fetchPRs(a.fetches.start(fetchPRList), msg.scope))
 
 	case prsLoadedMsg:
 		a.prs = a.prs.SetData(msg.prs, msg.warnings)
 		return a, nil
 
+	case navigateToKanbanMsg:
+		a.viewStack = append(a.viewStack, a.activeView)
+		a.activeView = viewKanban
+		a.kanban = NewKanbanModel(msg.jql)
+		a.kanban.fetches = a.fetches
+		a.kanban = a.kanban.SetSize(a.width, a.height-2)
+		return a, tea.Batch(a.kanban.Init(), fetchKanbanJQL(a.fetches.start(fetchKanban), a.client, msg.jql))
+
+	case kanbanLoadedMsg:
+		a.kanban = a.kanban.SetData(msg)
+		return a, nil
+
+	case navigateToTreeMsg:
+		a.viewStack = append(a.viewStack, a.activeView)
+		a.activeView = viewTree
+		a.tree = NewTreeModel(msg.key)
+		a.tree.fetches = a.fetches
+		a.tree = a.tree.SetSize(a.width, a.height-2)
+		return a, tea.Batch(a.tree.Init(), fetchHierarchy(a.fetches.start(fetchTree), a.client, msg.key))
+
+	case treeLoadedMsg:
+		a.tree = a.tree.SetData(msg)
+		return a, nil
+
 	case standupSummaryMsg:
 		a.standup = a.standup.SetSummary(msg.summary, msg.err)
 		return a, nil
@@ -438,6 +573,15 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case viewPRs:
 		a.prs, cmd = a.prs.Update(msg, nil)
 		cmds = append(cmds, cmd)
+	case viewKanban:
+		a.kanban, cmd = a.kanban.Update(msg, a.client)
+		cmds = append(cmds, cmd)
+	case viewTree:
+		a.tree, cmd = a.tree.Update(msg, a.client)
+		cmds = append(cmds, cmd)
+	case viewBulk:
+		a.bulk, cmd = a.bulk.Update(msg, a.client)
+		cmds = append(cmds, cmd)
 	}
 
 	return a, tea.Batch(cmds...)
@@ -448,6 +592,9 @@ func (a App) View() string {
 	switch a.activeView {
 	case viewDashboard:
 		content = a.dashboard.View()
+		if bar := a.tabBar(); bar != "" {
+			content = lipgloss.JoinVertical(lipgloss.Left, bar, content)
+		}
 	case viewDetail:
 		content = a.detail.View()
 	case viewForm:
@@ -460,6 +607,10 @@ func (a App) View() string {
 		content = a.standup.View()
 	case viewPRs:
 		content = a.prs.View()
+	case viewKanban:
+		content = a.kanban.View()
+	case viewTree:
+		content = a.tree.View()
 	case viewTransition:
 		// Render transition overlay on top of the previous view
 		var bg string
@@ -477,6 +628,8 @@ func (a App) View() string {
 		}
 		_ = bg
 		content = a.transition.View()
+	case viewBulk:
+		content = a.bulk.View()
 	}
 
 	// Status bar at the bottom
@@ -570,13 +723,7 @@ func composeResumeFollowup(workflowContent, instruction string) string {
 }
 
 func (a App) refreshDashboard() tea.Cmd {
-	if a.dashboard.viewingProjectEpics != "" {
-		return fetchProjectEpics(a.client, a.dashboard.viewingProjectEpics)
-	}
-	if a.dashboard.viewingEpic != "" {
-		return fetchEpicChildren(a.client, a.dashboard.viewingEpic)
-	}
-	return fetchIssues(a.client, a.dashboard.jql, 50)
+	return a.dashboard.reload(a.client)
 }
 
 func (a App) helpBar() string {
@@ -585,6 +732,7 @@ func (a App) helpBar() string {
 		prefix = lipgloss.NewStyle().Foreground(colorYellow).Bold(true).
 			Render(fmt.Sprintf(" [%d task(s) running] ", count))
 	}
+	prefix = timerBadge(a.timer, time.Now()) + prefix
 
 	var bar string
 	switch a.activeView {
@@ -595,14 +743,29 @@ func (a App) helpBar() string {
 		if a.dashboard.picker.InPromptPhase() {
 			return prefix + helpBarStyle.Render(" enter:new line  ctrl+s:submit  esc:cancel")
 		}
+		if a.dashboard.jqlPrompt.Active() {
+			return prefix + helpBarStyle.Render(" tab:complete  ↑/↓:choose  enter:validate & run  esc:cancel")
+		}
+		if a.dashboard.promptMode == promptLabels {
+			return prefix + helpBarStyle.Render(" label/+label:add  -label:remove  enter:apply  esc:cancel")
+		}
 		if a.dashboard.promptMode != promptNone {
 			return prefix + helpBarStyle.Render(" enter:confirm  esc:cancel")
 		}
-		base := " enter:view  o:open  x:epic  E:epics  S:standup  P:prs  C:claude  T:tasks  W:workflow  c:create  e:edit  t:transition  s:start  d:done  g:grab  r:refresh  q:quit"
+		if n := len(a.dashboard.selection()); n > 0 || a.dashboard.visual {
+			if a.dashboard.visual {
+				return prefix + helpBarStyle.Render(" j/k:extend range  v/space:mark range  t:transition  d:done  g:grab  +:labels  A:assign  esc:clear")
+			}
+			return prefix + helpBarStyle.Render(fmt.Sprintf(" %d selected  space:mark  v:range  t:transition  d:done  g:grab  +:labels  A:assign  esc:clear marks", n))
+		}
+		base := " enter:view  o:open  x:epic  E:epics  S:standup  P:prs  B:board  J:jql  C:claude  T:tasks  W:workflow  c:create  e:edit  t:transition  s:start  d:done  g:grab  +:labels  A:assign  space/v:mark  L:timer  r:refresh  q:quit"
 		if a.dashboard.viewingProjectEpics != "" {
 			base = " enter:view  m:my tickets  a:show/hide closed  x:epic  o:open  e:edit  t:transition  r:refresh  q:quit"
 		} else if a.dashboard.viewingEpic != "" {
-			base = " enter:view  m:my tickets  a:show/hide closed  C:claude  T:tasks  o:open  x:epic  e:edit  t:transition  s:start  d:done  g:grab  r:refresh  q:quit"
+			base = " enter:view  m:my tickets  a:show/hide closed  B:board  C:claude  T:tasks  o:open  x:epic  e:edit  t:transition  s:start  d:done  g:grab  L:timer  r:refresh  q:quit"
+		}
+		if len(a.tabs) > 1 {
+			base = fmt.Sprintf(" 1-%d:tabs", len(a.tabs)) + base
 		}
 		bar = helpBarStyle.Render(base)
 	case viewDetail:
@@ -611,7 +774,7 @@ func (a App) helpBar() string {
 		} else if a.detail.picker.InPromptPhase() {
 			bar = helpBarStyle.Render(" enter:new line  ctrl+s:submit  esc:cancel")
 		} else {
-			bar = helpBarStyle.Render(" j/k:scroll  e:edit  t:transition  c:comment  C:claude  g:grab  u:back  q:quit")
+			bar = helpBarStyle.Render(" j/k:scroll  tab:details/history  T:tree  e:edit  t:transition  c:comment  C:claude  g:grab  u:back  q:quit")
 		}
 	case viewForm:
 		if a.form.activePane == formPaneChat {
@@ -635,6 +798,23 @@ func (a App) helpBar() string {
 		bar = helpBarStyle.Render(" j/k:navigate  enter:view issue  s:summarize  r:refresh  u:back")
 	case viewPRs:
 		bar = helpBarStyle.Render(" j/k:navigate  enter/o:open in browser  tab:mine/team  r:refresh  u:back")
+	case viewKanban:
+		if a.kanban.prompting {
+			bar = helpBarStyle.Render(" enter:load board  esc:cancel")
+		} else {
+			bar = helpBarStyle.Render(" h/l:column  j/k:card  </>:move card  g:group  w:lanes  b:board  enter:view  r:refresh  u:back")
+		}
+	case viewTree:
+		bar = helpBarStyle.Render(" j/k:navigate  h/l:collapse/expand  space:toggle  +/-:all  enter:view  r:refresh  u:back")
+	case viewBulk:
+		switch a.bulk.phase {
+		case bulkConfirm:
+			bar = helpBarStyle.Render(" y/enter:apply  j/k:scroll  n/esc:cancel")
+		case bulkRunning:
+			bar = helpBarStyle.Render(" j/k:scroll  esc:stop")
+		default:
+			bar = helpBarStyle.Render(" j/k:scroll  enter/esc:close")
+		}
 	case viewTaskViewer:
 		if a.taskViewer.picker.InWorkflowPhase() {
 			return prefix + helpBarStyle.Render(" j/k:navigate  enter:select  esc:cancel")
@@ -0,0 +1,324 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"jet/internal/bulk"
+	"jet/internal/jira"
+)
+
+// bulkStartMsg asks the App to confirm a change to several issues and run
+// it.
+type bulkStartMsg struct {
+	change bulk.Change
+	issues []jira.Issue
+}
+
+// bulkProgressMsg reports an issue a running bulk change is done with.
+type bulkProgressMsg struct {
+	result bulk.Result
+}
+
+// bulkDoneMsg ends a bulk change.
+type bulkDoneMsg struct {
+	report *bulk.Report
+}
+
+// bulkTransitionsLoadedMsg carries the statuses the marked issues can move
+// to, and how many of them offer each.
+type bulkTransitionsLoadedMsg struct {
+	transitions []jira.Transition
+	offered     []int
+}
+
+// bulkAssignCmd resolves who ("me", "none", a name or email) and asks to
+// assign issues to them.
+func bulkAssignCmd(ctx context.Context, client *jira.Client, who string, issues []jira.Issue) tea.Cmd {
+	return func() tea.Msg {
+		if strings.EqualFold(who, "none") {
+			return bulkStartMsg{change: bulk.Assign(nil, ""), issues: issues}
+		}
+		user, err := client.ResolveUserContext(ctx, who)
+		if err != nil {
+			return errMsg{err: err}
+		}
+		name := user.DisplayName
+		if name == "" {
+			name = user.Name
+		}
+		return bulkStartMsg{change: bulk.Assign(user.Ref(), name), issues: issues}
+	}
+}
+
+// fetchBulkTransitions collects the statuses issues can move to, a few
+// issues at a time, in the order they are first offered.
+func fetchBulkTransitions(ctx context.Context, client *jira.Client, issues []jira.Issue) tea.Cmd {
+	return func() tea.Msg {
+		results := make([][]jira.Transition, len(issues))
+		errs := make([]error, len(issues))
+		sem := make(chan struct{}, bulk.DefaultConcurrency)
+		var wg sync.WaitGroup
+		for i, issue := range issues {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				results[i], errs[i] = client.GetTransitionsContext(ctx, issue.Key)
+			}()
+		}
+		wg.Wait()
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		var msg bulkTransitionsLoadedMsg
+		index := map[string]int{}
+		for i, transitions := range results {
+			if errs[i] != nil {
+				return errMsg{err: fmt.Errorf("failed to get transitions of %s: %w", issues[i].Key, errs[i])}
+			}
+			seen := map[string]bool{}
+			for _, t := range transitions {
+				status := strings.ToLower(t.To.Name)
+				if seen[status] {
+					continue
+				}
+				seen[status] = true
+				j, ok := index[status]
+				if !ok {
+					j = len(msg.transitions)
+					index[status] = j
+					t.Name = t.To.Name // names differ between workflows; the status does not
+					msg.transitions = append(msg.transitions, t)
+					msg.offered = append(msg.offered, 0)
+				}
+				msg.offered[j]++
+			}
+		}
+		return msg
+	}
+}
+
+// runBulkChange starts change on the plan in the background and returns
+// its first update; waitForBulk returns the ones after that.
+func runBulkChange(ctx context.Context, client *jira.Client, change bulk.Change, plan []bulk.Item, updates chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		go func() {
+			report := bulk.Run(ctx, client, change, plan, bulk.Options{
+				Progress: func(r bulk.Result) { updates <- bulkProgressMsg{result: r} },
+			})
+			updates <- bulkDoneMsg{report: report}
+		}()
+		return <-updates
+	}
+}
+
+func waitForBulk(updates chan tea.Msg) tea.Cmd {
+	return func() tea.Msg { return <-updates }
+}
+
+// parseLabelEdits splits "a +b -c" into labels to add (a, b) and remove
+// (c). Labels may also be separated by commas.
+func parseLabelEdits(s string) (add, remove []string) {
+	for _, f := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+		switch {
+		case strings.HasPrefix(f, "-") && len(f) > 1:
+			remove = append(remove, f[1:])
+		case strings.HasPrefix(f, "+") && len(f) > 1:
+			add = append(add, f[1:])
+		case f != "+" && f != "-":
+			add = append(add, f)
+		}
+	}
+	return add, remove
+}
+
+type bulkPhase int
+
+const (
+	bulkConfirm bulkPhase = iota
+	bulkRunning
+	bulkFinished
+)
+
+// BulkModel asks before changing several issues, then shows the change's
+// progress and what failed.
+type BulkModel struct {
+	change bulk.Change
+	plan   []bulk.Item
+	phase  bulkPhase
+
+	updates   chan tea.Msg
+	finished  int           // issues done with so far
+	failures  []bulk.Result // in the order they failed
+	report    *bulk.Report
+	cancelled bool
+
+	scroll  int
+	spinner spinner.Model
+
+	width, height int
+	fetches       *fetchTracker // shared with App; the run uses fetchBulk
+}
+
+// NewBulkModel plans change for issues and waits for confirmation.
+func NewBulkModel(change bulk.Change, issues []jira.Issue) BulkModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = lipgloss.NewStyle().Foreground(colorCyan)
+	return BulkModel{change: change, plan: bulk.NewPlan(issues, change), spinner: s}
+}
+
+func (m BulkModel) SetSize(width, height int) BulkModel {
+	m.width = width
+	m.height = height
+	return m
+}
+
+// Failed returns the keys of the issues the change failed on.
+func (m BulkModel) Failed() []string {
+	var keys []string
+	for _, r := range m.failures {
+		keys = append(keys, r.Key)
+	}
+	return keys
+}
+
+func (m BulkModel) Update(msg tea.Msg, client *jira.Client) (BulkModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, bulkKeys.Up):
+			m.scroll = max(0, m.scroll-1)
+		case key.Matches(msg, bulkKeys.Down):
+			m.scroll++
+		case m.phase == bulkConfirm && key.Matches(msg, bulkKeys.Confirm):
+			if bulk.Pending(m.plan) == 0 {
+				return m, func() tea.Msg { return goBackMsg{} }
+			}
+			m.phase = bulkRunning
+			m.scroll = 0
+			m.updates = make(chan tea.Msg, len(m.plan)+1)
+			ctx := m.fetches.start(fetchBulk)
+			return m, tea.Batch(m.spinner.Tick, runBulkChange(ctx, client, m.change, m.plan, m.updates))
+		case m.phase == bulkRunning && key.Matches(msg, bulkKeys.Cancel):
+			// Issues not started yet fail as cancelled.
+			m.cancelled = true
+			m.fetches.cancel(fetchBulk)
+		case m.phase != bulkRunning && (key.Matches(msg, bulkKeys.Cancel) || key.Matches(msg, bulkKeys.Confirm)):
+			return m, func() tea.Msg { return goBackMsg{} }
+		}
+		return m, nil
+
+	case bulkProgressMsg:
+		m.finished++
+		if msg.result.Outcome == bulk.Failed {
+			m.failures = append(m.failures, msg.result)
+		}
+		return m, waitForBulk(m.updates)
+
+	case bulkDoneMsg:
+		m.phase = bulkFinished
+		m.report = msg.report
+		m.scroll = 0
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.phase == bulkRunning {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+func (m BulkModel) View() string {
+	width := min(90, m.width-4)
+	inner := max(20, width-6) // border and padding
+
+	var head, body []string
+	switch m.phase {
+	case bulkConfirm:
+		pending := bulk.Pending(m.plan)
+		head = append(head, titleStyle.Render(truncateRunes(fmt.Sprintf("%s: %d issues", capitalize(m.change.String()), len(m.plan)), inner)))
+		info := fmt.Sprintf("%d to change", pending)
+		if skipped := len(m.plan) - pending; skipped > 0 {
+			info += fmt.Sprintf(", %d already done and skipped", skipped)
+		}
+		head = append(head, subtitleStyle.Render(info), "")
+		keyWidth := 0
+		for _, item := range m.plan {
+			keyWidth = max(keyWidth, len([]rune(item.Issue.Key)))
+		}
+		room := max(0, inner-keyWidth-2)
+		for _, item := range m.plan {
+			what := lipgloss.NewStyle().Foreground(colorYellow).Render(truncateRunes(item.Change, room))
+			if item.Skip {
+				what = dimStyle.Render(truncateRunes("skip: "+item.Change, room))
+			}
+			body = append(body, lipgloss.NewStyle().Foreground(colorCyan).Render(padRunes(item.Issue.Key, keyWidth))+"  "+what)
+		}
+
+	case bulkRunning:
+		status := "Applying"
+		if m.cancelled {
+			status = "Cancelling"
+		}
+		head = append(head,
+			titleStyle.Render(truncateRunes(capitalize(m.change.String()), inner)),
+			m.spinner.View()+" "+status+" "+treeProgress(m.finished, max(1, len(m.plan))),
+			"")
+		body = m.failureLines(inner)
+
+	case bulkFinished:
+		r := m.report
+		summary := fmt.Sprintf("%s applied · %s skipped · %s failed",
+			successStyle.Render(fmt.Sprint(r.Applied)), dimStyle.Render(fmt.Sprint(r.Skipped)), errorStyle.Render(fmt.Sprint(r.Failed)))
+		head = append(head, titleStyle.Render(truncateRunes(capitalize(m.change.String()), inner)), summary)
+		if m.cancelled {
+			head = append(head, dimStyle.Render("Cancelled; issues not yet started were left alone."))
+		}
+		if r.Failed > 0 {
+			head = append(head, dimStyle.Render("Failed issues stay marked so you can retry."))
+		}
+		head = append(head, "")
+		body = m.failureLines(inner)
+	}
+
+	// Scroll the body within the room the overlay has.
+	avail := max(1, m.height-len(head)-6)
+	scroll := max(0, min(m.scroll, len(body)-avail))
+	body = body[scroll:min(len(body), scroll+avail)]
+
+	box := overlayStyle.Width(width).Render(strings.Join(append(head, body...), "\n"))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// failureLines lists the failures so far, each with its error.
+func (m BulkModel) failureLines(width int) []string {
+	var lines []string
+	for _, r := range m.failures {
+		lines = append(lines,
+			errorStyle.Render("✗ ")+lipgloss.NewStyle().Foreground(colorCyan).Render(r.Key)+" "+truncateRunes(r.Summary, max(0, width-len([]rune(r.Key))-3)),
+			"  "+dimStyle.Render(truncateRunes(r.Error, max(0, width-2))))
+	}
+	return lines
+}
+
+// capitalize upper-cases the first letter of s.
+func capitalize(s string) string {
+	for i, r := range s {
+		return string(unicode.ToUpper(r)) + s[i+len(string(r)):]
+	}
+	return s
+}
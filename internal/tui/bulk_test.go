@@ -0,0 +1,135 @@
+package tui
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"jet/internal/bulk"
+	"jet/internal/jira"
+)
+
+func TestParseLabelEdits(t *testing.T) {
+	add, remove := parseLabelEdits("backend +infra, -triage - + -old")
+	if !reflect.DeepEqual(add, []string{"backend", "infra"}) || !reflect.DeepEqual(remove, []string{"triage", "old"}) {
+		t.Errorf("add %q, remove %q", add, remove)
+	}
+}
+
+func dashboardPress(d DashboardModel, keys ...string) (DashboardModel, tea.Cmd) {
+	var cmd tea.Cmd
+	for _, k := range keys {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		switch k {
+		case "space":
+			msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		}
+		d, cmd = d.Update(msg, nil)
+	}
+	return d, cmd
+}
+
+func selectedKeys(d DashboardModel) string {
+	var keys []string
+	for _, issue := range d.selection() {
+		keys = append(keys, issue.Key)
+	}
+	return strings.Join(keys, " ")
+}
+
+func TestDashboardSelection(t *testing.T) {
+	var issues []jira.Issue
+	for i := 1; i <= 6; i++ {
+		issues = append(issues, jira.Issue{Key: fmt.Sprintf("P-%d", i), Fields: jira.Fields{Summary: "Summary"}})
+	}
+	d := NewDashboardModel("").SetSize(80, 40).SetIssues(issues, len(issues))
+
+	// Space marks and moves on; v marks the range up to the cursor.
+	d, _ = dashboardPress(d, "space", "v", "j", "j")
+	if got := selectedKeys(d); got != "P-1 P-2 P-3 P-4" {
+		t.Errorf("selection = %s", got)
+	}
+	if !strings.HasSuffix(d.list.Title, "· 4 selected") {
+		t.Errorf("title = %q", d.list.Title)
+	}
+	if v := d.View(); !strings.Contains(v, "> ○ P-4") || !strings.Contains(v, "  ● P-1") {
+		t.Errorf("view:\n%s", v)
+	}
+	d, _ = dashboardPress(d, "space", "j", "k", "k", "space")
+	if got := selectedKeys(d); got != "P-1 P-2 P-4" || d.visual {
+		t.Errorf("after unmarking P-3: %s, visual %v", got, d.visual)
+	}
+
+	d, cmd := dashboardPress(d, "t")
+	if msg, ok := cmd().(navigateToTransitionMsg); !ok || len(msg.issues) != 3 {
+		t.Errorf("t: %#v", cmd())
+	}
+	d, cmd = dashboardPress(d, "d")
+	if msg, ok := cmd().(bulkStartMsg); !ok || msg.change.String() != "close" || len(msg.issues) != 3 {
+		t.Errorf("d: %#v", cmd())
+	}
+
+	d, cmd = dashboardPress(d, "+", "api -Triage", "enter")
+	msg, ok := cmd().(bulkStartMsg)
+	if !ok || msg.change.String() != "add labels api and remove labels Triage" || len(msg.issues) != 3 {
+		t.Errorf("+: %#v", cmd())
+	}
+
+	d, _ = dashboardPress(d, "esc")
+	if selectedKeys(d) != "" || strings.Contains(d.list.Title, "selected") {
+		t.Errorf("esc left %q selected, title %q", selectedKeys(d), d.list.Title)
+	}
+
+	// Without marks, labels apply to the issue under the cursor.
+	d, cmd = dashboardPress(d, "+", "api", "enter")
+	if msg, ok := cmd().(bulkStartMsg); !ok || len(msg.issues) != 1 || msg.issues[0].Key != d.selectedIssue().Key {
+		t.Errorf("+ on one issue: %#v", cmd())
+	}
+
+	d = d.SetMarked([]string{"P-5"})
+	if selectedKeys(d) != "P-5" {
+		t.Errorf("SetMarked: %s", selectedKeys(d))
+	}
+}
+
+func TestBulkModel(t *testing.T) {
+	issues := []jira.Issue{
+		{Key: "P-1", Fields: jira.Fields{Summary: "One", Labels: []string{"api"}}},
+		{Key: "P-2", Fields: jira.Fields{Summary: "Two"}},
+		{Key: "P-3", Fields: jira.Fields{Summary: "Three"}},
+	}
+	m := NewBulkModel(bulk.AddLabels("api"), issues).SetSize(100, 30)
+	v := m.View()
+	if !strings.Contains(v, "Add labels api: 3 issues") || !strings.Contains(v, "2 to change, 1 already done") || !strings.Contains(v, "skip: already labelled") {
+		t.Errorf("confirm view:\n%s", v)
+	}
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")}, nil)
+	if m.phase != bulkRunning || cmd == nil {
+		t.Fatalf("y: phase %v", m.phase)
+	}
+	m, _ = m.Update(bulkProgressMsg{result: bulk.Result{Key: "P-2", Outcome: bulk.Applied}}, nil)
+	m, _ = m.Update(bulkProgressMsg{result: bulk.Result{Key: "P-3", Summary: "Three", Outcome: bulk.Failed, Error: "P-3 is locked"}}, nil)
+	if v := m.View(); !strings.Contains(v, "2/3") || !strings.Contains(v, "P-3 is locked") {
+		t.Errorf("running view:\n%s", v)
+	}
+
+	m, _ = m.Update(bulkDoneMsg{report: &bulk.Report{Applied: 1, Skipped: 1, Failed: 1}}, nil)
+	if v := m.View(); !strings.Contains(v, "1 applied · 1 skipped · 1 failed") || !strings.Contains(v, "✗ P-3 Three") {
+		t.Errorf("finished view:\n%s", v)
+	}
+	if !reflect.DeepEqual(m.Failed(), []string{"P-3"}) {
+		t.Errorf("failed = %v", m.Failed())
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter}, nil); cmd == nil {
+		t.Error("enter did not close the summary")
+	} else if _, ok := cmd().(goBackMsg); !ok {
+		t.Errorf("enter: %#v", cmd())
+	}
+}
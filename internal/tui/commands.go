@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -13,12 +14,16 @@ import (
 // Navigation messages
 type navigateToDetailMsg struct{ key string }
 type navigateToFormMsg struct{ issue *jira.Issue } // nil = create, non-nil = edit
-type navigateToTransitionMsg struct{ key string }
+type navigateToTransitionMsg struct {
+	key    string
+	issues []jira.Issue // several issues to transition together, instead of key
+}
 type goBackMsg struct{}
 type refreshDashboardMsg struct{}
 
 // API result messages
 type issuesLoadedMsg struct {
+	tab    int // the dashboard tab that asked for them
 	issues []jira.Issue
 	total  int
 }
@@ -59,6 +64,7 @@ type errMsg struct {
 }
 
 type epicChildrenLoadedMsg struct {
+	tab     int
 	issues  []jira.Issue
 	epicKey string
 }
@@ -152,6 +158,7 @@ type formClaudeFields struct {
 }
 
 type projectEpicsLoadedMsg struct {
+	tab        int
 	issues     []jira.Issue
 	projectKey string
 	total      int
@@ -164,21 +171,29 @@ type standupDataLoadedMsg struct {
 
 type clearErrMsg struct{}
 
-// fetchIssues searches for issues matching the given JQL.
-func fetchIssues(client *jira.Client, jql string, max int) tea.Cmd {
+// fetchIssues searches for issues matching the given JQL for dashboard tab
+// tab. Nothing is delivered once ctx has been cancelled.
+func fetchIssues(ctx context.Context, client *jira.Client, tab int, jql string, max int) tea.Cmd {
 	return func() tea.Msg {
-		resp, err := client.SearchIssues(jql, max)
+		resp, err := client.SearchIssuesContext(ctx, jql, max)
+		if ctx.Err() != nil {
+			return nil
+		}
 		if err != nil {
 			return errMsg{err: err}
 		}
-		return issuesLoadedMsg{issues: resp.Issues, total: resp.Total}
+		return issuesLoadedMsg{tab: tab, issues: resp.Issues, total: resp.Total}
 	}
 }
 
-// fetchIssue gets a single issue by key.
-func fetchIssue(client *jira.Client, key string) tea.Cmd {
+// fetchIssue gets a single issue by key. Nothing is delivered once ctx has
+// been cancelled.
+func fetchIssue(ctx context.Context, client *jira.Client, key string) tea.Cmd {
 	return func() tea.Msg {
-		issue, err := client.GetIssue(key)
+		issue, err := client.GetIssueContext(ctx, key)
+		if ctx.Err() != nil {
+			return nil
+		}
 		if err != nil {
 			return errMsg{err: err}
 		}
@@ -297,25 +312,31 @@ func quickTransition(client *jira.Client, key string, keywords []string) tea.Cmd
 }
 
 // fetchEpicChildren gets all child issues of an epic.
-func fetchEpicChildren(client *jira.Client, epicKey string) tea.Cmd {
+func fetchEpicChildren(ctx context.Context, client *jira.Client, tab int, epicKey string) tea.Cmd {
 	return func() tea.Msg {
-		issues, err := client.GetEpicChildren(epicKey)
+		issues, err := client.GetEpicChildrenContext(ctx, epicKey)
+		if ctx.Err() != nil {
+			return nil
+		}
 		if err != nil {
 			return errMsg{err: err}
 		}
-		return epicChildrenLoadedMsg{issues: issues, epicKey: epicKey}
+		return epicChildrenLoadedMsg{tab: tab, issues: issues, epicKey: epicKey}
 	}
 }
 
 // fetchProjectEpics searches for epics in a project.
-func fetchProjectEpics(client *jira.Client, projectKey string) tea.Cmd {
+func fetchProjectEpics(ctx context.Context, client *jira.Client, tab int, projectKey string) tea.Cmd {
 	return func() tea.Msg {
 		jql := fmt.Sprintf("project = \"%s\" AND issuetype = Epic ORDER BY updated DESC", projectKey)
-		resp, err := client.SearchIssues(jql, 50)
+		resp, err := client.SearchIssuesContext(ctx, jql, 50)
+		if ctx.Err() != nil {
+			return nil
+		}
 		if err != nil {
 			return errMsg{err: err}
 		}
-		return projectEpicsLoadedMsg{issues: resp.Issues, projectKey: projectKey, total: resp.Total}
+		return projectEpicsLoadedMsg{tab: tab, issues: resp.Issues, projectKey: projectKey, total: resp.Total}
 	}
 }
 
@@ -346,7 +367,8 @@ func fetchStandupData(client *jira.Client, days int) tea.Cmd {
 }
 
 // fetchPRs aggregates PRs across Gerrit and GitHub for the given scope.
-func fetchPRs(scope string) tea.Cmd {
+// Nothing is delivered once ctx has been cancelled.
+func fetchPRs(ctx context.Context, scope string) tea.Cmd {
 	return func() tea.Msg {
 		cfg, err := prs.LoadConfig()
 		if err != nil {
@@ -355,9 +377,12 @@ func fetchPRs(scope string) tea.Cmd {
 		var list []prs.PR
 		var errs []error
 		if scope == "team" {
-			list, errs = prs.Team(cfg, prs.Options{Source: "all", Limit: 25})
+			list, errs = prs.TeamContext(ctx, cfg, prs.Options{Source: "all", Limit: 25})
 		} else {
-			list, errs = prs.Mine(cfg, prs.Options{Source: "all", Limit: 25})
+			list, errs = prs.MineContext(ctx, cfg, prs.Options{Source: "all", Limit: 25})
+		}
+		if ctx.Err() != nil {
+			return nil
 		}
 		warnings := make([]string, 0, len(errs))
 		for _, e := range errs {
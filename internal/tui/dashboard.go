@@ -11,6 +11,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"jet/internal/bulk"
 	"jet/internal/jira"
 )
 
@@ -23,8 +24,13 @@ func (i issueItem) FilterValue() string {
 	return i.issue.Key + " " + i.issue.Fields.Summary
 }
 
-// issueDelegate renders each issue in the list.
-type issueDelegate struct{}
+// issueDelegate renders each issue in the list. While issues are marked, or
+// a range is being marked from anchor to the cursor, a column shows which.
+type issueDelegate struct {
+	marked map[string]bool
+	visual bool
+	anchor int
+}
 
 func (d issueDelegate) Height() int                             { return 2 }
 func (d issueDelegate) Spacing() int                            { return 1 }
@@ -38,6 +44,7 @@ func (d issueDelegate) Render(w io.Writer, m list.Model, index int, item list.It
 
 	issue := i.issue
 	isSelected := index == m.Index()
+	selecting := len(d.marked) > 0 || d.visual
 
 	// Line 1: Key + Summary
 	keyStyle := lipgloss.NewStyle().Foreground(colorCyan).Bold(true)
@@ -48,6 +55,9 @@ func (d issueDelegate) Render(w io.Writer, m list.Model, index int, item list.It
 	}
 
 	maxSummaryWidth := m.Width() - len(issue.Key) - 4
+	if selecting {
+		maxSummaryWidth -= 2
+	}
 	summary := issue.Fields.Summary
 	if len(summary) > maxSummaryWidth && maxSummaryWidth > 3 {
 		summary = summary[:maxSummaryWidth-3] + "..."
@@ -87,6 +97,17 @@ func (d issueDelegate) Render(w io.Writer, m list.Model, index int, item list.It
 	if isSelected {
 		cursor = "> "
 	}
+	if selecting {
+		mark := "  "
+		lo, hi := min(d.anchor, m.Index()), max(d.anchor, m.Index())
+		if d.marked[issue.Key] {
+			mark = lipgloss.NewStyle().Foreground(colorMagenta).Render("●") + " "
+		} else if d.visual && index >= lo && index <= hi {
+			mark = lipgloss.NewStyle().Foreground(colorMagenta).Render("○") + " "
+		}
+		cursor += mark
+		line2 = "  " + line2
+	}
 
 	fmt.Fprintf(w, "%s%s\n%s", cursor, line1, line2)
 }
@@ -99,6 +120,8 @@ const (
 	promptOpenTicket
 	promptEpic
 	promptEpics
+	promptLabels
+	promptAssign
 )
 
 // DashboardModel is the model for the dashboard view.
@@ -115,6 +138,9 @@ type DashboardModel struct {
 	prompt     textinput.Model
 	promptMode promptMode
 
+	// JQL editor with suggestions, replacing the tab's query.
+	jqlPrompt jqlPrompt
+
 	// Shared workflow + instruction picker for Claude task launches.
 	picker             ClaudePicker
 	pendingClaudeIssue *jira.Issue
@@ -128,12 +154,24 @@ type DashboardModel struct {
 	viewingProjectEpics string       // non-empty when viewing project epics
 	allProjectEpics     []jira.Issue // unfiltered project epics for toggle
 	projectEpicsShowAll bool         // when true, show closed epics
+
+	// Multi-selection for bulk actions: marked issue keys, and a range
+	// being marked from anchor to the cursor while visual is set.
+	marked map[string]bool
+	visual bool
+	anchor int
+
+	fetches *fetchTracker // shared with App; cancels superseded list loads
+
+	tab       int    // index among the App's dashboard tabs
+	name      string // tab name; "" for the default dashboard
+	requested bool   // a load has been started for this tab
 }
 
 // AnyPromptActive reports whether any input mode (single-line prompt or
 // Claude picker) is capturing input.
 func (d DashboardModel) AnyPromptActive() bool {
-	return d.promptMode != promptNone || d.picker.Active()
+	return d.promptMode != promptNone || d.picker.Active() || d.jqlPrompt.Active()
 }
 
 // NewDashboardModel creates a new dashboard model.
@@ -160,7 +198,9 @@ func NewDashboardModel(jql string) DashboardModel {
 		loading:    true,
 		spinner:    s,
 		prompt:     ti,
+		jqlPrompt:  newJQLPrompt(),
 		picker:     NewClaudePicker(),
+		marked:     map[string]bool{},
 	}
 }
 
@@ -206,9 +246,14 @@ func (d *DashboardModel) updateTitle() {
 			label = "all"
 		}
 		d.list.Title = fmt.Sprintf("Epic %s (%d %s tickets)", d.viewingEpic, d.total, label)
+	} else if d.name != "" {
+		d.list.Title = fmt.Sprintf("%s (%d tickets)", d.name, d.total)
 	} else {
 		d.list.Title = fmt.Sprintf("Jet Dashboard (%d tickets)", d.total)
 	}
+	if n := len(d.selection()); n > 0 {
+		d.list.Title += fmt.Sprintf(" · %d selected", n)
+	}
 }
 
 func (d DashboardModel) SetEpicChildren(issues []jira.Issue, epicKey string) DashboardModel {
@@ -285,6 +330,80 @@ func (d DashboardModel) selectedIssue() *jira.Issue {
 	return &i.issue
 }
 
+// selection returns the marked issues and those in the range being marked,
+// in list order.
+func (d DashboardModel) selection() []jira.Issue {
+	inRange := map[string]bool{}
+	if d.visual {
+		visible := d.list.VisibleItems()
+		lo, hi := min(d.anchor, d.list.Index()), max(d.anchor, d.list.Index())
+		for i := max(lo, 0); i <= hi && i < len(visible); i++ {
+			if it, ok := visible[i].(issueItem); ok {
+				inRange[it.issue.Key] = true
+			}
+		}
+	}
+	var issues []jira.Issue
+	for _, item := range d.list.Items() {
+		if it, ok := item.(issueItem); ok && (d.marked[it.issue.Key] || inRange[it.issue.Key]) {
+			issues = append(issues, it.issue)
+		}
+	}
+	return issues
+}
+
+// targets returns the issues a bulk action applies to: the selection, or
+// else the issue under the cursor.
+func (d DashboardModel) targets() []jira.Issue {
+	if sel := d.selection(); len(sel) > 0 {
+		return sel
+	}
+	if issue := d.selectedIssue(); issue != nil {
+		return []jira.Issue{*issue}
+	}
+	return nil
+}
+
+// SetMarked replaces the marks with keys, ending any range.
+func (d DashboardModel) SetMarked(keys []string) DashboardModel {
+	d.marked = map[string]bool{}
+	for _, k := range keys {
+		d.marked[k] = true
+	}
+	d.visual = false
+	d.syncSelection()
+	return d
+}
+
+// commitRange marks the issues in the range being marked and ends it.
+func (d *DashboardModel) commitRange() {
+	marked := map[string]bool{}
+	for _, issue := range d.selection() {
+		marked[issue.Key] = true
+	}
+	d.marked = marked
+	d.visual = false
+}
+
+// syncSelection shows the current marks in the list and its title.
+func (d *DashboardModel) syncSelection() {
+	d.list.SetDelegate(issueDelegate{marked: d.marked, visual: d.visual, anchor: d.anchor})
+	d.updateTitle()
+}
+
+// reload fetches what the dashboard is showing again: project epics, an
+// epic's children or its own JQL.
+func (d DashboardModel) reload(client *jira.Client) tea.Cmd {
+	ctx := d.fetches.start(dashboardSlot(d.tab))
+	if d.viewingProjectEpics != "" {
+		return fetchProjectEpics(ctx, client, d.tab, d.viewingProjectEpics)
+	}
+	if d.viewingEpic != "" {
+		return fetchEpicChildren(ctx, client, d.tab, d.viewingEpic)
+	}
+	return fetchIssues(ctx, client, d.tab, d.jql, 50)
+}
+
 func (d DashboardModel) Update(msg tea.Msg, client *jira.Client) (DashboardModel, tea.Cmd) {
 	var cmds []tea.Cmd
 
@@ -295,7 +414,34 @@ func (d DashboardModel) Update(msg tea.Msg, client *jira.Client) (DashboardModel
 		return d, cmd
 	}
 
+	// The JQL prompt's own results, and all keys while it is open.
+	switch msg.(type) {
+	case jqlDataLoadedMsg, jqlSuggestTickMsg, jqlSuggestionsMsg, jqlValidatedMsg:
+		var cmd tea.Cmd
+		d.jqlPrompt, cmd = d.jqlPrompt.Update(msg, client)
+		return d, cmd
+	case tea.KeyMsg:
+		if d.jqlPrompt.Active() {
+			var cmd tea.Cmd
+			d.jqlPrompt, cmd = d.jqlPrompt.Update(msg, client)
+			return d, cmd
+		}
+	}
+
 	switch msg := msg.(type) {
+	case jqlAcceptedMsg:
+		d = d.SetMarked(nil)
+		d.jql = msg.query
+		d.currentJQL = msg.query
+		d.viewingEpic = ""
+		d.allEpicItems = nil
+		d.epicShowAll = false
+		d.viewingProjectEpics = ""
+		d.allProjectEpics = nil
+		d.projectEpicsShowAll = false
+		d.loading = true
+		return d, tea.Batch(d.spinner.Tick, d.reload(client))
+
 	case claudePickerResultMsg:
 		issue := d.pendingClaudeIssue
 		d.pendingClaudeIssue = nil
@@ -330,14 +476,23 @@ func (d DashboardModel) Update(msg tea.Msg, client *jira.Client) (DashboardModel
 					return d, nil
 				}
 				switch mode {
+				case promptLabels:
+					add, remove := parseLabelEdits(rawValue)
+					if len(add)+len(remove) == 0 {
+						return d, nil
+					}
+					issues := d.targets()
+					return d, func() tea.Msg { return bulkStartMsg{change: bulk.EditLabels(add, remove), issues: issues} }
+				case promptAssign:
+					return d, bulkAssignCmd(d.fetches.start(fetchBulk), client, rawValue, d.targets())
 				case promptOpenTicket:
 					return d, func() tea.Msg { return navigateToDetailMsg{key: value} }
 				case promptEpic:
 					d.loading = true
-					return d, tea.Batch(d.spinner.Tick, fetchEpicChildren(client, value))
+					return d, tea.Batch(d.spinner.Tick, fetchEpicChildren(d.fetches.start(dashboardSlot(d.tab)), client, d.tab, value))
 				case promptEpics:
 					d.loading = true
-					return d, tea.Batch(d.spinner.Tick, fetchProjectEpics(client, value))
+					return d, tea.Batch(d.spinner.Tick, fetchProjectEpics(d.fetches.start(dashboardSlot(d.tab)), client, d.tab, value))
 				}
 				return d, nil
 			}
@@ -352,6 +507,50 @@ func (d DashboardModel) Update(msg tea.Msg, client *jira.Client) (DashboardModel
 		}
 
 		switch {
+		case key.Matches(msg, dashboardKeys.Mark):
+			if d.visual {
+				d.commitRange()
+			} else if issue := d.selectedIssue(); issue != nil {
+				if d.marked[issue.Key] {
+					delete(d.marked, issue.Key)
+				} else {
+					d.marked[issue.Key] = true
+				}
+				d.list.CursorDown()
+			}
+			d.syncSelection()
+			return d, nil
+
+		case key.Matches(msg, dashboardKeys.Visual):
+			if d.visual {
+				d.commitRange()
+			} else if d.selectedIssue() != nil {
+				d.visual = true
+				d.anchor = d.list.Index()
+			}
+			d.syncSelection()
+			return d, nil
+
+		case key.Matches(msg, dashboardKeys.Unmark) && (len(d.marked) > 0 || d.visual):
+			d = d.SetMarked(nil)
+			return d, nil
+
+		case key.Matches(msg, dashboardKeys.Label):
+			if len(d.targets()) > 0 {
+				d.promptMode = promptLabels
+				d.prompt.Placeholder = "Labels to add, -label to remove (e.g. backend -triage)"
+				d.prompt.SetValue("")
+				return d, d.prompt.Focus()
+			}
+
+		case key.Matches(msg, dashboardKeys.Assign):
+			if len(d.targets()) > 0 {
+				d.promptMode = promptAssign
+				d.prompt.Placeholder = "me, none, or a name or email"
+				d.prompt.SetValue("")
+				return d, d.prompt.Focus()
+			}
+
 		case key.Matches(msg, dashboardKeys.Enter):
 			if issue := d.selectedIssue(); issue != nil {
 				return d, func() tea.Msg { return navigateToDetailMsg{key: issue.Key} }
@@ -380,6 +579,7 @@ func (d DashboardModel) Update(msg tea.Msg, client *jira.Client) (DashboardModel
 
 		case key.Matches(msg, dashboardKeys.BackToMine):
 			if d.viewingEpic != "" || d.viewingProjectEpics != "" {
+				d = d.SetMarked(nil)
 				d.viewingEpic = ""
 				d.allEpicItems = nil
 				d.epicShowAll = false
@@ -388,7 +588,7 @@ func (d DashboardModel) Update(msg tea.Msg, client *jira.Client) (DashboardModel
 				d.projectEpicsShowAll = false
 				d.loading = true
 				d.currentJQL = d.jql
-				return d, tea.Batch(d.spinner.Tick, fetchIssues(client, d.jql, 50))
+				return d, tea.Batch(d.spinner.Tick, fetchIssues(d.fetches.start(dashboardSlot(d.tab)), client, d.tab, d.jql, 50))
 			}
 
 		case key.Matches(msg, dashboardKeys.ToggleAll):
@@ -434,6 +634,9 @@ func (d DashboardModel) Update(msg tea.Msg, client *jira.Client) (DashboardModel
 			}
 
 		case key.Matches(msg, dashboardKeys.Transition):
+			if sel := d.selection(); len(sel) > 0 {
+				return d, func() tea.Msg { return navigateToTransitionMsg{issues: sel} }
+			}
 			if issue := d.selectedIssue(); issue != nil {
 				return d, func() tea.Msg { return navigateToTransitionMsg{key: issue.Key} }
 			}
@@ -445,26 +648,44 @@ func (d DashboardModel) Update(msg tea.Msg, client *jira.Client) (DashboardModel
 			}
 
 		case key.Matches(msg, dashboardKeys.Close):
+			if sel := d.selection(); len(sel) > 0 {
+				return d, func() tea.Msg { return bulkStartMsg{change: bulk.Close(), issues: sel} }
+			}
 			if issue := d.selectedIssue(); issue != nil {
 				d.loading = true
-				return d, quickTransition(client, issue.Key, []string{"done", "closed", "resolved", "complete"})
+				return d, quickTransition(client, issue.Key, jira.CloseStatuses)
 			}
 
 		case key.Matches(msg, dashboardKeys.Grab):
+			if sel := d.selection(); len(sel) > 0 {
+				return d, bulkAssignCmd(d.fetches.start(fetchBulk), client, "me", sel)
+			}
 			if issue := d.selectedIssue(); issue != nil {
 				d.loading = true
 				return d, grabIssueCmd(client, issue.Key)
 			}
 
+		case key.Matches(msg, dashboardKeys.Timer):
+			if issue := d.selectedIssue(); issue != nil {
+				return d, func() tea.Msg { return toggleTimerMsg{issueKey: issue.Key} }
+			}
+
+		case key.Matches(msg, dashboardKeys.JQL):
+			d.jqlPrompt.fetches = d.fetches
+			var cmd tea.Cmd
+			d.jqlPrompt, cmd = d.jqlPrompt.Open(d.currentJQL, client)
+			return d, cmd
+
+		case key.Matches(msg, dashboardKeys.Board):
+			return d, func() tea.Msg { return navigateToKanbanMsg{jql: d.currentJQL} }
+
+		case key.Matches(msg, dashboardKeys.Tab):
+			n := int(msg.Runes[0] - '1')
+			return d, func() tea.Msg { return switchTabMsg{tab: n} }
+
 		case key.Matches(msg, dashboardKeys.Refresh):
 			d.loading = true
-			if d.viewingProjectEpics != "" {
-				return d, tea.Batch(d.spinner.Tick, fetchProjectEpics(client, d.viewingProjectEpics))
-			}
-			if d.viewingEpic != "" {
-				return d, tea.Batch(d.spinner.Tick, fetchEpicChildren(client, d.viewingEpic))
-			}
-			return d, tea.Batch(d.spinner.Tick, fetchIssues(client, d.jql, 50))
+			return d, tea.Batch(d.spinner.Tick, d.reload(client))
 		}
 
 	case spinner.TickMsg:
@@ -479,6 +700,9 @@ func (d DashboardModel) Update(msg tea.Msg, client *jira.Client) (DashboardModel
 	var cmd tea.Cmd
 	d.list, cmd = d.list.Update(msg)
 	cmds = append(cmds, cmd)
+	if d.visual {
+		d.updateTitle() // the range follows the cursor
+	}
 
 	return d, tea.Batch(cmds...)
 }
@@ -494,6 +718,13 @@ func (d DashboardModel) View() string {
 
 	view := d.list.View()
 
+	if d.jqlPrompt.Active() {
+		prompt := d.jqlPrompt.View(d.width)
+		l := d.list
+		l.SetHeight(max(3, l.Height()-strings.Count(prompt, "\n")-1))
+		return lipgloss.JoinVertical(lipgloss.Left, l.View(), prompt)
+	}
+
 	if d.picker.Active() {
 		view = lipgloss.JoinVertical(lipgloss.Left, view, d.picker.View())
 	} else if d.promptMode != promptNone {
@@ -505,6 +736,10 @@ func (d DashboardModel) View() string {
 			label = "Epic key: "
 		case promptEpics:
 			label = "Project key: "
+		case promptLabels:
+			label = fmt.Sprintf("Labels for %d: ", len(d.targets()))
+		case promptAssign:
+			label = fmt.Sprintf("Assign %d to: ", len(d.targets()))
 		}
 		promptLine := lipgloss.NewStyle().Foreground(colorCyan).Bold(true).Render(label) + d.prompt.View()
 		view = lipgloss.JoinVertical(lipgloss.Left, view, promptLine)
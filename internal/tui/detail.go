@@ -14,9 +14,18 @@ import (
 	"jet/internal/jira"
 )
 
+// detailTab is a page of the detail view.
+type detailTab int
+
+const (
+	detailTabInfo detailTab = iota
+	detailTabHistory
+)
+
 type DetailModel struct {
 	viewport    viewport.Model
 	issue       *jira.Issue
+	tab         detailTab
 	loading     bool
 	spinner     spinner.Model
 	ready       bool
@@ -149,6 +158,14 @@ func (d DetailModel) Update(msg tea.Msg, client *jira.Client) (DetailModel, tea.
 			d.viewport.LineUp(1)
 			return d, nil
 
+		case key.Matches(msg, detailKeys.NextTab):
+			if d.issue != nil {
+				d.tab = (d.tab + 1) % 2
+				d.viewport.SetContent(d.renderContent())
+				d.viewport.GotoTop()
+			}
+			return d, nil
+
 		case key.Matches(msg, detailKeys.Edit):
 			if d.issue != nil {
 				return d, func() tea.Msg { return navigateToFormMsg{issue: d.issue} }
@@ -184,6 +201,12 @@ func (d DetailModel) Update(msg tea.Msg, client *jira.Client) (DetailModel, tea.
 				return d, cmd
 			}
 
+		case key.Matches(msg, detailKeys.Tree):
+			if d.issue != nil {
+				k := d.issue.Key
+				return d, func() tea.Msg { return navigateToTreeMsg{key: k} }
+			}
+
 		case key.Matches(msg, detailKeys.Open):
 			// We don't have the base URL here, so skip browser open for now
 			return d, nil
@@ -245,8 +268,14 @@ func (d DetailModel) renderContent() string {
 	// Header
 	b.WriteString(titleStyle.Render(fmt.Sprintf("  %s", issue.Key)) + "\n")
 	b.WriteString(headerStyle.Render(issue.Fields.Summary) + "\n")
+	b.WriteString(d.renderTabs() + "\n")
 	b.WriteString(dimStyle.Render(strings.Repeat("─", min(w, 60))) + "\n\n")
 
+	if d.tab == detailTabHistory {
+		b.WriteString(renderHistory(issue.Changelog, w))
+		return b.String()
+	}
+
 	// Status badge + Type + Priority
 	status := StatusStyle(issue.Fields.Status.Name).Render(fmt.Sprintf(" %s ", issue.Fields.Status.Name))
 	line := status
@@ -356,6 +385,9 @@ func (d DetailModel) renderContent() string {
 		b.WriteString("\n" + titleStyle.Render("Description") + "\n")
 		b.WriteString(dimStyle.Render(strings.Repeat("─", min(w, 40))) + "\n")
 		desc := cleanHTMLForTUI(issue.Fields.DescriptionText)
+		if jira.IsADF(issue.Fields.Description) {
+			desc = issue.Fields.DescriptionPlain()
+		}
 		b.WriteString(desc + "\n")
 	}
 
@@ -392,6 +424,9 @@ func (d DetailModel) renderContent() string {
 			}
 			b.WriteString(fmt.Sprintf("  %s  %s\n", lipgloss.NewStyle().Bold(true).Foreground(colorYellow).Render(author), dimStyle.Render(created)))
 			body := cleanHTMLForTUI(comment.Body)
+			if len(comment.BodyADF) > 0 {
+				body = comment.PlainBody()
+			}
 			// Indent comment body
 			for _, line := range strings.Split(body, "\n") {
 				b.WriteString("    " + line + "\n")
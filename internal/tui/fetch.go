@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"context"
+	"sync"
+)
+
+// fetchSlot identifies a kind of background load. Starting a load in a slot
+// cancels the one already in flight there, so a view the user has moved on
+// from never receives stale results.
+type fetchSlot int
+
+const (
+	fetchDashboard  fetchSlot = iota // dashboard list: my issues, epic children, project epics
+	fetchDetail                      // the issue shown in the detail view
+	fetchPRList                      // the PR view
+	fetchKanban                      // the Kanban board
+	fetchKanbanMove                  // moving a Kanban card
+	fetchJQL                         // JQL prompt suggestions and validation
+	fetchTree                        // the hierarchy tree
+	fetchBulk                        // a bulk change and the transitions it offers
+	fetchSavedView                   // dashboard tab 1; tab n uses fetchSavedView+n-1
+)
+
+// fetchTracker hands out per-slot contexts derived from a base context. It is
+// shared by pointer because Bubble Tea copies models on every update. A nil
+// tracker is valid and never cancels anything.
+type fetchTracker struct {
+	base context.Context
+
+	mu      sync.Mutex
+	cancels map[fetchSlot]context.CancelFunc
+}
+
+func newFetchTracker(base context.Context) *fetchTracker {
+	return &fetchTracker{base: base, cancels: make(map[fetchSlot]context.CancelFunc)}
+}
+
+// start cancels any load in flight in slot and returns the context for the
+// next one.
+func (t *fetchTracker) start(slot fetchSlot) context.Context {
+	if t == nil {
+		return context.Background()
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if cancel := t.cancels[slot]; cancel != nil {
+		cancel()
+	}
+	ctx, cancel := context.WithCancel(t.base)
+	t.cancels[slot] = cancel
+	return ctx
+}
+
+// cancel stops the loads in flight in the given slots.
+func (t *fetchTracker) cancel(slots ...fetchSlot) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, slot := range slots {
+		if cancel := t.cancels[slot]; cancel != nil {
+			cancel()
+			delete(t.cancels, slot)
+		}
+	}
+}
+
+// cancelAll stops every load in flight.
+func (t *fetchTracker) cancelAll() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for slot, cancel := range t.cancels {
+		cancel()
+		delete(t.cancels, slot)
+	}
+}
+
+// dashboardSlot returns the slot of dashboard tab tab, so that each tab
+// loads independently of the others.
+func dashboardSlot(tab int) fetchSlot {
+	if tab == 0 {
+		return fetchDashboard
+	}
+	return fetchSavedView + fetchSlot(tab-1)
+}
+
+// slotFor returns the slot whose loads belong to view v.
+func slotFor(v viewID) (fetchSlot, bool) {
+	switch v {
+	case viewDetail:
+		return fetchDetail, true
+	case viewPRs:
+		return fetchPRList, true
+	case viewKanban:
+		return fetchKanban, true
+	case viewTree:
+		return fetchTree, true
+	}
+	return 0, false
+}
@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFetchTrackerCancelsSupersededLoad(t *testing.T) {
+	tr := newFetchTracker(context.Background())
+	first := tr.start(fetchDashboard)
+	second := tr.start(fetchDashboard)
+	if first.Err() == nil {
+		t.Fatal("first load was not cancelled when the second started")
+	}
+	if second.Err() != nil {
+		t.Fatal("second load cancelled early")
+	}
+
+	detail := tr.start(fetchDetail)
+	tr.cancel(fetchDetail)
+	if detail.Err() == nil {
+		t.Fatal("detail load not cancelled")
+	}
+	if second.Err() != nil {
+		t.Fatal("cancelling one slot affected another")
+	}
+}
+
+func TestFetchTrackerFollowsBaseContext(t *testing.T) {
+	base, cancel := context.WithCancel(context.Background())
+	tr := newFetchTracker(base)
+	ctx := tr.start(fetchPRList)
+	cancel()
+	if ctx.Err() == nil {
+		t.Fatal("load not cancelled with the base context")
+	}
+}
+
+func TestNilFetchTracker(t *testing.T) {
+	var tr *fetchTracker
+	if ctx := tr.start(fetchPRList); ctx.Err() != nil {
+		t.Fatal("nil tracker returned a cancelled context")
+	}
+	tr.cancelAll()
+}
+
+func TestGoBackCancelsDetailLoad(t *testing.T) {
+	a := NewApp(context.Background(), nil, nil, nil)
+	m, _ := a.Update(navigateToDetailMsg{key: "PROJ-1"})
+	a = m.(App)
+	if a.fetches.cancels[fetchDetail] == nil {
+		t.Fatal("no detail load started")
+	}
+	m, _ = a.Update(goBackMsg{})
+	a = m.(App)
+	if _, ok := a.fetches.cancels[fetchDetail]; ok {
+		t.Fatal("detail load still tracked after leaving the view")
+	}
+}
@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"jet/internal/jira"
+)
+
+// renderTabs draws the detail view's tab bar with the active tab highlighted.
+func (d DetailModel) renderTabs() string {
+	history := "History"
+	if d.issue != nil && d.issue.Changelog != nil {
+		history = fmt.Sprintf("History (%d)", len(d.issue.Changelog.Entries()))
+	}
+	tabs := []string{"Details", history}
+
+	active := lipgloss.NewStyle().Bold(true).Foreground(colorCyan).Underline(true)
+	var parts []string
+	for i, t := range tabs {
+		if detailTab(i) == d.tab {
+			parts = append(parts, active.Render(t))
+		} else {
+			parts = append(parts, dimStyle.Render(t))
+		}
+	}
+	return "  " + strings.Join(parts, dimStyle.Render("  │  "))
+}
+
+// renderHistory lists changelog entries newest first, grouped by edit, so
+// the latest status change is the first thing on screen.
+func renderHistory(changelog *jira.Changelog, width int) string {
+	entries := changelog.Entries()
+	if len(entries) == 0 {
+		return dimStyle.Render("  No history recorded.") + "\n"
+	}
+
+	var b strings.Builder
+	if changelog.Total > len(changelog.Histories) {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("  Showing the latest %d of %d edits; run 'jet history' for all.", len(changelog.Histories), changelog.Total)) + "\n\n")
+	}
+
+	author := lipgloss.NewStyle().Bold(true).Foreground(colorYellow)
+	field := lipgloss.NewStyle().Foreground(colorBlue)
+	maxValue := max(width/3, 20)
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if i == len(entries)-1 || !e.Time.Equal(entries[i+1].Time) || e.Author != entries[i+1].Author {
+			if i != len(entries)-1 {
+				b.WriteString("\n")
+			}
+			b.WriteString(fmt.Sprintf("  %s  %s\n", author.Render(e.Author), dimStyle.Render(e.Time.Local().Format("2006-01-02 15:04"))))
+		}
+		to := valueStyle
+		if strings.EqualFold(e.Field, "status") {
+			to = StatusStyle(e.To)
+		}
+		b.WriteString(fmt.Sprintf("    %s %s → %s\n",
+			field.Render(e.Field+":"),
+			historyValue(e.From, maxValue, dimStyle),
+			historyValue(e.To, maxValue, to)))
+	}
+	return b.String()
+}
+
+// historyValue flattens and shortens a changed value for a single line.
+func historyValue(v string, maxLen int, style lipgloss.Style) string {
+	if v == "" {
+		return dimStyle.Render("(none)")
+	}
+	v = strings.Join(strings.Fields(v), " ")
+	if r := []rune(v); len(r) > maxLen {
+		v = string(r[:maxLen-1]) + "…"
+	}
+	return style.Render(v)
+}
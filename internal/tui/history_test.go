@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"jet/internal/jira"
+)
+
+func historyIssue() *jira.Issue {
+	return &jira.Issue{
+		Key:    "PROJ-1",
+		Fields: jira.Fields{Summary: "Mysterious status change"},
+		Changelog: &jira.Changelog{
+			Total: 3,
+			Histories: []jira.History{
+				{Author: jira.User{DisplayName: "Alice"}, Created: "2024-03-01T09:30:00.000+0000", Items: []jira.ChangeItem{
+					{Field: "status", FromString: "To Do", ToString: "In Progress"},
+				}},
+				{Author: jira.User{DisplayName: "Bob"}, Created: "2024-03-02T09:30:00.000+0000", Items: []jira.ChangeItem{
+					{Field: "status", FromString: "In Progress", ToString: "Done"},
+				}},
+			},
+		},
+	}
+}
+
+func TestDetailHistoryTab(t *testing.T) {
+	d := NewDetailModel().SetSize(100, 40).SetIssue(historyIssue())
+	if strings.Contains(d.View(), "In Progress → Done") {
+		t.Fatal("history shown on the details tab")
+	}
+
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyTab}, nil)
+	view := d.View()
+	for _, want := range []string{"History (2)", "Bob", "In Progress → Done", "latest 2 of 3 edits"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q\n---\n%s", want, view)
+		}
+	}
+	// Newest first.
+	if strings.Index(view, "Bob") > strings.Index(view, "Alice") {
+		t.Errorf("history not newest first\n%s", view)
+	}
+}
@@ -0,0 +1,623 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"jet/internal/jira"
+)
+
+// jqlSuggestDelay is how long typing must pause before value suggestions
+// are fetched from Jira.
+const jqlSuggestDelay = 150 * time.Millisecond
+
+// jqlMaxSuggestions is how many suggestions the prompt shows at once.
+const jqlMaxSuggestions = 6
+
+// jqlContextKind is what the text at the cursor is expected to be.
+type jqlContextKind int
+
+const (
+	jqlExpectField jqlContextKind = iota
+	jqlExpectOperator
+	jqlExpectValue
+	jqlExpectKeyword // after a complete clause: AND, OR, ORDER BY
+	jqlExpectOrderField
+	jqlExpectDirection
+)
+
+// jqlToken is a word, quoted string, operator or punctuation of a query.
+type jqlToken struct {
+	text       string
+	start, end int // byte offsets
+}
+
+func (t jqlToken) lower() string { return strings.ToLower(t.text) }
+
+func isJQLOperatorChar(r byte) bool { return strings.IndexByte("=!<>~", r) >= 0 }
+
+// tokenizeJQL splits a query into tokens. An unterminated string runs to
+// the end, as it does while being typed.
+func tokenizeJQL(s string) []jqlToken {
+	var tokens []jqlToken
+	for i := 0; i < len(s); {
+		c := s[i]
+		start := i
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+			continue
+		case c == '"' || c == '\'':
+			i++
+			for i < len(s) && s[i] != c {
+				if s[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			i = min(i+1, len(s))
+		case c == '(' || c == ')' || c == ',':
+			i++
+		case isJQLOperatorChar(c):
+			for i < len(s) && isJQLOperatorChar(s[i]) {
+				i++
+			}
+		default:
+			for i < len(s) && !strings.ContainsRune(" \t\n()\",'", rune(s[i])) && !isJQLOperatorChar(s[i]) {
+				i++
+			}
+		}
+		tokens = append(tokens, jqlToken{text: s[start:i], start: start, end: i})
+	}
+	return tokens
+}
+
+// jqlCompletion describes what is being typed at the cursor.
+type jqlCompletion struct {
+	kind     jqlContextKind
+	field    string // the clause's field, for operators and values
+	operator string // the clause's operator, lower-cased, for values
+	prefix   string // the partial token being typed
+	start    int    // byte offset where prefix starts
+	openList bool   // an IN list is expected but its "(" is not typed yet
+}
+
+// analyzeJQL works out what is expected at the end of before, the query
+// text up to the cursor.
+func analyzeJQL(before string) jqlCompletion {
+	tokens := tokenizeJQL(before)
+	c := jqlCompletion{start: len(before)}
+	if n := len(tokens); n > 0 && tokens[n-1].end == len(before) {
+		last := tokens[n-1]
+		if last.text != "(" && last.text != ")" && last.text != "," && !isJQLOperatorChar(last.text[0]) {
+			c.prefix, c.start = last.text, last.start
+			tokens = tokens[:n-1]
+		}
+	}
+
+	state := jqlExpectField
+	inList, listOpen := false, false
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		lw := t.lower()
+		switch state {
+		case jqlExpectField:
+			switch {
+			case t.text == "(" || lw == "not":
+			case lw == "order":
+				if i+1 < len(tokens) && tokens[i+1].lower() == "by" {
+					i++
+				}
+				state = jqlExpectOrderField
+			default:
+				c.field, c.operator = t.text, ""
+				state = jqlExpectOperator
+			}
+		case jqlExpectOperator:
+			switch lw {
+			case "not":
+				c.operator = "not"
+			case "in":
+				c.operator = strings.TrimSpace(c.operator + " in")
+				inList, listOpen = true, false
+				state = jqlExpectValue
+			case "is", "was":
+				c.operator = lw
+				state = jqlExpectValue
+			case "changed":
+				state = jqlExpectKeyword
+			default:
+				c.operator = lw
+				state = jqlExpectValue
+			}
+		case jqlExpectValue:
+			switch {
+			case (c.operator == "is" || c.operator == "was") && lw == "not":
+				c.operator += " not"
+			case strings.HasPrefix(c.operator, "was") && lw == "in":
+				c.operator += " in"
+				inList, listOpen = true, false
+			case inList && t.text == "(" && !listOpen:
+				listOpen = true
+			case inList && t.text == ",":
+			case inList && t.text == ")":
+				inList = false
+				state = jqlExpectKeyword
+			default:
+				// A function call: skip its arguments.
+				if i+1 < len(tokens) && tokens[i+1].text == "(" {
+					depth := 0
+					for i++; i < len(tokens); i++ {
+						if tokens[i].text == "(" {
+							depth++
+						} else if tokens[i].text == ")" {
+							if depth--; depth == 0 {
+								break
+							}
+						}
+					}
+				}
+				if !inList {
+					state = jqlExpectKeyword
+				}
+			}
+		case jqlExpectKeyword:
+			switch lw {
+			case "and", "or":
+				state = jqlExpectField
+			case "order":
+				if i+1 < len(tokens) && tokens[i+1].lower() == "by" {
+					i++
+				}
+				state = jqlExpectOrderField
+			}
+		case jqlExpectOrderField:
+			if t.text != "," {
+				c.field = t.text
+				state = jqlExpectDirection
+			}
+		case jqlExpectDirection:
+			if t.text == "," {
+				state = jqlExpectOrderField
+			}
+		}
+	}
+	c.kind = state
+	c.openList = state == jqlExpectValue && inList && !listOpen
+	return c
+}
+
+// jqlSuggestion is a completion: the text inserted and what is shown.
+type jqlSuggestion struct {
+	value string
+	label string
+}
+
+var defaultJQLOperators = []string{"=", "!=", "~", "!~", ">", ">=", "<", "<=", "in", "not in", "is", "is not", "was", "was in", "was not", "was not in", "changed"}
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+// buildJQLSuggestions lists the completions for c. data may be nil when the
+// site's autocomplete data could not be loaded; remote holds Jira's value
+// suggestions for c.field and c.prefix.
+func buildJQLSuggestions(c jqlCompletion, data *jira.JQLAutocompleteData, remote []jira.JQLSuggestion) []jqlSuggestion {
+	prefix := strings.Trim(c.prefix, `"'`)
+	var out []jqlSuggestion
+	add := func(value, label string, candidates ...string) {
+		for _, cand := range candidates {
+			if hasPrefixFold(strings.Trim(cand, `"'`), prefix) {
+				out = append(out, jqlSuggestion{value: value, label: label})
+				return
+			}
+		}
+	}
+
+	switch c.kind {
+	case jqlExpectField, jqlExpectOrderField:
+		if data == nil {
+			break
+		}
+		for _, f := range data.Fields {
+			if c.kind == jqlExpectOrderField && f.Orderable != "true" || c.kind == jqlExpectField && f.Searchable == "false" {
+				continue
+			}
+			add(f.Value, f.DisplayName, f.Value, f.DisplayName)
+		}
+		sort.SliceStable(out, func(i, j int) bool {
+			return strings.ToLower(strings.Trim(out[i].value, `"`)) < strings.ToLower(strings.Trim(out[j].value, `"`))
+		})
+	case jqlExpectOperator:
+		ops := defaultJQLOperators
+		if data != nil {
+			if f := data.Field(c.field); f != nil && len(f.Operators) > 0 {
+				ops = f.Operators
+			}
+		}
+		for _, op := range ops {
+			add(op, "", op)
+		}
+	case jqlExpectValue:
+		if strings.HasPrefix(c.operator, "is") {
+			for _, v := range []string{"EMPTY", "NULL"} {
+				add(v, "", v)
+			}
+			break
+		}
+		for _, s := range remote {
+			label := htmlTag.ReplaceAllString(s.DisplayName, "")
+			out = append(out, jqlSuggestion{value: quoteJQLValue(s.Value), label: label})
+		}
+		if data != nil {
+			var types []string
+			if f := data.Field(c.field); f != nil {
+				types = f.Types
+			}
+			for _, fn := range data.Functions {
+				if len(types) == 0 || sharesType(types, fn.Types) {
+					add(fn.Value, fn.DisplayName, fn.Value)
+				}
+			}
+		}
+	case jqlExpectKeyword:
+		for _, kw := range []string{"AND", "OR", "ORDER BY"} {
+			add(kw, "", kw)
+		}
+	case jqlExpectDirection:
+		for _, kw := range []string{"ASC", "DESC"} {
+			add(kw, "", kw)
+		}
+	}
+	return out
+}
+
+func sharesType(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// quoteJQLValue quotes a value that is not a single JQL word.
+func quoteJQLValue(v string) string {
+	if v == "" || strings.HasPrefix(v, `"`) || strings.HasPrefix(v, "'") {
+		return v
+	}
+	if strings.ContainsAny(v, " \t()\",'=!<>~") {
+		return `"` + strings.ReplaceAll(v, `"`, `\"`) + `"`
+	}
+	return v
+}
+
+// applyJQLSuggestion replaces the partial token before the cursor with s,
+// returning the new query and cursor position.
+func applyJQLSuggestion(query string, cursor int, c jqlCompletion, s jqlSuggestion) (string, int) {
+	insert := s.value + " "
+	if c.openList {
+		insert = "(" + insert
+	}
+	out := query[:c.start] + insert + strings.TrimLeft(query[cursor:], " ")
+	return out, c.start + len(insert)
+}
+
+// suggestionKey identifies a remote value lookup.
+func (c jqlCompletion) suggestionKey() string {
+	if c.kind != jqlExpectValue || c.field == "" || strings.HasPrefix(c.operator, "is") {
+		return ""
+	}
+	return c.field + "\x00" + strings.Trim(c.prefix, `"'`)
+}
+
+type jqlDataLoadedMsg struct {
+	data *jira.JQLAutocompleteData
+	err  error
+}
+
+type jqlSuggestTickMsg struct{ seq int }
+
+type jqlSuggestionsMsg struct {
+	key     string
+	results []jira.JQLSuggestion
+}
+
+type jqlValidatedMsg struct {
+	query      string
+	validation *jira.JQLValidation
+	err        error
+}
+
+// jqlAcceptedMsg carries a validated query to run.
+type jqlAcceptedMsg struct{ query string }
+
+func fetchJQLData(ctx context.Context, client *jira.Client) tea.Cmd {
+	return func() tea.Msg {
+		data, err := client.GetJQLAutocompleteDataContext(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		return jqlDataLoadedMsg{data: data, err: err}
+	}
+}
+
+// fetchJQLSuggestions asks Jira for values of a field. Failures are quiet:
+// the prompt just offers no values.
+func fetchJQLSuggestions(ctx context.Context, client *jira.Client, key, field, prefix string) tea.Cmd {
+	return func() tea.Msg {
+		results, err := client.GetJQLSuggestionsContext(ctx, field, prefix)
+		if ctx.Err() != nil || err != nil {
+			return nil
+		}
+		return jqlSuggestionsMsg{key: key, results: results}
+	}
+}
+
+func validateJQL(ctx context.Context, client *jira.Client, query string) tea.Cmd {
+	return func() tea.Msg {
+		results, err := client.ValidateJQLContext(ctx, query)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return jqlValidatedMsg{query: query, err: err}
+		}
+		if len(results) == 0 {
+			return jqlValidatedMsg{query: query}
+		}
+		return jqlValidatedMsg{query: query, validation: &results[0]}
+	}
+}
+
+// jqlPrompt is a single-line JQL editor with field, operator and value
+// suggestions, which validates the query with Jira before running it.
+type jqlPrompt struct {
+	input  textinput.Model
+	active bool
+
+	data    *jira.JQLAutocompleteData
+	dataErr error
+
+	completion  jqlCompletion
+	suggestions []jqlSuggestion
+	selected    int
+
+	seq       int    // bumped on every edit; debounces remote lookups
+	remoteKey string // lookup the remote values belong to
+	remote    []jira.JQLSuggestion
+
+	validating bool
+	errors     []jira.JQLError
+
+	fetches *fetchTracker
+}
+
+func newJQLPrompt() jqlPrompt {
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.Placeholder = "JQL, e.g. project = PROJ AND status = \"In Progress\""
+	return jqlPrompt{input: ti}
+}
+
+func (p jqlPrompt) Active() bool { return p.active }
+
+// Open starts editing query, loading the autocomplete data the first time.
+func (p jqlPrompt) Open(query string, client *jira.Client) (jqlPrompt, tea.Cmd) {
+	p.active = true
+	p.validating = false
+	p.errors = nil
+	p.input.SetValue(query)
+	p.input.CursorEnd()
+	p = p.refresh()
+	cmds := []tea.Cmd{p.input.Focus()}
+	if p.data == nil && client != nil {
+		cmds = append(cmds, fetchJQLData(p.fetches.start(fetchJQL), client))
+	}
+	return p, tea.Batch(cmds...)
+}
+
+func (p jqlPrompt) close() jqlPrompt {
+	p.active = false
+	p.input.Blur()
+	p.fetches.cancel(fetchJQL)
+	return p
+}
+
+// refresh recomputes the completion and suggestions for the cursor.
+func (p jqlPrompt) refresh() jqlPrompt {
+	value := p.input.Value()
+	p.completion = analyzeJQL(value[:p.cursorByte()])
+	remote := p.remote
+	if p.remoteKey != p.completion.suggestionKey() {
+		remote = nil
+	}
+	p.suggestions = buildJQLSuggestions(p.completion, p.data, remote)
+	if p.selected >= len(p.suggestions) {
+		p.selected = 0
+	}
+	return p
+}
+
+// cursorByte is the cursor's byte offset in the input's value.
+func (p jqlPrompt) cursorByte() int {
+	runes := []rune(p.input.Value())
+	return len(string(runes[:min(p.input.Position(), len(runes))]))
+}
+
+// remoteField is the name Jira's suggestions endpoint expects for a field.
+func (p jqlPrompt) remoteField(field string) string {
+	if p.data != nil {
+		if f := p.data.Field(field); f != nil {
+			if f.CFID != "" {
+				return f.CFID
+			}
+			return strings.Trim(f.Value, `"`)
+		}
+	}
+	return strings.Trim(field, `"'`)
+}
+
+func (p jqlPrompt) Update(msg tea.Msg, client *jira.Client) (jqlPrompt, tea.Cmd) {
+	switch msg := msg.(type) {
+	case jqlDataLoadedMsg:
+		p.data, p.dataErr = msg.data, msg.err
+		return p.refresh(), nil
+
+	case jqlSuggestTickMsg:
+		key := p.completion.suggestionKey()
+		if msg.seq != p.seq || key == "" || key == p.remoteKey || client == nil {
+			return p, nil
+		}
+		ctx := p.fetches.start(fetchJQL)
+		return p, fetchJQLSuggestions(ctx, client, key, p.remoteField(p.completion.field), strings.Trim(p.completion.prefix, `"'`))
+
+	case jqlSuggestionsMsg:
+		p.remoteKey, p.remote = msg.key, msg.results
+		return p.refresh(), nil
+
+	case jqlValidatedMsg:
+		if !p.active || msg.query != p.input.Value() {
+			return p, nil
+		}
+		p.validating = false
+		// When Jira cannot validate, run the query and let the search
+		// report any problem.
+		if msg.err == nil && msg.validation != nil && !msg.validation.Valid() {
+			p.errors = msg.validation.Errors
+			return p, nil
+		}
+		p = p.close()
+		query := msg.query
+		return p, func() tea.Msg { return jqlAcceptedMsg{query: query} }
+
+	case tea.KeyMsg:
+		if !p.active {
+			return p, nil
+		}
+		switch msg.String() {
+		case "esc":
+			return p.close(), nil
+		case "enter":
+			query := strings.TrimSpace(p.input.Value())
+			if query == "" || client == nil {
+				return p, nil
+			}
+			p.input.SetValue(query)
+			p.validating = true
+			p.errors = nil
+			return p, validateJQL(p.fetches.start(fetchJQL), client, query)
+		case "tab":
+			if len(p.suggestions) == 0 {
+				return p, nil
+			}
+			value, cursor := applyJQLSuggestion(p.input.Value(), p.cursorByte(), p.completion, p.suggestions[p.selected])
+			p.input.SetValue(value)
+			p.input.SetCursor(len([]rune(value[:cursor])))
+			p.selected = 0
+			return p.edited()
+		case "up", "ctrl+p":
+			if p.selected > 0 {
+				p.selected--
+			}
+			return p, nil
+		case "down", "ctrl+n":
+			if p.selected < len(p.suggestions)-1 {
+				p.selected++
+			}
+			return p, nil
+		}
+		before := p.input.Value()
+		var cmd tea.Cmd
+		p.input, cmd = p.input.Update(msg)
+		if p.input.Value() == before {
+			return p.refresh(), cmd
+		}
+		p.selected = 0
+		p.errors = nil
+		var tick tea.Cmd
+		p, tick = p.edited()
+		return p, tea.Batch(cmd, tick)
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	return p, cmd
+}
+
+// edited refreshes the suggestions after the query changed and schedules a
+// lookup of Jira's value suggestions once typing pauses.
+func (p jqlPrompt) edited() (jqlPrompt, tea.Cmd) {
+	p.seq++
+	p = p.refresh()
+	if p.completion.suggestionKey() == "" {
+		return p, nil
+	}
+	seq := p.seq
+	return p, tea.Tick(jqlSuggestDelay, func(time.Time) tea.Msg { return jqlSuggestTickMsg{seq: seq} })
+}
+
+func (p jqlPrompt) View(width int) string {
+	label := lipgloss.NewStyle().Foreground(colorCyan).Bold(true).Render("JQL: ")
+	p.input.Width = max(10, width-6)
+	lines := []string{label + p.input.View()}
+
+	switch {
+	case p.validating:
+		lines = append(lines, dimStyle.Render("  Validating..."))
+	case len(p.errors) > 0:
+		query := p.input.Value()
+		for _, e := range p.errors {
+			lines = append(lines, errorStyle.Render("  "+e.Message))
+			if e.Column > 0 && e.Line <= 1 {
+				lines = append(lines, jqlErrorContext(query, e.Column, width)...)
+			}
+		}
+	case len(p.suggestions) > 0:
+		first := max(0, p.selected-jqlMaxSuggestions+1)
+		last := min(len(p.suggestions), first+jqlMaxSuggestions)
+		for i := first; i < last; i++ {
+			s := p.suggestions[i]
+			text := s.value
+			if s.label != "" && !strings.EqualFold(strings.Trim(s.value, `"`), s.label) {
+				text += dimStyle.Render("  " + s.label)
+			}
+			if i == p.selected {
+				lines = append(lines, lipgloss.NewStyle().Foreground(colorCyan).Bold(true).Render("  ▸ ")+text)
+			} else {
+				lines = append(lines, "    "+text)
+			}
+		}
+		if len(p.suggestions) > last {
+			lines = append(lines, dimStyle.Render(fmt.Sprintf("    … %d more", len(p.suggestions)-last)))
+		}
+	case p.dataErr != nil:
+		lines = append(lines, dimStyle.Render("  No field suggestions: "+p.dataErr.Error()))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// jqlErrorContext shows the part of query around column (1-based) with a
+// caret under the offending character.
+func jqlErrorContext(query string, column, width int) []string {
+	runes := []rune(query)
+	at := min(column-1, len(runes))
+	span := max(20, width-8)
+	start := max(0, at-span/2)
+	end := min(len(runes), start+span)
+	return []string{
+		dimStyle.Render("    " + string(runes[start:end])),
+		errorStyle.Render("    " + strings.Repeat(" ", at-start) + "^"),
+	}
+}
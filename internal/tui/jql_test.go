@@ -0,0 +1,183 @@
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"jet/internal/jira"
+)
+
+func TestAnalyzeJQL(t *testing.T) {
+	tests := []struct {
+		before   string
+		kind     jqlContextKind
+		field    string
+		operator string
+		prefix   string
+	}{
+		{"", jqlExpectField, "", "", ""},
+		{"sta", jqlExpectField, "", "", "sta"},
+		{"status ", jqlExpectOperator, "status", "", ""},
+		{"status = ", jqlExpectValue, "status", "=", ""},
+		{"status=In", jqlExpectValue, "status", "=", "In"},
+		{`status = "In Pro`, jqlExpectValue, "status", "=", `"In Pro`},
+		{`status = "In Progress" `, jqlExpectKeyword, "status", "=", ""},
+		{"status = Done AND ass", jqlExpectField, "status", "=", "ass"},
+		{"assignee = currentUser() ", jqlExpectKeyword, "assignee", "=", ""},
+		{"status not in (Done, ", jqlExpectValue, "status", "not in", ""},
+		{"status in (Done) o", jqlExpectKeyword, "status", "in", "o"},
+		{"resolution is not ", jqlExpectValue, "resolution", "is not", ""},
+		{`project = X ORDER BY upd`, jqlExpectOrderField, "project", "=", "upd"},
+		{`project = X ORDER BY updated `, jqlExpectDirection, "updated", "=", ""},
+		{`"Story Points" > `, jqlExpectValue, `"Story Points"`, ">", ""},
+	}
+	for _, tt := range tests {
+		c := analyzeJQL(tt.before)
+		if c.kind != tt.kind || c.prefix != tt.prefix || tt.field != "" && c.field != tt.field || tt.operator != "" && c.operator != tt.operator {
+			t.Errorf("analyzeJQL(%q) = %+v", tt.before, c)
+		}
+	}
+	if c := analyzeJQL("status in "); !c.openList {
+		t.Error("IN without ( does not open a list")
+	}
+}
+
+func TestApplyJQLSuggestion(t *testing.T) {
+	query := "status = in AND project = X"
+	c := analyzeJQL(query[:11])
+	got, cursor := applyJQLSuggestion(query, 11, c, jqlSuggestion{value: `"In Progress"`})
+	if got != `status = "In Progress" AND project = X` || cursor != 23 {
+		t.Errorf("got %q, cursor %d", got, cursor)
+	}
+	c = analyzeJQL("status in ")
+	if got, _ := applyJQLSuggestion("status in ", 10, c, jqlSuggestion{value: "Done"}); got != "status in (Done " {
+		t.Errorf("list got %q", got)
+	}
+}
+
+// fakeJQLJira serves the autocomplete, suggestion and parse endpoints.
+func fakeJQLJira(t *testing.T) *jira.Client {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/jql/autocompletedata":
+			w.Write([]byte(`{
+				"visibleFieldNames":[
+					{"value":"assignee","displayName":"Assignee","orderable":"true","searchable":"true","operators":["=","!=","in","is"],"types":["com.atlassian.jira.user.ApplicationUser"]},
+					{"value":"status","displayName":"Status","orderable":"true","searchable":"true","operators":["=","!=","in","not in","was"],"types":["com.atlassian.jira.issue.status.Status"]},
+					{"value":"summary","displayName":"Summary","orderable":"true","searchable":"true","operators":["~","!~"],"types":["java.lang.String"]}
+				],
+				"visibleFunctionNames":[{"value":"currentUser()","displayName":"currentUser()","types":["com.atlassian.jira.user.ApplicationUser"]}],
+				"jqlReservedWords":["and","or"]}`))
+		case "/rest/api/3/jql/autocompletedata/suggestions":
+			if r.URL.Query().Get("fieldName") != "status" {
+				t.Errorf("fieldName = %q", r.URL.Query().Get("fieldName"))
+			}
+			prefix := strings.ToLower(r.URL.Query().Get("fieldValue"))
+			var results []map[string]string
+			for _, s := range []string{"In Progress", "In Review", "Done"} {
+				if strings.HasPrefix(strings.ToLower(s), prefix) {
+					results = append(results, map[string]string{"value": `"` + s + `"`, "displayName": "<b>" + s + "</b>"})
+				}
+			}
+			json.NewEncoder(w).Encode(map[string]any{"results": results})
+		case "/rest/api/3/jql/parse":
+			var body struct{ Queries []string }
+			json.NewDecoder(r.Body).Decode(&body)
+			q := body.Queries[0]
+			if strings.Contains(q, "sttus") {
+				json.NewEncoder(w).Encode(map[string]any{"queries": []any{map[string]any{"query": q, "errors": []string{"Field 'sttus' does not exist or you do not have permission to view it."}}}})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"queries": []any{map[string]any{"query": q}}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return jira.NewClient(srv.URL, "me@example.com", "", "token")
+}
+
+func typeJQL(t *testing.T, p jqlPrompt, client *jira.Client, s string) jqlPrompt {
+	t.Helper()
+	for _, r := range s {
+		p, _ = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}, client)
+	}
+	return p
+}
+
+func TestJQLPromptSuggestionFlow(t *testing.T) {
+	client := fakeJQLJira(t)
+	p := newJQLPrompt()
+	p, _ = p.Open("", client)
+	p, _ = p.Update(fetchJQLData(context.Background(), client)(), client)
+	if p.data == nil || len(p.suggestions) != 3 {
+		t.Fatalf("field suggestions = %+v (err %v)", p.suggestions, p.dataErr)
+	}
+
+	// Field: "st" completes to status.
+	p = typeJQL(t, p, client, "st")
+	if len(p.suggestions) != 1 || p.suggestions[0].value != "status" {
+		t.Fatalf("after 'st': %+v", p.suggestions)
+	}
+	p, _ = p.Update(tea.KeyMsg{Type: tea.KeyTab}, client)
+	if p.input.Value() != "status " {
+		t.Fatalf("after tab: %q", p.input.Value())
+	}
+
+	// Operators come from the field.
+	if got := len(p.suggestions); got != 5 {
+		t.Errorf("operator suggestions = %+v", p.suggestions)
+	}
+	p = typeJQL(t, p, client, "= in")
+
+	// Values come from Jira once typing pauses.
+	p, cmd := p.Update(jqlSuggestTickMsg{seq: p.seq}, client)
+	if cmd == nil {
+		t.Fatal("no value lookup after the pause")
+	}
+	p, _ = p.Update(cmd(), client)
+	if len(p.suggestions) != 2 || p.suggestions[1].value != `"In Review"` || p.suggestions[1].label != "In Review" {
+		t.Fatalf("value suggestions = %+v", p.suggestions)
+	}
+	p, _ = p.Update(tea.KeyMsg{Type: tea.KeyDown}, client)
+	p, _ = p.Update(tea.KeyMsg{Type: tea.KeyTab}, client)
+	if p.input.Value() != `status = "In Review" ` {
+		t.Fatalf("after value tab: %q", p.input.Value())
+	}
+	if p.completion.kind != jqlExpectKeyword || p.suggestions[0].value != "AND" {
+		t.Errorf("after value: %+v", p.suggestions)
+	}
+
+	// Enter validates, then hands the query over.
+	p, cmd = p.Update(tea.KeyMsg{Type: tea.KeyEnter}, client)
+	if !p.validating || cmd == nil {
+		t.Fatal("enter did not validate")
+	}
+	p, cmd = p.Update(cmd(), client)
+	if p.Active() || cmd == nil {
+		t.Fatal("valid query did not close the prompt")
+	}
+	if msg, ok := cmd().(jqlAcceptedMsg); !ok || msg.query != `status = "In Review"` {
+		t.Errorf("accepted = %#v", msg)
+	}
+}
+
+func TestJQLPromptShowsErrorLocation(t *testing.T) {
+	client := fakeJQLJira(t)
+	p := newJQLPrompt()
+	p, _ = p.Open("project = X AND sttus = Done", client)
+	p, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter}, client)
+	p, _ = p.Update(cmd(), client)
+	if !p.Active() || len(p.errors) != 1 || p.errors[0].Column != 17 {
+		t.Fatalf("errors = %+v", p.errors)
+	}
+	view := p.View(80)
+	if !strings.Contains(view, "does not exist") || !strings.Contains(view, strings.Repeat(" ", 16)+"^") {
+		t.Errorf("view = %s", view)
+	}
+}
@@ -0,0 +1,721 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"jet/internal/jira"
+)
+
+// kanbanMaxIssues caps the issues loaded for a JQL board.
+const kanbanMaxIssues = 200
+
+// kanbanBoardJQL keeps long-finished work off Kanban boards, which would
+// otherwise return every issue ever done.
+const kanbanBoardJQL = "statusCategory != Done OR resolved >= -14d"
+
+// kanbanGrouping decides what the columns are.
+type kanbanGrouping int
+
+const (
+	groupByStatus   kanbanGrouping = iota // one column per status
+	groupByCategory                       // To Do / In Progress / Done
+	groupByBoard                          // the Agile board's configured columns
+)
+
+func (g kanbanGrouping) String() string {
+	switch g {
+	case groupByCategory:
+		return "category"
+	case groupByBoard:
+		return "board columns"
+	}
+	return "status"
+}
+
+// kanbanLanes decides the horizontal swimlanes.
+type kanbanLanes int
+
+const (
+	lanesNone kanbanLanes = iota
+	lanesAssignee
+	lanesEpic
+)
+
+func (l kanbanLanes) String() string {
+	switch l {
+	case lanesAssignee:
+		return "assignee"
+	case lanesEpic:
+		return "epic"
+	}
+	return "none"
+}
+
+// kanbanColumn is one column and the issues in it. A card belongs to the
+// column when its status ID or name is listed, or, for category columns,
+// when its status category matches.
+type kanbanColumn struct {
+	name      string
+	statusIDs []string
+	statuses  []string // lower-cased status names
+	category  string   // "new", "indeterminate" or "done"
+	max       int      // WIP limit, 0 for none
+	cards     []int    // indexes into the model's issues, in lane order
+}
+
+func (c kanbanColumn) accepts(st jira.Status) bool {
+	for _, id := range c.statusIDs {
+		if st.ID != "" && id == st.ID {
+			return true
+		}
+	}
+	for _, name := range c.statuses {
+		if strings.EqualFold(name, st.Name) {
+			return true
+		}
+	}
+	return c.category != "" && c.category == statusCategory(st)
+}
+
+// statusCategory returns a status's category key, guessing from the name
+// when Jira did not send one.
+func statusCategory(st jira.Status) string {
+	if st.Category != nil && st.Category.Key != "" {
+		return st.Category.Key
+	}
+	if st.IsDone() {
+		return "done"
+	}
+	return "new"
+}
+
+var categoryRank = map[string]int{"new": 0, "indeterminate": 1, "done": 2}
+
+// kanbanLane is a swimlane; an empty key is the catch-all lane
+// ("Unassigned", "No epic"), which sorts last.
+type kanbanLane struct {
+	key   string
+	label string
+}
+
+func laneOf(issue jira.Issue, lanes kanbanLanes) kanbanLane {
+	switch lanes {
+	case lanesAssignee:
+		if a := issue.Fields.Assignee; a != nil {
+			name := a.DisplayName
+			if name == "" {
+				name = a.Name
+			}
+			return kanbanLane{key: name, label: name}
+		}
+		return kanbanLane{label: "Unassigned"}
+	case lanesEpic:
+		if e := issue.Fields.EpicLink; e != nil && e.Key != "" {
+			return kanbanLane{key: e.Key, label: strings.TrimSpace(e.Key + " " + e.Summary)}
+		}
+		if p := issue.Fields.Parent; p != nil && p.Key != "" {
+			return kanbanLane{key: p.Key, label: strings.TrimSpace(p.Key + " " + p.Summary)}
+		}
+		return kanbanLane{label: "No epic"}
+	}
+	return kanbanLane{}
+}
+
+// buildKanbanColumns lays issues out in columns. Issues keep their order
+// (the search or board rank) within a column, grouped by lane. Issues whose
+// status is on no board column are left out, as Jira does.
+func buildKanbanColumns(issues []jira.Issue, boardCols []jira.BoardColumn, grouping kanbanGrouping, lanes kanbanLanes) ([]kanbanColumn, []kanbanLane) {
+	var cols []kanbanColumn
+	switch grouping {
+	case groupByBoard:
+		for _, bc := range boardCols {
+			cols = append(cols, kanbanColumn{name: bc.Name, statusIDs: bc.Statuses, max: bc.Max})
+		}
+	case groupByCategory:
+		cols = []kanbanColumn{
+			{name: "To Do", category: "new"},
+			{name: "In Progress", category: "indeterminate"},
+			{name: "Done", category: "done"},
+		}
+	default:
+		seen := map[string]bool{}
+		for _, issue := range issues {
+			st := issue.Fields.Status
+			if seen[strings.ToLower(st.Name)] {
+				continue
+			}
+			seen[strings.ToLower(st.Name)] = true
+			col := kanbanColumn{name: st.Name, statuses: []string{strings.ToLower(st.Name)}, category: statusCategory(st)}
+			if st.ID != "" {
+				col.statusIDs = []string{st.ID}
+			}
+			cols = append(cols, col)
+		}
+		// Stable insertion sort by category keeps first-seen order within one.
+		for i := 1; i < len(cols); i++ {
+			for j := i; j > 0 && categoryRank[cols[j].category] < categoryRank[cols[j-1].category]; j-- {
+				cols[j], cols[j-1] = cols[j-1], cols[j]
+			}
+		}
+		// Status columns match by status only; category is just for ordering.
+		for i := range cols {
+			cols[i].category = ""
+		}
+	}
+
+	// Lanes in first-seen order, the catch-all last.
+	var laneList []kanbanLane
+	laneIndex := map[string]int{}
+	hasCatchAll := false
+	var catchAll kanbanLane
+	for _, issue := range issues {
+		l := laneOf(issue, lanes)
+		if l.key == "" {
+			hasCatchAll, catchAll = true, l
+			continue
+		}
+		if _, ok := laneIndex[l.key]; !ok {
+			laneIndex[l.key] = len(laneList)
+			laneList = append(laneList, l)
+		}
+	}
+	if hasCatchAll {
+		laneIndex[""] = len(laneList)
+		laneList = append(laneList, catchAll)
+	}
+
+	for li := range laneList {
+		for i, issue := range issues {
+			if laneIndex[laneOf(issue, lanes).key] != li {
+				continue
+			}
+			for c := range cols {
+				if cols[c].accepts(issue.Fields.Status) {
+					cols[c].cards = append(cols[c].cards, i)
+					break
+				}
+			}
+		}
+	}
+	return cols, laneList
+}
+
+// pickTransition finds the transition that lands an issue in col,
+// preferring an exact status over a category match.
+func pickTransition(transitions []jira.Transition, col kanbanColumn) *jira.Transition {
+	var fallback *jira.Transition
+	for i, t := range transitions {
+		for _, id := range col.statusIDs {
+			if t.To.ID != "" && t.To.ID == id {
+				return &transitions[i]
+			}
+		}
+		for _, name := range col.statuses {
+			if strings.EqualFold(t.To.Name, name) {
+				return &transitions[i]
+			}
+		}
+		if fallback == nil && col.category != "" && statusCategory(t.To) == col.category {
+			fallback = &transitions[i]
+		}
+	}
+	return fallback
+}
+
+type navigateToKanbanMsg struct {
+	jql string
+}
+
+type kanbanLoadedMsg struct {
+	issues  []jira.Issue
+	board   *jira.Board // nil for a JQL board
+	columns []jira.BoardColumn
+}
+
+type kanbanMovedMsg struct {
+	issueKey string
+	to       jira.Status
+}
+
+// fetchKanbanJQL loads the issues of a JQL board.
+func fetchKanbanJQL(ctx context.Context, client *jira.Client, jql string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := client.SearchIssuesContext(ctx, jql, kanbanMaxIssues)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return kanbanLoadedMsg{issues: resp.Issues}
+	}
+}
+
+// fetchKanbanBoard loads an Agile board by ID or name: its columns, and the
+// issues of its active sprints (Scrum) or its recent issues (Kanban).
+func fetchKanbanBoard(ctx context.Context, client *jira.Client, ref string) tea.Cmd {
+	return func() tea.Msg {
+		msg, err := loadKanbanBoard(ctx, client, ref)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return msg
+	}
+}
+
+func loadKanbanBoard(ctx context.Context, client *jira.Client, ref string) (kanbanLoadedMsg, error) {
+	board, err := client.ResolveBoardContext(ctx, ref)
+	if err != nil {
+		return kanbanLoadedMsg{}, err
+	}
+
+	columns, err := client.GetBoardColumnsContext(ctx, board.ID)
+	if err != nil {
+		return kanbanLoadedMsg{}, err
+	}
+	msg := kanbanLoadedMsg{board: board, columns: columns}
+	if board.Type != "scrum" {
+		msg.issues, err = client.GetBoardIssuesContext(ctx, board.ID, kanbanBoardJQL)
+		return msg, err
+	}
+	sprints, err := client.GetSprintsContext(ctx, board.ID, jira.SprintActive)
+	if err != nil {
+		return kanbanLoadedMsg{}, err
+	}
+	for _, s := range sprints {
+		issues, err := client.GetSprintIssuesContext(ctx, s.ID)
+		if err != nil {
+			return kanbanLoadedMsg{}, err
+		}
+		msg.issues = append(msg.issues, issues...)
+	}
+	return msg, nil
+}
+
+// moveCard transitions an issue so it lands in col.
+func moveCard(ctx context.Context, client *jira.Client, issueKey string, col kanbanColumn) tea.Cmd {
+	return func() tea.Msg {
+		transitions, err := client.GetTransitionsContext(ctx, issueKey)
+		if err != nil {
+			return errMsg{err: err}
+		}
+		t := pickTransition(transitions, col)
+		if t == nil {
+			return errMsg{err: fmt.Errorf("%s has no transition to %s", issueKey, col.name)}
+		}
+		if err := client.TransitionIssueContext(ctx, issueKey, t.ID); err != nil {
+			return errMsg{err: err}
+		}
+		return kanbanMovedMsg{issueKey: issueKey, to: t.To}
+	}
+}
+
+// KanbanModel lays the issues of a JQL query or an Agile board out in
+// columns, with optional swimlanes.
+type KanbanModel struct {
+	jql       string
+	board     *jira.Board
+	boardCols []jira.BoardColumn
+	issues    []jira.Issue
+
+	grouping kanbanGrouping
+	lanes    kanbanLanes
+	columns  []kanbanColumn
+	laneList []kanbanLane
+
+	col, row int // selected column and card within it
+	firstCol int // leftmost visible column
+	scroll   int // first visible body line
+
+	loading bool
+	moving  string // key of the card being moved
+	spinner spinner.Model
+
+	prompt    textinput.Model
+	prompting bool
+
+	width, height int
+	fetches       *fetchTracker // shared with App; cancels superseded loads
+}
+
+// NewKanbanModel creates a board for the issues matching jql.
+func NewKanbanModel(jql string) KanbanModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = lipgloss.NewStyle().Foreground(colorCyan)
+
+	ti := textinput.New()
+	ti.CharLimit = 64
+	ti.Placeholder = "Board ID or name (empty for the dashboard query)"
+
+	return KanbanModel{jql: jql, loading: true, spinner: s, prompt: ti}
+}
+
+func (m KanbanModel) Init() tea.Cmd { return m.spinner.Tick }
+
+func (m KanbanModel) SetSize(width, height int) KanbanModel {
+	m.width = width
+	m.height = height
+	return m
+}
+
+// SetData shows freshly loaded issues, keeping the selection on the same
+// card when it is still there.
+func (m KanbanModel) SetData(msg kanbanLoadedMsg) KanbanModel {
+	selected := m.selectedKey()
+	m.loading = false
+	m.issues = msg.issues
+	m.board = msg.board
+	m.boardCols = msg.columns
+	switch {
+	case m.board != nil:
+		m.grouping = groupByBoard
+	case m.grouping == groupByBoard:
+		m.grouping = groupByStatus
+	}
+	m.regroup(selected)
+	return m
+}
+
+// regroup rebuilds the columns and puts the cursor back on key if present.
+func (m *KanbanModel) regroup(key string) {
+	m.columns, m.laneList = buildKanbanColumns(m.issues, m.boardCols, m.grouping, m.lanes)
+	m.col, m.row = 0, 0
+	for c, col := range m.columns {
+		for r, i := range col.cards {
+			if m.issues[i].Key == key {
+				m.col, m.row = c, r
+			}
+		}
+	}
+	if key == "" {
+		// Start on the first column with cards.
+		for c, col := range m.columns {
+			if len(col.cards) > 0 {
+				m.col = c
+				break
+			}
+		}
+	}
+	m.clampCursor()
+}
+
+func (m *KanbanModel) clampCursor() {
+	if m.col >= len(m.columns) {
+		m.col = len(m.columns) - 1
+	}
+	if m.col < 0 {
+		m.col = 0
+	}
+	if m.col < len(m.columns) {
+		if n := len(m.columns[m.col].cards); m.row >= n {
+			m.row = n - 1
+		}
+	}
+	if m.row < 0 {
+		m.row = 0
+	}
+}
+
+func (m KanbanModel) selectedIssue() *jira.Issue {
+	if m.col >= len(m.columns) || m.row >= len(m.columns[m.col].cards) {
+		return nil
+	}
+	return &m.issues[m.columns[m.col].cards[m.row]]
+}
+
+func (m KanbanModel) selectedKey() string {
+	if issue := m.selectedIssue(); issue != nil {
+		return issue.Key
+	}
+	return ""
+}
+
+// Failed stops waiting on a load or move after the App has shown its error.
+func (m KanbanModel) Failed() KanbanModel {
+	m.loading = false
+	m.moving = ""
+	return m
+}
+
+// reload fetches the current source again.
+func (m KanbanModel) reload(client *jira.Client) tea.Cmd {
+	ctx := m.fetches.start(fetchKanban)
+	if m.board != nil {
+		return fetchKanbanBoard(ctx, client, strconv.Itoa(m.board.ID))
+	}
+	return fetchKanbanJQL(ctx, client, m.jql)
+}
+
+func (m KanbanModel) Update(msg tea.Msg, client *jira.Client) (KanbanModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case kanbanMovedMsg:
+		m.moving = ""
+		for i := range m.issues {
+			if m.issues[i].Key == msg.issueKey {
+				m.issues[i].Fields.Status = msg.to
+			}
+		}
+		m.regroup(msg.issueKey)
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.prompting {
+			switch msg.String() {
+			case "esc":
+				m.prompting = false
+				m.prompt.Blur()
+				return m, nil
+			case "enter":
+				ref := strings.TrimSpace(m.prompt.Value())
+				m.prompting = false
+				m.prompt.Blur()
+				m.prompt.SetValue("")
+				m.loading = true
+				if ref == "" {
+					m.board = nil
+					return m, tea.Batch(m.spinner.Tick, fetchKanbanJQL(m.fetches.start(fetchKanban), client, m.jql))
+				}
+				return m, tea.Batch(m.spinner.Tick, fetchKanbanBoard(m.fetches.start(fetchKanban), client, ref))
+			}
+			var cmd tea.Cmd
+			m.prompt, cmd = m.prompt.Update(msg)
+			return m, cmd
+		}
+
+		switch {
+		case key.Matches(msg, kanbanKeys.Left):
+			if m.col > 0 {
+				m.col--
+				m.clampCursor()
+			}
+		case key.Matches(msg, kanbanKeys.Right):
+			if m.col < len(m.columns)-1 {
+				m.col++
+				m.clampCursor()
+			}
+		case key.Matches(msg, kanbanKeys.Up):
+			if m.row > 0 {
+				m.row--
+			}
+		case key.Matches(msg, kanbanKeys.Down):
+			if m.col < len(m.columns) && m.row < len(m.columns[m.col].cards)-1 {
+				m.row++
+			}
+		case key.Matches(msg, kanbanKeys.MoveLeft), key.Matches(msg, kanbanKeys.MoveRight):
+			target := m.col - 1
+			if key.Matches(msg, kanbanKeys.MoveRight) {
+				target = m.col + 1
+			}
+			issue := m.selectedIssue()
+			if issue == nil || m.moving != "" || target < 0 || target >= len(m.columns) {
+				return m, nil
+			}
+			m.moving = issue.Key
+			return m, tea.Batch(m.spinner.Tick, moveCard(m.fetches.start(fetchKanbanMove), client, issue.Key, m.columns[target]))
+		case key.Matches(msg, kanbanKeys.Enter):
+			if issue := m.selectedIssue(); issue != nil {
+				return m, func() tea.Msg { return navigateToDetailMsg{key: issue.Key} }
+			}
+		case key.Matches(msg, kanbanKeys.Group):
+			m.grouping = (m.grouping + 1) % 3
+			if m.grouping == groupByBoard && m.board == nil {
+				m.grouping = groupByStatus
+			}
+			m.regroup(m.selectedKey())
+		case key.Matches(msg, kanbanKeys.Lanes):
+			m.lanes = (m.lanes + 1) % 3
+			m.regroup(m.selectedKey())
+		case key.Matches(msg, kanbanKeys.Board):
+			m.prompting = true
+			return m, m.prompt.Focus()
+		case key.Matches(msg, kanbanKeys.Refresh):
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, m.reload(client))
+		case key.Matches(msg, globalKeys.Back):
+			return m, func() tea.Msg { return goBackMsg{} }
+		}
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.loading || m.moving != "" {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// columnWidth is the width of each column including its one-space gutter.
+func (m KanbanModel) columnWidth() int {
+	if len(m.columns) == 0 {
+		return m.width
+	}
+	w := m.width / len(m.columns)
+	return max(w, 24)
+}
+
+func (m KanbanModel) View() string {
+	if m.loading && len(m.issues) == 0 {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+			m.spinner.View()+" Loading board...")
+	}
+
+	title := "Board: " + m.jql
+	if m.board != nil {
+		title = fmt.Sprintf("Board: %s (%s)", m.board.Name, m.board.Type)
+	}
+	info := fmt.Sprintf("%d issues · columns by %s · lanes: %s", len(m.issues), m.grouping, m.lanes)
+	if m.loading {
+		info = m.spinner.View() + " Refreshing · " + info
+	} else if m.moving != "" {
+		info = m.spinner.View() + " Moving " + m.moving + " · " + info
+	}
+	header := []string{
+		titleStyle.Render(truncateRunes(title, m.width)),
+		subtitleStyle.Render(truncateRunes(info, m.width)),
+	}
+
+	var footer []string
+	if m.prompting {
+		footer = append(footer, lipgloss.NewStyle().Foreground(colorCyan).Bold(true).Render("Board: ")+m.prompt.View())
+	}
+
+	if len(m.columns) == 0 {
+		body := dimStyle.Render("  No issues to show.")
+		return strings.Join(append(append(header, "", body), footer...), "\n")
+	}
+
+	// Window of columns that fits, keeping the selected one visible.
+	colW := m.columnWidth()
+	visible := max(1, m.width/colW)
+	first := m.firstCol
+	if m.col < first {
+		first = m.col
+	}
+	if m.col >= first+visible {
+		first = m.col - visible + 1
+	}
+	first = max(0, min(first, len(m.columns)-visible))
+	last := min(len(m.columns), first+visible)
+
+	cell := func(s string, style lipgloss.Style) string {
+		return style.Render(padRunes(truncateRunes(s, colW-1), colW-1)) + " "
+	}
+
+	// Column headers with WIP counts.
+	var heads []string
+	for c := first; c < last; c++ {
+		col := m.columns[c]
+		count := fmt.Sprintf("%d", len(col.cards))
+		style := headerStyle
+		if col.max > 0 {
+			count = fmt.Sprintf("%d/%d", len(col.cards), col.max)
+			if len(col.cards) > col.max {
+				style = errorStyle
+			}
+		}
+		heads = append(heads, cell(col.name+" ("+count+")", style))
+	}
+	more := ""
+	if first > 0 {
+		more += "◀ "
+	}
+	if last < len(m.columns) {
+		more += "▶"
+	}
+	header = append(header, strings.Join(heads, "")+dimStyle.Render(more))
+	header = append(header, dimStyle.Render(strings.Repeat("─", min(m.width, colW*(last-first)))))
+
+	// Body: each lane is a header line and then rows of cards.
+	var body []string
+	selectedLine := 0
+	for li, lane := range m.laneList {
+		if m.lanes != lanesNone {
+			if li > 0 {
+				body = append(body, "")
+			}
+			body = append(body, labelStyle.Render(truncateRunes("▸ "+lane.label, m.width)))
+		}
+		var laneCards [][]int // per visible column, the rows of this lane
+		rows := 0
+		for c := first; c < last; c++ {
+			var cards []int
+			for r, i := range m.columns[c].cards {
+				if laneOf(m.issues[i], m.lanes) == lane {
+					cards = append(cards, r)
+				}
+			}
+			laneCards = append(laneCards, cards)
+			rows = max(rows, len(cards))
+		}
+		for r := 0; r < rows; r++ {
+			var line strings.Builder
+			for vc, cards := range laneCards {
+				c := first + vc
+				if r >= len(cards) {
+					line.WriteString(strings.Repeat(" ", colW))
+					continue
+				}
+				issue := m.issues[m.columns[c].cards[cards[r]]]
+				style := lipgloss.NewStyle().Foreground(colorWhite)
+				if c == m.col && cards[r] == m.row {
+					style = style.Background(lipgloss.Color("236")).Bold(true).Foreground(colorCyan)
+					selectedLine = len(body)
+				} else if issue.Key == m.moving {
+					style = dimStyle
+				}
+				line.WriteString(cell(issue.Key+" "+issue.Fields.Summary, style))
+			}
+			body = append(body, line.String())
+		}
+	}
+
+	// Scroll the body to keep the selected card in view.
+	avail := max(1, m.height-len(header)-len(footer))
+	scroll := m.scroll
+	if selectedLine < scroll {
+		scroll = selectedLine
+	}
+	if selectedLine >= scroll+avail {
+		scroll = selectedLine - avail + 1
+	}
+	scroll = max(0, min(scroll, len(body)-avail))
+	body = body[scroll:min(len(body), scroll+avail)]
+
+	lines := append(header, body...)
+	lines = append(lines, footer...)
+	return strings.Join(lines, "\n")
+}
+
+// truncateRunes shortens s to n display characters, ending in "…".
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	if n <= 1 {
+		return string(r[:max(n, 0)])
+	}
+	return string(r[:n-1]) + "…"
+}
+
+// padRunes right-pads s with spaces to n characters.
+func padRunes(s string, n int) string {
+	if w := len([]rune(s)); w < n {
+		return s + strings.Repeat(" ", n-w)
+	}
+	return s
+}
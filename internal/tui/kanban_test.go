@@ -0,0 +1,136 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+
+	"jet/internal/jira"
+)
+
+func kanbanIssue(key, status, category, assignee, epic string) jira.Issue {
+	issue := jira.Issue{Key: key}
+	issue.Fields.Status = jira.Status{Name: status, Category: &jira.StatusCategory{Key: category}}
+	if assignee != "" {
+		issue.Fields.Assignee = &jira.User{DisplayName: assignee}
+	}
+	if epic != "" {
+		issue.Fields.EpicLink = &jira.EpicLink{Key: epic}
+	}
+	return issue
+}
+
+// columnKeys renders columns as "Name:KEY,KEY" for easy comparison.
+func columnKeys(issues []jira.Issue, cols []kanbanColumn) string {
+	var out []string
+	for _, c := range cols {
+		s := c.name + ":"
+		for i, idx := range c.cards {
+			if i > 0 {
+				s += ","
+			}
+			s += issues[idx].Key
+		}
+		out = append(out, s)
+	}
+	return fmt.Sprint(out)
+}
+
+func TestBuildKanbanColumns(t *testing.T) {
+	issues := []jira.Issue{
+		kanbanIssue("P-1", "Done", "done", "Ann", "P-10"),
+		kanbanIssue("P-2", "In Review", "indeterminate", "", "P-11"),
+		kanbanIssue("P-3", "To Do", "new", "Bob", ""),
+		kanbanIssue("P-4", "In Progress", "indeterminate", "Ann", "P-11"),
+		kanbanIssue("P-5", "To Do", "new", "Ann", "P-10"),
+	}
+
+	tests := []struct {
+		name     string
+		grouping kanbanGrouping
+		lanes    kanbanLanes
+		want     string
+	}{
+		{"status", groupByStatus, lanesNone, "[To Do:P-3,P-5 In Review:P-2 In Progress:P-4 Done:P-1]"},
+		{"category", groupByCategory, lanesNone, "[To Do:P-3,P-5 In Progress:P-2,P-4 Done:P-1]"},
+		{"assignee lanes", groupByCategory, lanesAssignee, "[To Do:P-5,P-3 In Progress:P-4,P-2 Done:P-1]"},
+		{"epic lanes", groupByCategory, lanesEpic, "[To Do:P-5,P-3 In Progress:P-2,P-4 Done:P-1]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cols, _ := buildKanbanColumns(issues, nil, tt.grouping, tt.lanes)
+			if got := columnKeys(issues, cols); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	_, lanes := buildKanbanColumns(issues, nil, groupByStatus, lanesAssignee)
+	if fmt.Sprint(lanes) != "[{Ann Ann} {Bob Bob} { Unassigned}]" {
+		t.Errorf("lanes = %v", lanes)
+	}
+}
+
+func TestBuildKanbanColumnsFromBoard(t *testing.T) {
+	issues := []jira.Issue{
+		{Key: "P-1", Fields: jira.Fields{Status: jira.Status{ID: "1", Name: "Open"}}},
+		{Key: "P-2", Fields: jira.Fields{Status: jira.Status{ID: "3", Name: "Doing"}}},
+		{Key: "P-3", Fields: jira.Fields{Status: jira.Status{ID: "9", Name: "Hidden"}}},
+		{Key: "P-4", Fields: jira.Fields{Status: jira.Status{ID: "4", Name: "Review"}}},
+	}
+	board := []jira.BoardColumn{
+		{Name: "Backlog", Statuses: []string{"1"}},
+		{Name: "Selected", Statuses: []string{"2"}},
+		{Name: "Doing", Statuses: []string{"3", "4"}, Max: 1},
+	}
+	cols, _ := buildKanbanColumns(issues, board, groupByBoard, lanesNone)
+	if got := columnKeys(issues, cols); got != "[Backlog:P-1 Selected: Doing:P-2,P-4]" {
+		t.Errorf("got %s", got)
+	}
+	if cols[2].max != 1 {
+		t.Errorf("WIP limit = %d", cols[2].max)
+	}
+}
+
+func TestPickTransition(t *testing.T) {
+	transitions := []jira.Transition{
+		{ID: "11", To: jira.Status{ID: "1", Name: "To Do", Category: &jira.StatusCategory{Key: "new"}}},
+		{ID: "21", To: jira.Status{ID: "3", Name: "In Progress", Category: &jira.StatusCategory{Key: "indeterminate"}}},
+		{ID: "31", To: jira.Status{ID: "4", Name: "In Review", Category: &jira.StatusCategory{Key: "indeterminate"}}},
+	}
+	tests := []struct {
+		name string
+		col  kanbanColumn
+		want string
+	}{
+		{"by status id", kanbanColumn{statusIDs: []string{"4"}}, "31"},
+		{"by status name", kanbanColumn{statuses: []string{"in review"}}, "31"},
+		{"by category", kanbanColumn{category: "indeterminate"}, "21"},
+		{"none", kanbanColumn{category: "done"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if tr := pickTransition(transitions, tt.col); tr != nil {
+				got = tr.ID
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKanbanMoveKeepsSelection(t *testing.T) {
+	m := NewKanbanModel("project = P").SetSize(100, 30)
+	m = m.SetData(kanbanLoadedMsg{issues: []jira.Issue{
+		kanbanIssue("P-1", "To Do", "new", "", ""),
+		kanbanIssue("P-2", "In Progress", "indeterminate", "", ""),
+	}})
+	if m.selectedKey() != "P-1" {
+		t.Fatalf("selected %q", m.selectedKey())
+	}
+	m, _ = m.Update(kanbanMovedMsg{issueKey: "P-1", to: jira.Status{Name: "In Progress"}}, nil)
+	if m.selectedKey() != "P-1" || m.col != 0 || len(m.columns) != 1 {
+		t.Errorf("after move: selected %q in column %d of %d", m.selectedKey(), m.col, len(m.columns))
+	}
+}
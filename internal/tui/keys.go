@@ -44,6 +44,15 @@ type dashboardKeyMap struct {
 	Workflow   key.Binding
 	Standup    key.Binding
 	PRs        key.Binding
+	Timer      key.Binding
+	Board      key.Binding
+	Tab        key.Binding
+	JQL        key.Binding
+	Mark       key.Binding
+	Visual     key.Binding
+	Unmark     key.Binding
+	Label      key.Binding
+	Assign     key.Binding
 }
 
 var dashboardKeys = dashboardKeyMap{
@@ -119,6 +128,42 @@ var dashboardKeys = dashboardKeyMap{
 		key.WithKeys("P"),
 		key.WithHelp("P", "pull requests"),
 	),
+	Timer: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "start/stop timer"),
+	),
+	Board: key.NewBinding(
+		key.WithKeys("B"),
+		key.WithHelp("B", "board"),
+	),
+	Tab: key.NewBinding(
+		key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"),
+		key.WithHelp("1-9", "switch tab"),
+	),
+	JQL: key.NewBinding(
+		key.WithKeys("J"),
+		key.WithHelp("J", "edit JQL"),
+	),
+	Mark: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "mark"),
+	),
+	Visual: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "mark a range"),
+	),
+	Unmark: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "clear marks"),
+	),
+	Label: key.NewBinding(
+		key.WithKeys("+"),
+		key.WithHelp("+", "labels"),
+	),
+	Assign: key.NewBinding(
+		key.WithKeys("A"),
+		key.WithHelp("A", "assign"),
+	),
 }
 
 // Detail view key bindings.
@@ -129,6 +174,8 @@ type detailKeyMap struct {
 	Grab       key.Binding
 	Open       key.Binding
 	Claude     key.Binding
+	NextTab    key.Binding
+	Tree       key.Binding
 }
 
 var detailKeys = detailKeyMap{
@@ -156,6 +203,128 @@ var detailKeys = detailKeyMap{
 		key.WithKeys("C"),
 		key.WithHelp("C", "claude task"),
 	),
+	NextTab: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "details/history"),
+	),
+	Tree: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "hierarchy tree"),
+	),
+}
+
+// Kanban board key bindings.
+type kanbanKeyMap struct {
+	Left      key.Binding
+	Right     key.Binding
+	Up        key.Binding
+	Down      key.Binding
+	MoveLeft  key.Binding
+	MoveRight key.Binding
+	Enter     key.Binding
+	Group     key.Binding
+	Lanes     key.Binding
+	Board     key.Binding
+	Refresh   key.Binding
+}
+
+var kanbanKeys = kanbanKeyMap{
+	Left: key.NewBinding(
+		key.WithKeys("h", "left"),
+		key.WithHelp("h", "column left"),
+	),
+	Right: key.NewBinding(
+		key.WithKeys("l", "right"),
+		key.WithHelp("l", "column right"),
+	),
+	Up: key.NewBinding(
+		key.WithKeys("k", "up"),
+		key.WithHelp("k", "card up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("j", "down"),
+		key.WithHelp("j", "card down"),
+	),
+	MoveLeft: key.NewBinding(
+		key.WithKeys("<", "H", "shift+left"),
+		key.WithHelp("<", "move card left"),
+	),
+	MoveRight: key.NewBinding(
+		key.WithKeys(">", "L", "shift+right"),
+		key.WithHelp(">", "move card right"),
+	),
+	Enter: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "view"),
+	),
+	Group: key.NewBinding(
+		key.WithKeys("g"),
+		key.WithHelp("g", "group columns"),
+	),
+	Lanes: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "swimlanes"),
+	),
+	Board: key.NewBinding(
+		key.WithKeys("b"),
+		key.WithHelp("b", "choose board"),
+	),
+	Refresh: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "refresh"),
+	),
+}
+
+// Hierarchy tree key bindings.
+type treeKeyMap struct {
+	Up          key.Binding
+	Down        key.Binding
+	Expand      key.Binding
+	Collapse    key.Binding
+	Toggle      key.Binding
+	ExpandAll   key.Binding
+	CollapseAll key.Binding
+	Enter       key.Binding
+	Refresh     key.Binding
+}
+
+var treeKeys = treeKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("k", "up"),
+		key.WithHelp("k", "up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("j", "down"),
+		key.WithHelp("j", "down"),
+	),
+	Expand: key.NewBinding(
+		key.WithKeys("l", "right"),
+		key.WithHelp("l", "expand"),
+	),
+	Collapse: key.NewBinding(
+		key.WithKeys("h", "left"),
+		key.WithHelp("h", "collapse/parent"),
+	),
+	Toggle: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "toggle"),
+	),
+	ExpandAll: key.NewBinding(
+		key.WithKeys("+", "="),
+		key.WithHelp("+", "expand all"),
+	),
+	CollapseAll: key.NewBinding(
+		key.WithKeys("-"),
+		key.WithHelp("-", "collapse all"),
+	),
+	Enter: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "view"),
+	),
+	Refresh: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "refresh"),
+	),
 }
 
 // Form key bindings.
@@ -179,3 +348,30 @@ var formKeys = formKeyMap{
 		key.WithHelp("ctrl+s", "submit"),
 	),
 }
+
+// Bulk change overlay key bindings.
+type bulkKeyMap struct {
+	Up      key.Binding
+	Down    key.Binding
+	Confirm key.Binding
+	Cancel  key.Binding
+}
+
+var bulkKeys = bulkKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("up", "k"),
+		key.WithHelp("k", "up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "j"),
+		key.WithHelp("j", "down"),
+	),
+	Confirm: key.NewBinding(
+		key.WithKeys("y", "enter"),
+		key.WithHelp("y", "apply"),
+	),
+	Cancel: key.NewBinding(
+		key.WithKeys("n", "esc", "u"),
+		key.WithHelp("n", "cancel"),
+	),
+}
@@ -36,6 +36,7 @@ type PRsModel struct {
 	height       int
 	scrollOffset int
 	warnings     []string
+	fetches      *fetchTracker // shared with App; cancels superseded loads
 }
 
 // NewPRsModel creates a PR view for the given scope ("mine" or "team").
@@ -156,7 +157,7 @@ func (m PRsModel) Update(msg tea.Msg, _ interface{}) (PRsModel, tea.Cmd) {
 			return m, func() tea.Msg { return navigateToPRsMsg{scope: next} }
 		case msg.String() == "r":
 			m.loading = true
-			return m, tea.Batch(m.spinner.Tick, fetchPRs(m.scope))
+			return m, tea.Batch(m.spinner.Tick, fetchPRs(m.fetches.start(fetchPRList), m.scope))
 		case key.Matches(msg, globalKeys.Back):
 			return m, func() tea.Msg { return goBackMsg{} }
 		}
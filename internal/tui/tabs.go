@@ -0,0 +1,110 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Tab is a dashboard tab: a named JQL query, usually one saved with
+// 'jet query save'. The first tab is the default dashboard.
+type Tab struct {
+	Name string
+	JQL  string
+}
+
+// maxTabs is how many tabs the number keys can reach.
+const maxTabs = 9
+
+type switchTabMsg struct{ tab int }
+
+// newDashboardTabs creates one dashboard per tab, each with its own list,
+// cursor and load state.
+func newDashboardTabs(tabs []Tab, fetches *fetchTracker) []DashboardModel {
+	if len(tabs) > maxTabs {
+		tabs = tabs[:maxTabs]
+	}
+	dashboards := make([]DashboardModel, len(tabs))
+	for i, t := range tabs {
+		d := NewDashboardModel(t.JQL)
+		d.fetches = fetches
+		d.tab = i
+		if i > 0 {
+			d.name = t.Name
+		}
+		dashboards[i] = d
+	}
+	return dashboards
+}
+
+// dashboardTab returns tab i; the active tab lives in a.dashboard.
+func (a App) dashboardTab(i int) (DashboardModel, bool) {
+	if i == a.activeTab {
+		return a.dashboard, true
+	}
+	if i < 0 || i >= len(a.tabs) {
+		return DashboardModel{}, false
+	}
+	return a.tabs[i], true
+}
+
+func (a *App) setDashboardTab(i int, d DashboardModel) {
+	if i == a.activeTab {
+		a.dashboard = d
+	} else if i >= 0 && i < len(a.tabs) {
+		a.tabs[i] = d
+	}
+}
+
+// switchTab makes tab n active, loading it the first time it is shown.
+// The tab being left keeps its cursor and any load in flight.
+func (a App) switchTab(n int) (App, tea.Cmd) {
+	if n == a.activeTab || n < 0 || n >= len(a.tabs) {
+		return a, nil
+	}
+	a.tabs[a.activeTab] = a.dashboard
+	a.activeTab = n
+	a.dashboard = a.tabs[n].SetSize(a.width, a.dashboardHeight())
+	if a.dashboard.requested {
+		if a.dashboard.loading {
+			return a, a.dashboard.Init()
+		}
+		return a, nil
+	}
+	a.dashboard.requested = true
+	return a, tea.Batch(a.dashboard.Init(), a.dashboard.reload(a.client))
+}
+
+// dashboardHeight is the dashboard's height below the tab bar, if any.
+func (a App) dashboardHeight() int {
+	h := a.height - 2
+	if len(a.tabs) > 1 {
+		h--
+	}
+	return h
+}
+
+// tabBar renders the tab names with their number keys, the active one
+// highlighted. It is empty when there is only the default dashboard.
+func (a App) tabBar() string {
+	if len(a.tabs) < 2 {
+		return ""
+	}
+	active := lipgloss.NewStyle().Foreground(colorCyan).Background(lipgloss.Color("236")).Bold(true)
+	var parts []string
+	for i, d := range a.tabs {
+		name := d.name
+		if i == 0 {
+			name = "dashboard"
+		}
+		label := fmt.Sprintf(" %d %s ", i+1, name)
+		if i == a.activeTab {
+			parts = append(parts, active.Render(label))
+		} else {
+			parts = append(parts, dimStyle.Render(label))
+		}
+	}
+	return strings.Join(parts, " ")
+}
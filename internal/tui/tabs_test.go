@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"context"
+	"testing"
+
+	"jet/internal/jira"
+)
+
+func TestDashboardTabs(t *testing.T) {
+	a := NewApp(context.Background(), nil, []Tab{{JQL: "assignee = currentUser()"}, {Name: "bugs", JQL: "type = Bug"}}, nil)
+	m, _ := a.Update(issuesLoadedMsg{tab: 0, issues: []jira.Issue{{Key: "P-1"}, {Key: "P-2"}}, total: 2})
+	a = m.(App)
+	a.dashboard.list.Select(1)
+
+	m, cmd := a.Update(switchTabMsg{tab: 1})
+	a = m.(App)
+	if a.activeTab != 1 || a.dashboard.jql != "type = Bug" || cmd == nil {
+		t.Fatalf("switch: tab %d, jql %q, cmd %v", a.activeTab, a.dashboard.jql, cmd)
+	}
+	if _, ok := a.fetches.cancels[dashboardSlot(1)]; !ok {
+		t.Error("first visit did not load the tab")
+	}
+
+	// A load for the other tab lands there, not on the active one.
+	m, _ = a.Update(issuesLoadedMsg{tab: 0, issues: []jira.Issue{{Key: "P-1"}, {Key: "P-2"}, {Key: "P-3"}}, total: 3})
+	a = m.(App)
+	if len(a.dashboard.list.Items()) != 0 || !a.dashboard.loading {
+		t.Errorf("active tab got another tab's issues")
+	}
+
+	m, cmd = a.Update(switchTabMsg{tab: 0})
+	a = m.(App)
+	if cmd != nil {
+		t.Error("returning to a loaded tab loaded it again")
+	}
+	if a.dashboard.total != 3 || a.dashboard.list.Index() != 1 {
+		t.Errorf("tab 0: total %d, cursor %d", a.dashboard.total, a.dashboard.list.Index())
+	}
+
+	if m, _ = a.Update(switchTabMsg{tab: 5}); m.(App).activeTab != 0 {
+		t.Error("switched to a missing tab")
+	}
+}
@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"jet/internal/jira"
+	"jet/internal/timer"
+)
+
+// timerRefresh is how often the status bar re-reads the timer file, which
+// also picks up timers started or stopped with `jet timer` meanwhile.
+const timerRefresh = 30 * time.Second
+
+type toggleTimerMsg struct {
+	issueKey string
+}
+
+type timerTickMsg struct{}
+
+type timerLoadedMsg struct {
+	timer *timer.Timer
+}
+
+// timerToggledMsg reports the outcome of a toggle: the timer that was
+// stopped and logged, if any, and the one started, if any.
+type timerToggledMsg struct {
+	stopped *timer.StopResult
+	started *timer.Timer
+}
+
+func timerTick() tea.Cmd {
+	return tea.Tick(timerRefresh, func(time.Time) tea.Msg { return timerTickMsg{} })
+}
+
+// loadTimer reads the running timer from the store.
+func loadTimer(store *timer.Store) tea.Cmd {
+	return func() tea.Msg {
+		t, err := store.Load()
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return timerLoadedMsg{timer: t}
+	}
+}
+
+// toggleTimer stops the timer when it runs on issueKey, and otherwise starts
+// one on issueKey, first logging any timer running on another issue. The
+// store is re-read so a timer changed from the command line is respected.
+func toggleTimer(ctx context.Context, store *timer.Store, client *jira.Client, issueKey string) tea.Cmd {
+	return func() tea.Msg {
+		running, err := store.Load()
+		if err != nil {
+			return errMsg{err: err}
+		}
+		var msg timerToggledMsg
+		if running != nil {
+			msg.stopped, err = store.Stop(ctx, client, "", time.Now())
+			if err != nil {
+				return errMsg{err: err}
+			}
+			if running.IssueKey == issueKey {
+				return msg
+			}
+		}
+		msg.started, err = store.Start(issueKey, "", time.Now())
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return msg
+	}
+}
+
+// timerNotification describes a toggle for the status bar.
+func timerNotification(msg timerToggledMsg) string {
+	var s string
+	if res := msg.stopped; res != nil {
+		if res.Logged > 0 {
+			s = fmt.Sprintf("Logged %s on %s", jira.FormatDuration(res.Logged), res.Timer.IssueKey)
+		} else {
+			s = fmt.Sprintf("Timer on %s stopped, too short to log", res.Timer.IssueKey)
+		}
+	}
+	if msg.started != nil {
+		if s != "" {
+			s += "; "
+		}
+		s += fmt.Sprintf("Timer started on %s", msg.started.IssueKey)
+	}
+	return s
+}
+
+// timerBadge renders the running timer for the status bar, e.g. " [⏱ PROJ-1 1h05m] ".
+func timerBadge(t *timer.Timer, now time.Time) string {
+	if t == nil {
+		return ""
+	}
+	d := t.Elapsed(now)
+	elapsed := fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	return lipgloss.NewStyle().Foreground(colorGreen).Bold(true).
+		Render(fmt.Sprintf(" [⏱ %s %s] ", t.IssueKey, elapsed))
+}
@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"jet/internal/timer"
+)
+
+func TestToggleTimer(t *testing.T) {
+	store := &timer.Store{Path: filepath.Join(t.TempDir(), "timer.json"), Rounding: timer.DefaultRounding}
+
+	msg := toggleTimer(context.Background(), store, nil, "PROJ-1")()
+	toggled, ok := msg.(timerToggledMsg)
+	if !ok || toggled.started == nil || toggled.stopped != nil {
+		t.Fatalf("first toggle = %#v", msg)
+	}
+	if got := timerNotification(toggled); got != "Timer started on PROJ-1" {
+		t.Errorf("notification = %q", got)
+	}
+
+	// Stopping straight away is too short to log, so no worklog is posted.
+	msg = toggleTimer(context.Background(), store, nil, "PROJ-1")()
+	toggled, ok = msg.(timerToggledMsg)
+	if !ok || toggled.started != nil || toggled.stopped == nil {
+		t.Fatalf("second toggle = %#v", msg)
+	}
+	if got := timerNotification(toggled); !strings.Contains(got, "too short") {
+		t.Errorf("notification = %q", got)
+	}
+	if running, _ := store.Load(); running != nil {
+		t.Errorf("timer still running: %+v", running)
+	}
+}
+
+func TestTimerBadge(t *testing.T) {
+	if got := timerBadge(nil, time.Now()); got != "" {
+		t.Errorf("timerBadge(nil) = %q", got)
+	}
+	started := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	got := timerBadge(&timer.Timer{IssueKey: "PROJ-1", Started: started}, started.Add(65*time.Minute))
+	if !strings.Contains(got, "PROJ-1 1h05m") {
+		t.Errorf("timerBadge = %q", got)
+	}
+}
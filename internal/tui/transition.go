@@ -9,12 +9,16 @@ import (
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"jet/internal/bulk"
 	"jet/internal/jira"
 )
 
-// transitionItem wraps a jira.Transition for the list.
+// transitionItem wraps a jira.Transition for the list. For several issues,
+// offered counts those that can make the transition, out of of.
 type transitionItem struct {
 	transition jira.Transition
+	offered    int
+	of         int
 }
 
 func (t transitionItem) FilterValue() string { return t.transition.Name }
@@ -43,6 +47,10 @@ func (d transitionDelegate) Render(w io.Writer, m list.Model, index int, item li
 		style = lipgloss.NewStyle().Foreground(colorCyan).Bold(true)
 	}
 
+	if t.of > 0 {
+		fmt.Fprintf(w, "%s%s %s", cursor, style.Render(target), dimStyle.Render(fmt.Sprintf("(%d of %d)", t.offered, t.of)))
+		return
+	}
 	fmt.Fprintf(w, "%s%s %s", cursor, style.Render(name), dimStyle.Render("→ "+target))
 }
 
@@ -50,6 +58,7 @@ func (d transitionDelegate) Render(w io.Writer, m list.Model, index int, item li
 type TransitionModel struct {
 	list     list.Model
 	issueKey string
+	issues   []jira.Issue // set when transitioning several issues at once
 	loading  bool
 	spinner  spinner.Model
 	width    int
@@ -77,6 +86,15 @@ func NewTransitionModel(issueKey string) TransitionModel {
 	}
 }
 
+// NewBulkTransitionModel creates a picker of the statuses issues can move
+// to together.
+func NewBulkTransitionModel(issues []jira.Issue) TransitionModel {
+	t := NewTransitionModel("")
+	t.list.Title = fmt.Sprintf("Transition %d issues", len(issues))
+	t.issues = issues
+	return t
+}
+
 func (t TransitionModel) Init() tea.Cmd {
 	return t.spinner.Tick
 }
@@ -102,6 +120,18 @@ func (t TransitionModel) SetTransitions(transitions []jira.Transition, issueKey
 	return t
 }
 
+// SetBulkTransitions lists the statuses the issues can move to, with how
+// many of them can.
+func (t TransitionModel) SetBulkTransitions(msg bulkTransitionsLoadedMsg) TransitionModel {
+	items := make([]list.Item, len(msg.transitions))
+	for i, tr := range msg.transitions {
+		items[i] = transitionItem{transition: tr, offered: msg.offered[i], of: len(t.issues)}
+	}
+	t.list.SetItems(items)
+	t.loading = false
+	return t
+}
+
 func (t TransitionModel) Update(msg tea.Msg, client *jira.Client) (TransitionModel, tea.Cmd) {
 	var cmds []tea.Cmd
 
@@ -115,6 +145,10 @@ func (t TransitionModel) Update(msg tea.Msg, client *jira.Client) (TransitionMod
 			item := t.list.SelectedItem()
 			if item != nil {
 				tr := item.(transitionItem).transition
+				if t.issues != nil {
+					issues := t.issues
+					return t, func() tea.Msg { return bulkStartMsg{change: bulk.Shift(tr.To.Name), issues: issues} }
+				}
 				t.loading = true
 				return t, transitionIssueCmd(client, t.issueKey, tr.ID, tr.To.Name)
 			}
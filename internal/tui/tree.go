@@ -0,0 +1,361 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"jet/internal/graph"
+	"jet/internal/jira"
+)
+
+// treeOpenDepth is how many levels below the top start expanded; deeper
+// nodes open only on the way to the issue the tree was opened from.
+const treeOpenDepth = 2
+
+type navigateToTreeMsg struct {
+	key string
+}
+
+type treeLoadedMsg struct {
+	root *graph.TreeNode
+}
+
+// fetchHierarchy loads the whole hierarchy key belongs to.
+func fetchHierarchy(ctx context.Context, client *jira.Client, key string) tea.Cmd {
+	return func() tea.Msg {
+		top, err := graph.TopOf(ctx, client, key)
+		if err == nil {
+			var root *graph.TreeNode
+			root, err = graph.Tree(ctx, client, top, graph.TreeOptions{})
+			if err == nil {
+				return treeLoadedMsg{root: root}
+			}
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		return errMsg{err: err}
+	}
+}
+
+// treeRow is a visible line of the tree.
+type treeRow struct {
+	node   *graph.TreeNode
+	parent int    // row index of the parent, -1 for the top
+	prefix string // branch lines drawn before the node
+}
+
+// TreeModel shows the hierarchy an issue belongs to as a collapsible tree.
+type TreeModel struct {
+	key       string // the issue the tree was opened from
+	root      *graph.TreeNode
+	collapsed map[string]bool
+	rows      []treeRow
+
+	cursor, scroll int
+
+	loading bool
+	spinner spinner.Model
+
+	width, height int
+	fetches       *fetchTracker // shared with App; cancels superseded loads
+}
+
+// NewTreeModel creates a tree around the issue key.
+func NewTreeModel(key string) TreeModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = lipgloss.NewStyle().Foreground(colorCyan)
+	return TreeModel{key: key, loading: true, spinner: s, collapsed: map[string]bool{}}
+}
+
+func (m TreeModel) Init() tea.Cmd { return m.spinner.Tick }
+
+func (m TreeModel) SetSize(width, height int) TreeModel {
+	m.width = width
+	m.height = height
+	return m
+}
+
+// SetData shows a freshly loaded tree. On the first load, levels past
+// treeOpenDepth start collapsed except on the way to the tree's issue; a
+// reload keeps what the user opened and closed.
+func (m TreeModel) SetData(msg treeLoadedMsg) TreeModel {
+	selected := m.selectedKey()
+	first := m.root == nil
+	m.loading = false
+	m.root = msg.root
+	if first {
+		selected = m.key
+		onPath := pathTo(m.root, m.key)
+		m.root.Walk(func(n *graph.TreeNode, depth int) bool {
+			if len(n.Children) > 0 && depth >= treeOpenDepth && !onPath[n.Key] {
+				m.collapsed[n.Key] = true
+			}
+			return true
+		})
+	}
+	m.rebuild(selected)
+	return m
+}
+
+// pathTo returns the keys of the nodes above key.
+func pathTo(root *graph.TreeNode, key string) map[string]bool {
+	path := map[string]bool{}
+	var find func(n *graph.TreeNode) bool
+	find = func(n *graph.TreeNode) bool {
+		if n.Key == key {
+			return true
+		}
+		for _, c := range n.Children {
+			if find(c) {
+				path[n.Key] = true
+				return true
+			}
+		}
+		return false
+	}
+	find(root)
+	return path
+}
+
+// rebuild lists the visible rows and puts the cursor on key if shown.
+func (m *TreeModel) rebuild(key string) {
+	m.rows = nil
+	var add func(n *graph.TreeNode, parent int, prefix, branch string)
+	add = func(n *graph.TreeNode, parent int, prefix, branch string) {
+		idx := len(m.rows)
+		m.rows = append(m.rows, treeRow{node: n, parent: parent, prefix: prefix + branch})
+		if m.collapsed[n.Key] {
+			return
+		}
+		childPrefix := prefix
+		switch branch {
+		case "├─ ":
+			childPrefix += "│  "
+		case "└─ ":
+			childPrefix += "   "
+		}
+		for i, c := range n.Children {
+			b := "├─ "
+			if i == len(n.Children)-1 {
+				b = "└─ "
+			}
+			add(c, idx, childPrefix, b)
+		}
+	}
+	if m.root != nil {
+		add(m.root, -1, "", "")
+	}
+	for i, r := range m.rows {
+		if r.node.Key == key {
+			m.cursor = i
+		}
+	}
+	m.cursor = max(0, min(m.cursor, len(m.rows)-1))
+}
+
+func (m TreeModel) selected() *treeRow {
+	if m.cursor < len(m.rows) {
+		return &m.rows[m.cursor]
+	}
+	return nil
+}
+
+func (m TreeModel) selectedKey() string {
+	if r := m.selected(); r != nil {
+		return r.node.Key
+	}
+	return ""
+}
+
+// Failed stops waiting on a load after the App has shown its error.
+func (m TreeModel) Failed() TreeModel {
+	m.loading = false
+	return m
+}
+
+// setAll collapses or expands every node with children.
+func (m *TreeModel) setAll(collapse bool) {
+	if m.root == nil {
+		return
+	}
+	m.root.Walk(func(n *graph.TreeNode, depth int) bool {
+		if len(n.Children) > 0 && depth > 0 {
+			m.collapsed[n.Key] = collapse
+		}
+		return true
+	})
+}
+
+func (m TreeModel) Update(msg tea.Msg, client *jira.Client) (TreeModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		row := m.selected()
+		switch {
+		case key.Matches(msg, treeKeys.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case key.Matches(msg, treeKeys.Down):
+			if m.cursor < len(m.rows)-1 {
+				m.cursor++
+			}
+		case key.Matches(msg, treeKeys.Toggle):
+			if row != nil && len(row.node.Children) > 0 {
+				m.collapsed[row.node.Key] = !m.collapsed[row.node.Key]
+				m.rebuild(row.node.Key)
+			}
+		case key.Matches(msg, treeKeys.Expand):
+			if row != nil && m.collapsed[row.node.Key] {
+				m.collapsed[row.node.Key] = false
+				m.rebuild(row.node.Key)
+			} else if row != nil && len(row.node.Children) > 0 && m.cursor < len(m.rows)-1 {
+				m.cursor++
+			}
+		case key.Matches(msg, treeKeys.Collapse):
+			// Close an open node, or else go up to its parent.
+			if row != nil && len(row.node.Children) > 0 && !m.collapsed[row.node.Key] {
+				m.collapsed[row.node.Key] = true
+				m.rebuild(row.node.Key)
+			} else if row != nil && row.parent >= 0 {
+				m.cursor = row.parent
+			}
+		case key.Matches(msg, treeKeys.ExpandAll):
+			m.setAll(false)
+			m.rebuild(m.selectedKey())
+		case key.Matches(msg, treeKeys.CollapseAll):
+			// Only the top two levels stay; move up to what is left.
+			sel := m.selectedKey()
+			for r := row; r != nil && r.parent > 0; r = &m.rows[r.parent] {
+				sel = m.rows[r.parent].node.Key
+			}
+			m.setAll(true)
+			m.rebuild(sel)
+		case key.Matches(msg, treeKeys.Enter):
+			if row != nil {
+				k := row.node.Key
+				return m, func() tea.Msg { return navigateToDetailMsg{key: k} }
+			}
+		case key.Matches(msg, treeKeys.Refresh):
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, fetchHierarchy(m.fetches.start(fetchTree), client, m.key))
+		case key.Matches(msg, globalKeys.Back):
+			return m, func() tea.Msg { return goBackMsg{} }
+		}
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+func (m TreeModel) View() string {
+	if m.root == nil {
+		if !m.loading {
+			return dimStyle.Render("  No hierarchy to show.")
+		}
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+			m.spinner.View()+" Loading hierarchy...")
+	}
+
+	title := "Hierarchy: " + m.root.Key + " " + m.root.Summary
+	info := fmt.Sprintf("%d issues · %d/%d done", m.root.Total+1, m.root.Done, m.root.Total)
+	if m.loading {
+		info = m.spinner.View() + " Refreshing · " + info
+	}
+	header := []string{
+		titleStyle.Render(truncateRunes(title, m.width)),
+		subtitleStyle.Render(truncateRunes(info, m.width)),
+		"",
+	}
+
+	var body []string
+	for i, r := range m.rows {
+		body = append(body, m.renderRow(r, i == m.cursor))
+	}
+
+	// Scroll to keep the cursor in view.
+	avail := max(1, m.height-len(header))
+	scroll := m.scroll
+	if m.cursor < scroll {
+		scroll = m.cursor
+	}
+	if m.cursor >= scroll+avail {
+		scroll = m.cursor - avail + 1
+	}
+	scroll = max(0, min(scroll, len(body)-avail))
+	body = body[scroll:min(len(body), scroll+avail)]
+
+	return strings.Join(append(header, body...), "\n")
+}
+
+func (m TreeModel) renderRow(r treeRow, selected bool) string {
+	n := r.node
+	marker := "  "
+	if len(n.Children) > 0 {
+		marker = "▾ "
+		if m.collapsed[n.Key] {
+			marker = "▸ "
+		}
+	}
+
+	keyStyle := lipgloss.NewStyle().Foreground(colorCyan)
+	if n.Key == m.key {
+		keyStyle = keyStyle.Bold(true).Underline(true)
+	}
+	var b strings.Builder
+	b.WriteString(dimStyle.Render(r.prefix + marker))
+	b.WriteString(keyStyle.Render(n.Key))
+	if n.Type != "" {
+		b.WriteString(" " + IssueTypeStyle(n.Type).Render("["+n.Type+"]"))
+	}
+
+	// The summary gets what the status, assignee and progress leave.
+	tail := " " + StatusStyle(n.Status).Render(n.Status)
+	tailWidth := 1 + len([]rune(n.Status))
+	if n.Assignee != "" {
+		tail += dimStyle.Render(" · " + n.Assignee)
+		tailWidth += 3 + len([]rune(n.Assignee))
+	}
+	if n.Total > 0 {
+		progress := treeProgress(n.Done, n.Total)
+		style := lipgloss.NewStyle().Foreground(colorYellow)
+		if n.Done == n.Total {
+			style = lipgloss.NewStyle().Foreground(colorGreen)
+		}
+		tail += " " + style.Render(progress)
+		tailWidth += 1 + len([]rune(progress))
+	}
+	used := len([]rune(r.prefix+marker+n.Key)) + 1
+	if n.Type != "" {
+		used += len([]rune(n.Type)) + 3
+	}
+	if room := m.width - used - tailWidth - 1; room > 8 {
+		b.WriteString(" " + truncateRunes(n.Summary, room))
+	}
+	b.WriteString(tail)
+
+	line := b.String()
+	if selected {
+		return lipgloss.NewStyle().Background(lipgloss.Color("236")).Bold(true).Render(line)
+	}
+	return line
+}
+
+// treeProgress renders done/total as a short bar and count.
+func treeProgress(done, total int) string {
+	const width = 8
+	filled := done * width / total
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + fmt.Sprintf(" %d/%d", done, total)
+}
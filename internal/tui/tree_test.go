@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"jet/internal/graph"
+)
+
+// visibleKeys lists the keys of the visible rows.
+func visibleKeys(m TreeModel) string {
+	var keys []string
+	for _, r := range m.rows {
+		keys = append(keys, r.node.Key)
+	}
+	return strings.Join(keys, " ")
+}
+
+func press(m TreeModel, keys ...string) TreeModel {
+	for _, k := range keys {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		if k == "space" {
+			msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+		}
+		m, _ = m.Update(msg, nil)
+	}
+	return m
+}
+
+func TestTreeModel(t *testing.T) {
+	leaf := func(key string) *graph.TreeNode { return &graph.TreeNode{Key: key} }
+	root := &graph.TreeNode{Key: "P-1", Children: []*graph.TreeNode{
+		{Key: "P-2", Children: []*graph.TreeNode{
+			{Key: "P-4", Children: []*graph.TreeNode{leaf("P-6")}},
+			{Key: "P-5", Children: []*graph.TreeNode{leaf("P-7")}},
+		}},
+		leaf("P-3"),
+	}}
+
+	// Deep levels start collapsed, except on the way to the tree's issue.
+	m := NewTreeModel("P-7").SetSize(80, 20).SetData(treeLoadedMsg{root: root})
+	if got, want := visibleKeys(m), "P-1 P-2 P-4 P-5 P-7 P-3"; got != want {
+		t.Fatalf("rows = %s, want %s", got, want)
+	}
+	if m.selectedKey() != "P-7" {
+		t.Errorf("cursor on %s, want P-7", m.selectedKey())
+	}
+
+	// h goes up to the parent, then closes it.
+	m = press(m, "h")
+	if m.selectedKey() != "P-5" {
+		t.Errorf("h: cursor on %s", m.selectedKey())
+	}
+	m = press(m, "h")
+	if got, want := visibleKeys(m), "P-1 P-2 P-4 P-5 P-3"; got != want {
+		t.Errorf("after closing P-5: %s, want %s", got, want)
+	}
+
+	m = press(m, "k", "space")
+	if got, want := visibleKeys(m), "P-1 P-2 P-4 P-6 P-5 P-3"; got != want {
+		t.Errorf("after toggling P-4: %s, want %s", got, want)
+	}
+
+	m = press(m, "-")
+	if got, want := visibleKeys(m), "P-1 P-2 P-3"; got != want {
+		t.Errorf("collapse all: %s, want %s", got, want)
+	}
+	if m.selectedKey() != "P-2" {
+		t.Errorf("collapse all: cursor on %s, want P-2", m.selectedKey())
+	}
+	m = press(m, "+")
+	if got, want := visibleKeys(m), "P-1 P-2 P-4 P-6 P-5 P-7 P-3"; got != want {
+		t.Errorf("expand all: %s, want %s", got, want)
+	}
+
+	if v := m.View(); !strings.Contains(v, "└─ ▾ P-5") || !strings.Contains(v, "P-7") {
+		t.Errorf("view:\n%s", v)
+	}
+
+	// A reload keeps what was opened and closed.
+	m = press(m, "space")
+	m = m.SetData(treeLoadedMsg{root: root})
+	if got, want := visibleKeys(m), "P-1 P-2 P-3"; got != want {
+		t.Errorf("after reload: %s, want %s", got, want)
+	}
+}
+
+func TestTreeProgress(t *testing.T) {
+	if got := treeProgress(1, 4); got != "██░░░░░░ 1/4" {
+		t.Errorf("treeProgress(1, 4) = %q", got)
+	}
+}